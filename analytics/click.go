@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ClickPath is the path of the link to a search result which records the click before redirecting to the result
+const ClickPath = "/search/click"
+
+// Query parameters of a click link
+const (
+	clickURIParam       = "uri"
+	clickQueryParam     = "q"
+	clickRankParam      = "rank"
+	clickPageParam      = "page"
+	clickTemplateParam  = "template"
+	clickLangParam      = "lang"
+	clickSignatureParam = "sig"
+)
+
+// ErrInvalidClick is returned for a click link which has not been signed by the recorder, or which does not link to
+// a page of the site, so that the link cannot be used to redirect users elsewhere
+var ErrInvalidClick = errors.New("click link is invalid")
+
+// TracksClicks returns true if the recorder signs click links
+func (r *Recorder) TracksClicks() bool {
+	return r != nil && r.clickKey != nil
+}
+
+// ClickURL returns the signed link to the search result of the event, which records the click when followed, or the
+// uri of the result if clicks are not tracked. The query of the event should already be normalised
+func (r *Recorder) ClickURL(event Event) string {
+	if !r.TracksClicks() {
+		return event.URI
+	}
+
+	values := url.Values{
+		clickURIParam:      {event.URI},
+		clickQueryParam:    {event.Query},
+		clickRankParam:     {strconv.Itoa(event.Rank)},
+		clickPageParam:     {strconv.Itoa(event.Page)},
+		clickTemplateParam: {event.Template},
+		clickLangParam:     {event.Lang},
+	}
+	values.Set(clickSignatureParam, r.sign(values))
+
+	return ClickPath + "?" + values.Encode()
+}
+
+// ParseClick returns the click event of a click link, or ErrInvalidClick if it has not been signed by the recorder or
+// does not link to a page of the site
+func (r *Recorder) ParseClick(values url.Values) (Event, error) {
+	if !r.TracksClicks() {
+		return Event{}, ErrInvalidClick
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(values.Get(clickSignatureParam))
+	if err != nil {
+		return Event{}, ErrInvalidClick
+	}
+	expected, _ := base64.RawURLEncoding.DecodeString(r.sign(values))
+	if !hmac.Equal(signature, expected) {
+		return Event{}, ErrInvalidClick
+	}
+
+	uri := values.Get(clickURIParam)
+	if !isSitePath(uri) {
+		return Event{}, ErrInvalidClick
+	}
+
+	rank, _ := strconv.Atoi(values.Get(clickRankParam))
+	page, _ := strconv.Atoi(values.Get(clickPageParam))
+
+	return Event{
+		Type:     EventClick,
+		Template: values.Get(clickTemplateParam),
+		Query:    values.Get(clickQueryParam),
+		Page:     page,
+		Lang:     values.Get(clickLangParam),
+		URI:      uri,
+		Rank:     rank,
+	}, nil
+}
+
+// sign returns the signature of the parameters of a click link
+func (r *Recorder) sign(values url.Values) string {
+	mac := hmac.New(sha256.New, r.clickKey)
+	for _, param := range []string{clickURIParam, clickQueryParam, clickRankParam, clickPageParam, clickTemplateParam, clickLangParam} {
+		mac.Write([]byte(values.Get(param)))
+		mac.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// isSitePath returns true if the uri is a path of the site, rather than a link to another host
+func isSitePath(uri string) bool {
+	return strings.HasPrefix(uri, "/") && !strings.HasPrefix(uri, "//") && !strings.ContainsAny(uri, "\\\r\n")
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClickURL(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a recorder which tracks clicks", t, func() {
+		recorder := NewRecorder(&fakeSink{}, Config{ClickSecret: "secret"})
+		click := Event{URI: "/economy/inflation", Query: "cpi", Rank: 12, Page: 2, Template: "search", Lang: "en"}
+
+		Convey("When the click link of a search result is created", func() {
+			link := recorder.ClickURL(click)
+
+			Convey("Then it links to the click path", func() {
+				So(recorder.TracksClicks(), ShouldBeTrue)
+				So(strings.HasPrefix(link, ClickPath+"?"), ShouldBeTrue)
+			})
+
+			Convey("Then the click parsed from it is the search result clicked", func() {
+				parsedURL, err := url.Parse(link)
+				So(err, ShouldBeNil)
+
+				event, err := recorder.ParseClick(parsedURL.Query())
+				So(err, ShouldBeNil)
+				So(event, ShouldResemble, Event{
+					Type: EventClick, URI: "/economy/inflation", Query: "cpi", Rank: 12, Page: 2, Template: "search", Lang: "en",
+				})
+			})
+
+			Convey("Then a click parsed from it once changed is invalid", func() {
+				parsedURL, err := url.Parse(link)
+				So(err, ShouldBeNil)
+				values := parsedURL.Query()
+				values.Set("uri", "/somewhere-else")
+
+				_, err = recorder.ParseClick(values)
+				So(err, ShouldEqual, ErrInvalidClick)
+			})
+		})
+
+		Convey("When the click link of a result on another site is followed", func() {
+			click.URI = "//example.com/phishing"
+			parsedURL, err := url.Parse(recorder.ClickURL(click))
+			So(err, ShouldBeNil)
+
+			_, err = recorder.ParseClick(parsedURL.Query())
+
+			Convey("Then it is invalid, even though it is signed", func() {
+				So(err, ShouldEqual, ErrInvalidClick)
+			})
+		})
+
+		Convey("When a click link signed with another secret is followed", func() {
+			other := NewRecorder(&fakeSink{}, Config{ClickSecret: "other"})
+			parsedURL, err := url.Parse(other.ClickURL(click))
+			So(err, ShouldBeNil)
+
+			_, err = recorder.ParseClick(parsedURL.Query())
+
+			Convey("Then it is invalid", func() {
+				So(err, ShouldEqual, ErrInvalidClick)
+			})
+		})
+	})
+
+	Convey("Given a recorder which does not track clicks", t, func() {
+		recorder := NewRecorder(&fakeSink{}, Config{})
+
+		Convey("Then the link of a search result is its uri", func() {
+			So(recorder.TracksClicks(), ShouldBeFalse)
+			So(recorder.ClickURL(Event{URI: "/economy"}), ShouldEqual, "/economy")
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package analytics
+
+import "time"
+
+// Types of event
+const (
+	EventSearch = "search"
+	EventClick  = "click"
+)
+
+// Event is a search made, or a search result clicked, by a user. The query is normalised and scrubbed of personal
+// data before the event is recorded
+type Event struct {
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	Template string    `json:"template,omitempty"`
+	Query    string    `json:"query"`
+	// Filters are the filters and sort applied to the search, by name
+	Filters     map[string]string `json:"filters,omitempty"`
+	ResultCount int               `json:"result_count,omitempty"`
+	Page        int               `json:"page,omitempty"`
+	Lang        string            `json:"lang,omitempty"`
+	// LatencyMS is the time taken by the search api to return the results, in milliseconds
+	LatencyMS int64 `json:"latency_ms,omitempty"`
+	// URI is the search result clicked
+	URI string `json:"uri,omitempty"`
+	// Rank is the position of the search result clicked across every page of the results, starting at 1
+	Rank int `json:"rank,omitempty"`
+}
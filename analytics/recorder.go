@@ -0,0 +1,167 @@
+package analytics
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// bufferedBatches is the number of batches of events held while the sink is busy, after which events are dropped
+// rather than holding up requests
+const bufferedBatches = 10
+
+// Config is the configuration of a recorder
+type Config struct {
+	// BatchSize is the number of events sent to the sink at once
+	BatchSize int
+	// FlushInterval is the longest an event waits before being sent, however few events are in its batch
+	FlushInterval time.Duration
+	// SampleRate is the fraction of searches recorded, from 0 to 1. Every click is recorded as clicks are far fewer
+	SampleRate float64
+	// ClickSecret is the key the click links are signed with, clicks are not tracked if it is empty
+	ClickSecret string
+}
+
+// Recorder records search analytics events, sending them to its sink in batches in the background
+type Recorder struct {
+	sink     Sink
+	cfg      Config
+	clickKey []byte
+	events   chan Event
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce *sync.Once
+	started  *atomic.Bool
+	dropped  *atomic.Int64
+	now      func() time.Time
+	sample   func() float64
+}
+
+// NewRecorder creates a recorder which sends events to the sink. Start must be called for events to be sent
+func NewRecorder(sink Sink, cfg Config) *Recorder {
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = 1
+	}
+
+	r := &Recorder{
+		sink:     sink,
+		cfg:      cfg,
+		events:   make(chan Event, cfg.BatchSize*bufferedBatches),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		stopOnce: &sync.Once{},
+		started:  &atomic.Bool{},
+		dropped:  &atomic.Int64{},
+		now:      time.Now,
+		sample:   rand.Float64, //nolint:gosec // sampling does not need a secure random number
+	}
+	if cfg.ClickSecret != "" {
+		r.clickKey = []byte(cfg.ClickSecret)
+	}
+
+	return r
+}
+
+// Record queues the event to be sent, unless it is a search which is not sampled. The event is dropped if the queue
+// is full so that a slow sink never holds up a request
+func (r *Recorder) Record(event Event) {
+	if r == nil {
+		return
+	}
+
+	if event.Type == EventSearch && r.sample() >= r.cfg.SampleRate {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = r.now()
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full
+func (r *Recorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Start sends the queued events to the sink in the background until the recorder is closed
+func (r *Recorder) Start(ctx context.Context) {
+	if r.started.CompareAndSwap(false, true) {
+		go r.run(ctx)
+	}
+}
+
+func (r *Recorder) run(ctx context.Context) {
+	defer close(r.done)
+
+	flushInterval := r.cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, r.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.sink.Send(ctx, batch); err != nil {
+			log.Warn(ctx, "failed to send search analytics events", log.FormatErrors([]error{err}), log.Data{"events": len(batch)})
+		}
+		batch = make([]Event, 0, r.cfg.BatchSize)
+	}
+	add := func(event Event) {
+		batch = append(batch, event)
+		if len(batch) >= r.cfg.BatchSize {
+			flush()
+		}
+	}
+
+	for {
+		select {
+		case event := <-r.events:
+			add(event)
+		case <-ticker.C:
+			flush()
+		case <-r.stop:
+			for {
+				select {
+				case event := <-r.events:
+					add(event)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close sends the queued events to the sink and closes it, waiting until the context is done at most
+func (r *Recorder) Close(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	if r.started.Load() {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if dropped := r.Dropped(); dropped > 0 {
+		log.Warn(ctx, "search analytics events were dropped as the queue was full", log.Data{"dropped": dropped})
+	}
+
+	return r.sink.Close()
+}
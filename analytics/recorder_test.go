@@ -0,0 +1,144 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeSink is a sink which keeps the batches sent to it
+type fakeSink struct {
+	mutex   sync.Mutex
+	batches [][]Event
+	closed  bool
+}
+
+func (s *fakeSink) Send(_ context.Context, events []Event) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) sent() [][]Event {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.batches
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a recorder which sends batches of two events", t, func() {
+		sink := &fakeSink{}
+		recorder := NewRecorder(sink, Config{BatchSize: 2, FlushInterval: time.Hour, SampleRate: 1})
+		recorder.Start(ctx)
+
+		Convey("When three events are recorded and the recorder is closed", func() {
+			recorder.Record(Event{Type: EventSearch, Query: "inflation"})
+			recorder.Record(Event{Type: EventSearch, Query: "gdp"})
+			recorder.Record(Event{Type: EventClick, URI: "/economy"})
+
+			So(recorder.Close(ctx), ShouldBeNil)
+
+			Convey("Then a full batch is sent followed by the remaining event, and the sink is closed", func() {
+				batches := sink.sent()
+				So(batches, ShouldHaveLength, 2)
+				So(batches[0], ShouldHaveLength, 2)
+				So(batches[0][0].Query, ShouldEqual, "inflation")
+				So(batches[0][0].Time.IsZero(), ShouldBeFalse)
+				So(batches[1], ShouldHaveLength, 1)
+				So(batches[1][0].URI, ShouldEqual, "/economy")
+				So(sink.closed, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a recorder which flushes its events every 10ms", t, func() {
+		sink := &fakeSink{}
+		recorder := NewRecorder(sink, Config{BatchSize: 100, FlushInterval: 10 * time.Millisecond, SampleRate: 1})
+		recorder.Start(ctx)
+		defer recorder.Close(ctx)
+
+		Convey("When fewer events than a batch are recorded", func() {
+			recorder.Record(Event{Type: EventSearch, Query: "inflation"})
+
+			Convey("Then they are sent once the flush interval has passed", func() {
+				So(func() bool {
+					deadline := time.Now().Add(time.Second)
+					for time.Now().Before(deadline) {
+						if len(sink.sent()) == 1 {
+							return true
+						}
+						time.Sleep(5 * time.Millisecond)
+					}
+					return false
+				}(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a recorder which samples half of the searches", t, func() {
+		sink := &fakeSink{}
+		recorder := NewRecorder(sink, Config{BatchSize: 10, SampleRate: 0.5})
+		samples := []float64{0.2, 0.7}
+		recorder.sample = func() float64 {
+			sample := samples[0]
+			samples = samples[1:]
+			return sample
+		}
+		recorder.Start(ctx)
+
+		Convey("When searches and a click are recorded", func() {
+			recorder.Record(Event{Type: EventSearch, Query: "sampled"})
+			recorder.Record(Event{Type: EventSearch, Query: "not sampled"})
+			recorder.Record(Event{Type: EventClick, URI: "/economy"})
+			So(recorder.Close(ctx), ShouldBeNil)
+
+			Convey("Then only the sampled search and the click are sent", func() {
+				batches := sink.sent()
+				So(batches, ShouldHaveLength, 1)
+				So(batches[0], ShouldHaveLength, 2)
+				So(batches[0][0].Query, ShouldEqual, "sampled")
+				So(batches[0][1].Type, ShouldEqual, EventClick)
+			})
+		})
+	})
+
+	Convey("Given a recorder which has not been started", t, func() {
+		recorder := NewRecorder(&fakeSink{}, Config{BatchSize: 1, SampleRate: 1})
+
+		Convey("When more events are recorded than can be queued", func() {
+			for range bufferedBatches + 1 {
+				recorder.Record(Event{Type: EventClick, URI: "/economy"})
+			}
+
+			Convey("Then the events which do not fit are dropped rather than blocking", func() {
+				So(recorder.Dropped(), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given no recorder", t, func() {
+		var recorder *Recorder
+
+		Convey("Then recording an event does nothing", func() {
+			So(func() { recorder.Record(Event{Type: EventSearch}) }, ShouldNotPanic)
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"regexp"
+	"strings"
+)
+
+// minAccountDigits and minPhoneDigits are the digits in a number, or group of numbers, from which it is scrubbed as it
+// may be an account or phone number. Years, and ranges of them, have fewer
+const (
+	minAccountDigits = 7
+	minPhoneDigits   = 10
+)
+
+// numberPattern matches a number, or numbers separated by single spaces or dashes as phone numbers are written
+var numberPattern = regexp.MustCompile(`\+?\d+(?:[ \-]\d+)*`)
+
+// piiPatterns are the personal data which may be typed into a search, replaced by a placeholder
+var piiPatterns = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`), "[email]"},
+	{regexp.MustCompile(`\b[a-z]{2}\s?\d{2}\s?\d{2}\s?\d{2}\s?[a-d]\b`), "[ni-number]"},
+	{regexp.MustCompile(`\b[a-z]{1,2}\d[a-z\d]?\s?\d[a-z]{2}\b`), "[postcode]"},
+}
+
+// NormaliseQuery returns the search query in lower case with its whitespace collapsed, and any email address,
+// national insurance number, postcode or long number replaced by a placeholder, so that it can be recorded
+func NormaliseQuery(q string) string {
+	q = strings.Join(strings.Fields(strings.ToLower(q)), " ")
+
+	for _, pii := range piiPatterns {
+		q = pii.pattern.ReplaceAllString(q, pii.placeholder)
+	}
+
+	return numberPattern.ReplaceAllStringFunc(q, scrubNumber)
+}
+
+func scrubNumber(number string) string {
+	digits := 0
+	for _, group := range strings.FieldsFunc(number, func(r rune) bool { return r < '0' || r > '9' }) {
+		if len(group) >= minAccountDigits {
+			return "[number]"
+		}
+		digits += len(group)
+	}
+
+	if digits >= minPhoneDigits {
+		return "[number]"
+	}
+	return number
+}
@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNormaliseQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given search queries typed by users", t, func() {
+		Convey("Then the case and whitespace are normalised", func() {
+			So(NormaliseQuery("  Consumer   Price\tInflation "), ShouldEqual, "consumer price inflation")
+		})
+
+		Convey("Then years and CDIDs are kept", func() {
+			So(NormaliseQuery("census 2011 2021"), ShouldEqual, "census 2011 2021")
+			So(NormaliseQuery("CPIH D7BT"), ShouldEqual, "cpih d7bt")
+		})
+
+		Convey("Then email addresses are scrubbed", func() {
+			So(NormaliseQuery("contact Jane.Doe@example.com about"), ShouldEqual, "contact [email] about")
+		})
+
+		Convey("Then national insurance numbers are scrubbed", func() {
+			So(NormaliseQuery("QQ 12 34 56 C"), ShouldEqual, "[ni-number]")
+		})
+
+		Convey("Then postcodes are scrubbed", func() {
+			So(NormaliseQuery("population SW1A 1AA"), ShouldEqual, "population [postcode]")
+		})
+
+		Convey("Then phone and account numbers are scrubbed", func() {
+			So(NormaliseQuery("call 07700 900123"), ShouldEqual, "call [number]")
+			So(NormaliseQuery("020-7946-0958"), ShouldEqual, "[number]")
+			So(NormaliseQuery("account 12345678"), ShouldEqual, "account [number]")
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Names of the sinks which events can be sent to
+const (
+	SinkLog  = "log"
+	SinkFile = "file"
+	SinkHTTP = "http"
+)
+
+// Sink is where batches of events are sent, e.g. a log, a file or a collector
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// LogSink writes each event to the log of the service
+type LogSink struct{}
+
+// Send logs each event of the batch
+func (LogSink) Send(ctx context.Context, events []Event) error {
+	for i := range events {
+		log.Info(ctx, "search analytics event", log.Data{"event": events[i]})
+	}
+	return nil
+}
+
+// Close does nothing as the log is not owned by the sink
+func (LogSink) Close() error {
+	return nil
+}
+
+// FileSink appends each event to a file as a line of JSON
+type FileSink struct {
+	mutex *sync.Mutex
+	file  *os.File
+}
+
+// NewFileSink opens, or creates, the file the events are appended to
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // the path is from the config of the service
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics file: %w", err)
+	}
+
+	return &FileSink{mutex: &sync.Mutex{}, file: file}, nil
+}
+
+// Send appends the events of the batch to the file
+func (s *FileSink) Send(_ context.Context, events []Event) error {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	for i := range events {
+		if err := encoder.Encode(events[i]); err != nil {
+			return fmt.Errorf("failed to encode analytics event: %w", err)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.file.Write(b.Bytes()); err != nil {
+		return fmt.Errorf("failed to write analytics events: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.file.Close()
+}
+
+// HTTPSink posts each batch of events to a collector as a JSON array, e.g. an analytics service or the REST proxy of
+// a Kafka topic
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink creates a sink which posts batches of events to the url, giving up on a post after the timeout
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Send posts the events of the batch to the collector
+func (s *HTTPSink) Send(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode analytics events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create analytics request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post analytics events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("analytics collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close does nothing as each post is its own request
+func (s *HTTPSink) Close() error {
+	return nil
+}
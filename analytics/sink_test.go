@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a file sink", t, func() {
+		path := filepath.Join(t.TempDir(), "analytics.jsonl")
+		sink, err := NewFileSink(path)
+		So(err, ShouldBeNil)
+
+		Convey("When batches of events are sent", func() {
+			So(sink.Send(context.Background(), []Event{{Type: EventSearch, Query: "inflation"}}), ShouldBeNil)
+			So(sink.Send(context.Background(), []Event{{Type: EventClick, URI: "/economy"}}), ShouldBeNil)
+			So(sink.Close(), ShouldBeNil)
+
+			Convey("Then each event is appended to the file as a line of JSON", func() {
+				b, err := os.ReadFile(path)
+				So(err, ShouldBeNil)
+
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				So(lines, ShouldHaveLength, 2)
+
+				var event Event
+				So(json.Unmarshal([]byte(lines[1]), &event), ShouldBeNil)
+				So(event.URI, ShouldEqual, "/economy")
+			})
+		})
+	})
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an http sink posting to a collector", t, func() {
+		var received []Event
+		status := http.StatusAccepted
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_ = json.NewDecoder(req.Body).Decode(&received)
+			w.WriteHeader(status)
+		}))
+		defer collector.Close()
+
+		sink := NewHTTPSink(collector.URL, time.Second)
+
+		Convey("When a batch of events is sent", func() {
+			err := sink.Send(context.Background(), []Event{{Type: EventSearch, Query: "inflation"}, {Type: EventSearch, Query: "gdp"}})
+
+			Convey("Then it is posted as a JSON array", func() {
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 2)
+				So(received[1].Query, ShouldEqual, "gdp")
+			})
+		})
+
+		Convey("When the collector fails", func() {
+			status = http.StatusInternalServerError
+			err := sink.Send(context.Background(), []Event{{Type: EventSearch}})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
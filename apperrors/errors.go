@@ -15,13 +15,17 @@ var (
 	ErrPageExceedsTotalPages        = errors.New("invalid page value, exceeding the total page value")
 	ErrTopicNotFound                = errors.New("topic not found")
 	ErrTopicPathNotFound            = errors.New("topic path not found")
+	ErrInvalidEditionComparison     = errors.New("two different editions of the release must be chosen to compare")
+	ErrInvalidClickLink             = errors.New("search result click link is invalid")
 
 	BadRequestMap = map[error]bool{
-		ErrContentTypeNotFound:   true,
-		ErrInvalidPage:           true,
-		ErrInvalidQueryString:    true,
-		ErrPageExceedsTotalPages: true,
-		ErrTopicNotFound:         true,
+		ErrContentTypeNotFound:      true,
+		ErrInvalidPage:              true,
+		ErrInvalidQueryString:       true,
+		ErrPageExceedsTotalPages:    true,
+		ErrTopicNotFound:            true,
+		ErrInvalidEditionComparison: true,
+		ErrInvalidClickLink:         true,
 	}
 
 	NotFoundMap = map[error]bool{
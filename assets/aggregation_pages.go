@@ -0,0 +1,8 @@
+package assets
+
+import _ "embed" // required to embed the default aggregation pages
+
+// AggregationPages is the default registry of the data aggregation pages in JSON
+//
+//go:embed aggregation-pages.json
+var AggregationPages []byte
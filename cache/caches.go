@@ -1,8 +1,66 @@
 package cache
 
+import (
+	"context"
+	"time"
+)
+
 // CacheList is a list of caches for the dp-frontend-search-controller
 type List struct {
 	CensusTopic *TopicCache
 	DataTopic   *TopicCache
 	Navigation  *NavigationCache
 }
+
+// IsReady returns true once the topic caches in the list have been successfully populated for the first time.
+// The navigation cache is not required as pages are rendered without navigation until it is populated
+func (l *List) IsReady() bool {
+	if l.CensusTopic != nil && !l.CensusTopic.IsReady() {
+		return false
+	}
+	if l.DataTopic != nil && !l.DataTopic.IsReady() {
+		return false
+	}
+
+	return true
+}
+
+// EnableSnapshots enables each cache in the list to write snapshots of its data to the directory `dir` on every
+// successful update, so that the data can be loaded on startup if the snapshots are not older than maxAge
+func (l *List) EnableSnapshots(dir string, maxAge time.Duration) {
+	if l.CensusTopic != nil {
+		l.CensusTopic.EnableSnapshot(dir, "census-topic-cache", maxAge)
+	}
+	if l.DataTopic != nil {
+		l.DataTopic.EnableSnapshot(dir, "data-topic-cache", maxAge)
+	}
+	if l.Navigation != nil {
+		l.Navigation.EnableSnapshot(dir, NavigationCacheKey, maxAge)
+	}
+}
+
+// EnableMetrics enables each cache in the list to record the metrics of its lookups and refreshes
+func (l *List) EnableMetrics() {
+	if l.CensusTopic != nil {
+		l.CensusTopic.EnableMetrics("census_topic")
+	}
+	if l.DataTopic != nil {
+		l.DataTopic.EnableMetrics("data_topic")
+	}
+	if l.Navigation != nil {
+		l.Navigation.EnableMetrics("navigation")
+	}
+}
+
+// LoadSnapshots populates each cache in the list from its snapshots, if snapshots are enabled
+func (l *List) LoadSnapshots(ctx context.Context) {
+	if l.CensusTopic != nil {
+		l.CensusTopic.LoadSnapshot(ctx)
+	}
+	if l.DataTopic != nil {
+		l.DataTopic.LoadSnapshot(ctx)
+	}
+	if l.Navigation != nil {
+		l.Navigation.LoadSnapshot(ctx)
+	}
+}
@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/resilience"
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// CrawlConfig contains the settings used to crawl the topic tree when updating a topic cache
+type CrawlConfig struct {
+	// Workers is the maximum number of topics fetched concurrently
+	Workers int
+	// CallTimeout is the timeout of each call made to fetch a topic
+	CallTimeout time.Duration
+	// MaxRetries is the number of times a failed call is retried
+	MaxRetries int
+	// RetryBackoff is the time to wait before the first retry, which doubles on every subsequent retry
+	RetryBackoff time.Duration
+}
+
+// CrawlTask is a topic to be visited whilst crawling the topic tree
+type CrawlTask struct {
+	ID         string
+	ParentID   string
+	ParentSlug string
+	Depth      int
+	// path contains the ids of the ancestors of the topic, used to detect cycles
+	path []string
+}
+
+// Child returns the task to visit the subtopic `id` of the topic of this task, where slug is the slug of this task's topic
+func (t CrawlTask) Child(id, slug string) CrawlTask {
+	path := make([]string, 0, len(t.path)+1)
+	path = append(path, t.path...)
+	path = append(path, t.ID)
+
+	return CrawlTask{
+		ID:         id,
+		ParentID:   t.ID,
+		ParentSlug: slug,
+		Depth:      t.Depth + 1,
+		path:       path,
+	}
+}
+
+// CrawlVisitFunc fetches the topic of a task, stores what it has loaded and returns the number of topics loaded
+// along with the tasks for the subtopics which need to be visited next
+type CrawlVisitFunc func(ctx context.Context, task CrawlTask) (loaded int, next []CrawlTask, err error)
+
+// crawler walks a topic tree concurrently using a bounded number of workers
+type crawler struct {
+	cfg     CrawlConfig
+	visit   CrawlVisitFunc
+	sem     chan struct{}
+	wg      *sync.WaitGroup
+	mutex   *sync.Mutex
+	visited map[string]struct{}
+	loaded  map[int]int
+	failed  int
+}
+
+// Crawl visits each root task and then every subtopic returned by visit, until the whole topic tree has been crawled.
+// A topic is only visited once, a topic reached again through one of its own subtopics is logged as a cycle.
+// Failed visits are retried with backoff. Once complete, a summary of the topics loaded at each depth is logged.
+func Crawl(ctx context.Context, name string, cfg CrawlConfig, roots []CrawlTask, visit CrawlVisitFunc) {
+	ctx, span := tracing.Start(ctx, "crawl "+name)
+	defer span.End()
+
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	c := &crawler{
+		cfg:     cfg,
+		visit:   visit,
+		sem:     make(chan struct{}, workers),
+		wg:      &sync.WaitGroup{},
+		mutex:   &sync.Mutex{},
+		visited: make(map[string]struct{}),
+		loaded:  make(map[int]int),
+	}
+
+	start := time.Now()
+	for _, root := range roots {
+		c.schedule(ctx, root)
+	}
+	c.wg.Wait()
+
+	c.logSummary(ctx, name, time.Since(start))
+}
+
+// schedule starts visiting the task unless its topic is part of a cycle or has already been visited
+func (c *crawler) schedule(ctx context.Context, task CrawlTask) {
+	for _, ancestorID := range task.path {
+		if ancestorID == task.ID {
+			log.Warn(ctx, "topic cycle detected, skipping topic", log.Data{
+				"topic_id": task.ID,
+				"path":     strings.Join(append(task.path, task.ID), " > "),
+				"depth":    task.Depth,
+			})
+			return
+		}
+	}
+
+	c.mutex.Lock()
+	if _, exists := c.visited[task.ID]; exists {
+		c.mutex.Unlock()
+		return
+	}
+	c.visited[task.ID] = struct{}{}
+	c.mutex.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		select {
+		case c.sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		next, ok := c.run(ctx, task)
+		<-c.sem
+
+		if !ok {
+			return
+		}
+		for _, nextTask := range next {
+			c.schedule(ctx, nextTask)
+		}
+	}()
+}
+
+// run visits the task, retrying with backoff on failure, and records the number of topics loaded
+func (c *crawler) run(ctx context.Context, task CrawlTask) ([]CrawlTask, bool) {
+	backoff := c.cfg.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		loaded, next, err := c.visitWithTimeout(ctx, task, attempt+1)
+		if err == nil {
+			c.mutex.Lock()
+			c.loaded[task.Depth] += loaded
+			c.mutex.Unlock()
+			return next, true
+		}
+
+		logData := log.Data{
+			"topic_id": task.ID,
+			"depth":    task.Depth,
+			"attempt":  attempt + 1,
+		}
+
+		if attempt >= c.cfg.MaxRetries || !isRetryable(err) {
+			log.Error(ctx, "failed to crawl topic", err, logData)
+			c.mutex.Lock()
+			c.failed++
+			c.mutex.Unlock()
+			return nil, false
+		}
+
+		log.Warn(ctx, "failed to crawl topic, retrying", log.FormatErrors([]error{err}), logData)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, false
+		}
+		backoff *= 2
+	}
+}
+
+// visitWithTimeout visits the task in a span of its own, which the calls to the topic source are children of
+func (c *crawler) visitWithTimeout(ctx context.Context, task CrawlTask, attempt int) (loaded int, next []CrawlTask, err error) {
+	ctx, span := tracing.Start(ctx, "crawl topic",
+		tracing.AttrTopicID.String(task.ID),
+		tracing.AttrTopicDepth.Int(task.Depth),
+		tracing.AttrAttempt.Int(attempt),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if c.cfg.CallTimeout <= 0 {
+		return c.visit(ctx, task)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.cfg.CallTimeout)
+	defer cancel()
+
+	return c.visit(callCtx, task)
+}
+
+func (c *crawler) logSummary(ctx context.Context, name string, duration time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	depths := make([]int, 0, len(c.loaded))
+	total := 0
+	for depth, count := range c.loaded {
+		depths = append(depths, depth)
+		total += count
+	}
+	sort.Ints(depths)
+
+	loadedPerDepth := make([]log.Data, 0, len(depths))
+	for _, depth := range depths {
+		loadedPerDepth = append(loadedPerDepth, log.Data{"depth": depth, "topics": c.loaded[depth]})
+	}
+
+	log.Info(ctx, "finished crawling topics", log.Data{
+		"name":             name,
+		"topics_loaded":    total,
+		"topics_visited":   len(c.visited),
+		"topics_failed":    c.failed,
+		"loaded_per_depth": loadedPerDepth,
+		"duration":         duration.String(),
+	})
+}
+
+// isRetryable returns false for client errors, such as a topic not being found, and while the circuit breaker of the
+// topic api is open, as retrying them will not succeed
+func isRetryable(err error) bool {
+	if resilience.IsUnavailable(err) {
+		return false
+	}
+
+	var statusErr interface{ Status() int }
+	if errors.As(err, &statusErr) {
+		status := statusErr.Status()
+		return status < http.StatusBadRequest || status >= http.StatusInternalServerError
+	}
+
+	return true
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/resilience"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testStatusError struct {
+	status int
+}
+
+func (e testStatusError) Error() string {
+	return http.StatusText(e.status)
+}
+
+func (e testStatusError) Status() int {
+	return e.status
+}
+
+var testCrawlConfig = CrawlConfig{
+	Workers:      2,
+	CallTimeout:  time.Second,
+	MaxRetries:   2,
+	RetryBackoff: time.Millisecond,
+}
+
+func TestCrawl(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic tree", t, func() {
+		tree := map[string][]string{
+			"root":   {"1", "2", "3"},
+			"1":      {"1.1", "1.2"},
+			"2":      {"2.1"},
+			"3":      {},
+			"1.1":    {},
+			"1.2":    {},
+			"2.1":    {},
+			"cyclic": {"cyclic.1"},
+		}
+
+		mutex := &sync.Mutex{}
+		visited := map[string]int{}
+		var inFlight, maxInFlight int32
+
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			mutex.Lock()
+			visited[task.ID]++
+			mutex.Unlock()
+
+			next := make([]CrawlTask, 0, len(tree[task.ID]))
+			for _, id := range tree[task.ID] {
+				next = append(next, task.Child(id, "slug-"+task.ID))
+			}
+			return 1, next, nil
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then every topic is visited exactly once", func() {
+				So(visited, ShouldResemble, map[string]int{
+					"root": 1, "1": 1, "2": 1, "3": 1, "1.1": 1, "1.2": 1, "2.1": 1,
+				})
+			})
+
+			Convey("And the number of concurrent visits does not exceed the number of workers", func() {
+				So(atomic.LoadInt32(&maxInFlight), ShouldBeLessThanOrEqualTo, testCrawlConfig.Workers)
+			})
+		})
+
+		Convey("When the tree contains a cycle", func() {
+			tree["cyclic.1"] = []string{"cyclic"}
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "cyclic"}}, visit)
+
+			Convey("Then each topic in the cycle is only visited once", func() {
+				So(visited, ShouldResemble, map[string]int{"cyclic": 1, "cyclic.1": 1})
+			})
+		})
+	})
+
+	Convey("Given a child task", t, func() {
+		child := CrawlTask{ID: "root"}.Child("1", "root-slug").Child("1.1", "slug-1")
+
+		Convey("Then it references its parent and depth", func() {
+			So(child.ID, ShouldEqual, "1.1")
+			So(child.ParentID, ShouldEqual, "1")
+			So(child.ParentSlug, ShouldEqual, "slug-1")
+			So(child.Depth, ShouldEqual, 2)
+			So(child.path, ShouldResemble, []string{"root", "1"})
+		})
+	})
+
+	Convey("Given a topic which fails to be fetched with a server error", t, func() {
+		var attempts int32
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			atomic.AddInt32(&attempts, 1)
+			return 0, nil, testStatusError{status: http.StatusInternalServerError}
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then the call is retried up to the maximum number of retries", func() {
+				So(atomic.LoadInt32(&attempts), ShouldEqual, testCrawlConfig.MaxRetries+1)
+			})
+		})
+	})
+
+	Convey("Given a topic which fails to be fetched as the circuit breaker of the topic api is open", t, func() {
+		var attempts int32
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			atomic.AddInt32(&attempts, 1)
+			return 0, nil, &resilience.UnavailableError{Dependency: "Topic API"}
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then the call is not retried", func() {
+				So(atomic.LoadInt32(&attempts), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a topic which succeeds after a failed attempt", t, func() {
+		var attempts int32
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				return 0, nil, errors.New("connection reset")
+			}
+			return 1, nil, nil
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then the call is retried once", func() {
+				So(atomic.LoadInt32(&attempts), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a topic which is not found", t, func() {
+		var attempts int32
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			atomic.AddInt32(&attempts, 1)
+			return 0, nil, testStatusError{status: http.StatusNotFound}
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", testCrawlConfig, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then the call is not retried", func() {
+				So(atomic.LoadInt32(&attempts), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a topic which takes longer than the call timeout", t, func() {
+		cfg := CrawlConfig{Workers: 1, CallTimeout: 10 * time.Millisecond}
+		var deadlineSet bool
+		visit := func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+			_, deadlineSet = ctx.Deadline()
+			<-ctx.Done()
+			return 0, nil, ctx.Err()
+		}
+
+		Convey("When the tree is crawled", func() {
+			Crawl(ctx, "test", cfg, []CrawlTask{{ID: "root"}}, visit)
+
+			Convey("Then the call is cancelled by its timeout", func() {
+				So(deadlineSet, ShouldBeTrue)
+			})
+		})
+	})
+}
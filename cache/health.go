@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// updateTracker records when each key of a cache was last successfully populated so that the
+// readiness and health of the cache can be reported
+type updateTracker struct {
+	mutex       *sync.RWMutex
+	startTime   time.Time
+	lastUpdated map[string]time.Time
+	now         func() time.Time
+}
+
+func newUpdateTracker() *updateTracker {
+	return &updateTracker{
+		mutex:       &sync.RWMutex{},
+		startTime:   time.Now(),
+		lastUpdated: make(map[string]time.Time),
+		now:         time.Now,
+	}
+}
+
+// register adds a key to be tracked, a registered key which has never been updated means the cache is not ready
+func (t *updateTracker) register(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.lastUpdated[key]; !ok {
+		t.lastUpdated[key] = time.Time{}
+	}
+}
+
+// setUpdated records that the data for the key has been successfully populated
+func (t *updateTracker) setUpdated(key string) {
+	t.setUpdatedAt(key, t.now())
+}
+
+// setUpdatedAt records that the data for the key was successfully populated at the given time
+func (t *updateTracker) setUpdatedAt(key string, updatedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.lastUpdated[key] = updatedAt
+}
+
+// keys returns all the registered keys
+func (t *updateTracker) keys() []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	keys := make([]string, 0, len(t.lastUpdated))
+	for key := range t.lastUpdated {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// isReady returns true when every registered key has been successfully populated at least once
+func (t *updateTracker) isReady() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, lastUpdated := range t.lastUpdated {
+		if lastUpdated.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// oldestUpdate returns the least recent successful update across all keys and whether any key has never been populated
+func (t *updateTracker) oldestUpdate() (oldest time.Time, hasEmpty bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, lastUpdated := range t.lastUpdated {
+		if lastUpdated.IsZero() {
+			hasEmpty = true
+			continue
+		}
+		if oldest.IsZero() || lastUpdated.Before(oldest) {
+			oldest = lastUpdated
+		}
+	}
+
+	return oldest, hasEmpty
+}
+
+// checker returns a healthcheck checker which reports:
+// CRITICAL when the cache has not been populated after the startup grace period,
+// WARNING when the cache data has not been successfully updated within the stale threshold,
+// OK otherwise, including while the cache is still within its startup grace period.
+func (t *updateTracker) checker(name string, staleThreshold, startupGrace time.Duration) healthcheck.Checker {
+	return func(ctx context.Context, state *healthcheck.CheckState) error {
+		oldest, hasEmpty := t.oldestUpdate()
+		now := t.now()
+
+		if hasEmpty {
+			if now.Sub(t.startTime) <= startupGrace {
+				return state.Update(healthcheck.StatusOK, fmt.Sprintf("%s is waiting to be populated", name), 0)
+			}
+			return state.Update(healthcheck.StatusCritical, fmt.Sprintf("%s is empty", name), 0)
+		}
+
+		if age := now.Sub(oldest); age > staleThreshold {
+			return state.Update(healthcheck.StatusWarning, fmt.Sprintf("%s is stale, last updated %s ago", name, age.Round(time.Second)), 0)
+		}
+
+		return state.Update(healthcheck.StatusOK, fmt.Sprintf("%s is ok", name), 0)
+	}
+}
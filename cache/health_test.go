@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTopicCacheReadiness(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic cache with an update function", t, func() {
+		testTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+
+		updatedTopic := GetMockCensusTopic()
+		var returnTopic *Topic
+		testTopicCache.AddUpdateFunc(CensusTopicID, func() *Topic {
+			return returnTopic
+		})
+
+		Convey("When the cache has not been updated", func() {
+			Convey("Then the cache is not ready", func() {
+				So(testTopicCache.IsReady(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When the cache is updated successfully", func() {
+			returnTopic = updatedTopic
+			err := testTopicCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache is ready", func() {
+				So(testTopicCache.IsReady(), ShouldBeTrue)
+			})
+
+			Convey("And a later update fails", func() {
+				returnTopic = nil
+				err := testTopicCache.UpdateContent(ctx)
+				So(err, ShouldBeNil)
+
+				Convey("Then the previously cached topic is kept", func() {
+					cachedTopic, err := testTopicCache.GetCensusData(ctx)
+					So(err, ShouldBeNil)
+					So(cachedTopic, ShouldEqual, updatedTopic)
+				})
+			})
+		})
+
+		Convey("When the first update fails", func() {
+			err := testTopicCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache is not ready", func() {
+				So(testTopicCache.IsReady(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When the first update returns an empty census topic, as the census topic does not exist", func() {
+			returnTopic = GetEmptyCensusTopic()
+			err := testTopicCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache is ready", func() {
+				So(testTopicCache.IsReady(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestListReadiness(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a cache list whose census topic does not exist and whose navigation fails to be updated", t, func() {
+		censusTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		censusTopicCache.AddUpdateFunc(CensusTopicID, GetEmptyCensusTopic)
+
+		navigationCache, err := NewNavigationCache(ctx, nil)
+		So(err, ShouldBeNil)
+		navigationCache.AddUpdateFunc(navigationCache.GetCachingKeyForNavigationLanguage(englishLang), func() *models.Navigation {
+			return nil
+		})
+
+		list := &List{CensusTopic: censusTopicCache, Navigation: navigationCache}
+
+		Convey("When the caches are updated", func() {
+			So(censusTopicCache.UpdateContent(ctx), ShouldBeNil)
+			So(navigationCache.UpdateContent(ctx), ShouldBeNil)
+
+			Convey("Then the list is ready", func() {
+				So(list.IsReady(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestNavigationCacheReadiness(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a navigation cache with an update function that fails", t, func() {
+		testNavigationCache, err := NewNavigationCache(ctx, nil)
+		So(err, ShouldBeNil)
+
+		key := testNavigationCache.GetCachingKeyForNavigationLanguage(englishLang)
+		testNavigationCache.AddUpdateFunc(key, func() *models.Navigation {
+			return nil
+		})
+
+		Convey("When the cache is updated", func() {
+			err := testNavigationCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache is not ready", func() {
+				So(testNavigationCache.IsReady(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a navigation cache with an update function that succeeds", t, func() {
+		testNavigationCache, err := NewNavigationCache(ctx, nil)
+		So(err, ShouldBeNil)
+
+		key := testNavigationCache.GetCachingKeyForNavigationLanguage(englishLang)
+		testNavigationCache.AddUpdateFunc(key, func() *models.Navigation {
+			return &models.Navigation{}
+		})
+
+		Convey("When the cache is updated", func() {
+			err := testNavigationCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache is ready", func() {
+				So(testNavigationCache.IsReady(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	startTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	staleThreshold := 90 * time.Minute
+	startupGrace := 5 * time.Minute
+
+	Convey("Given a cache tracker with a key which has never been populated", t, func() {
+		tracker := newUpdateTracker()
+		tracker.startTime = startTime
+		tracker.register("test")
+		checker := tracker.checker("test cache", staleThreshold, startupGrace)
+		state := healthcheck.NewCheckState("Test cache")
+
+		Convey("When the checker is called within the startup grace period", func() {
+			tracker.now = func() time.Time { return startTime.Add(time.Minute) }
+			err := checker(ctx, state)
+
+			Convey("Then the check state is OK", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "test cache is waiting to be populated")
+			})
+		})
+
+		Convey("When the checker is called after the startup grace period", func() {
+			tracker.now = func() time.Time { return startTime.Add(10 * time.Minute) }
+			err := checker(ctx, state)
+
+			Convey("Then the check state is CRITICAL", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusCritical)
+				So(state.Message(), ShouldEqual, "test cache is empty")
+			})
+		})
+	})
+
+	Convey("Given a cache tracker with a key which has been populated", t, func() {
+		tracker := newUpdateTracker()
+		tracker.startTime = startTime
+		tracker.register("test")
+		tracker.now = func() time.Time { return startTime }
+		tracker.setUpdated("test")
+		checker := tracker.checker("test cache", staleThreshold, startupGrace)
+		state := healthcheck.NewCheckState("Test cache")
+
+		Convey("When the checker is called within the stale threshold", func() {
+			tracker.now = func() time.Time { return startTime.Add(time.Hour) }
+			err := checker(ctx, state)
+
+			Convey("Then the check state is OK", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "test cache is ok")
+			})
+		})
+
+		Convey("When the checker is called after the stale threshold", func() {
+			tracker.now = func() time.Time { return startTime.Add(2 * time.Hour) }
+			err := checker(ctx, state)
+
+			Convey("Then the check state is WARNING", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldEqual, "test cache is stale, last updated 2h0m0s ago")
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// TopicInvalidator refreshes the cached data affected by a change to topics. It is called by the cache invalidation
+// webhook and can be called by a consumer of topic change events, e.g. from Kafka
+type TopicInvalidator interface {
+	InvalidateTopics(ctx context.Context, topicIDs []string) error
+}
+
+// Invalidator is a TopicInvalidator which refreshes the subtree of a changed topic in the census and data topic caches
+// from the topic source, and refreshes the navigation cache
+type Invalidator struct {
+	caches      List
+	crawlConfig CrawlConfig
+	source      TopicSource
+}
+
+// NewInvalidator creates an invalidator for the caches in the list, which refreshes topics from the topic source
+func NewInvalidator(caches List, crawlConfig CrawlConfig, source TopicSource) *Invalidator {
+	return &Invalidator{
+		caches:      caches,
+		crawlConfig: crawlConfig,
+		source:      source,
+	}
+}
+
+// InvalidateTopics refreshes the cached data for the changed topics and their subtopics. The subtree of each topic is
+// refreshed in the topic caches, while the navigation cache is refreshed once for all the topics
+func (inv *Invalidator) InvalidateTopics(ctx context.Context, topicIDs []string) (err error) {
+	ctx, span := tracing.Start(ctx, "cache invalidate topics", tracing.AttrTopicID.StringSlice(topicIDs))
+	defer func() { tracing.End(span, err) }()
+
+	var errs []error
+	for _, topicID := range topicIDs {
+		if err := inv.refreshTopic(ctx, topicID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	logData := log.Data{"topic_ids": topicIDs}
+
+	if inv.caches.Navigation != nil {
+		if err := inv.caches.Navigation.UpdateContent(ctx); err != nil {
+			log.Error(ctx, "failed to refresh navigation cache for changed topics", err, logData)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	log.Info(ctx, "refreshed caches for changed topics", logData)
+	return nil
+}
+
+// refreshTopic refreshes the subtree of the changed topic in the census and data topic caches
+func (inv *Invalidator) refreshTopic(ctx context.Context, topicID string) error {
+	logData := log.Data{"topic_id": topicID}
+	var errs []error
+
+	if inv.caches.CensusTopic != nil {
+		err := inv.caches.CensusTopic.refresh(CensusTopicID, func(censusTopic *Topic) (*Topic, error) {
+			return refreshCensusTopicSubtree(ctx, inv.crawlConfig, inv.source, censusTopic, topicID)
+		})
+		if err != nil {
+			log.Error(ctx, "failed to refresh census topic cache for changed topic", err, logData)
+			errs = append(errs, err)
+		}
+	}
+
+	if inv.caches.DataTopic != nil {
+		err := inv.caches.DataTopic.refresh(DataTopicCacheKey, func(dataTopic *Topic) (*Topic, error) {
+			return refreshDataTopicSubtree(ctx, inv.crawlConfig, inv.source, dataTopic, topicID)
+		})
+		if err != nil {
+			log.Error(ctx, "failed to refresh data topic cache for changed topic", err, logData)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
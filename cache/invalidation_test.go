@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testTopicSource is an in-memory topic source which counts the calls made to get topics
+type testTopicSource struct {
+	mutex        *sync.Mutex
+	rootTopicIDs []string
+	topics       map[string]models.Topic
+	calls        int
+	err          error
+}
+
+func newTestTopicSource(rootTopicIDs []string, topics ...models.Topic) *testTopicSource {
+	source := &testTopicSource{
+		mutex:        &sync.Mutex{},
+		rootTopicIDs: rootTopicIDs,
+		topics:       make(map[string]models.Topic),
+	}
+	for i := range topics {
+		source.topics[topics[i].ID] = topics[i]
+	}
+	return source
+}
+
+func (s *testTopicSource) Name() string { return "test" }
+
+func (s *testTopicSource) GetRootTopics(ctx context.Context) ([]models.Topic, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rootTopics := make([]models.Topic, 0, len(s.rootTopicIDs))
+	for _, id := range s.rootTopicIDs {
+		rootTopics = append(rootTopics, s.topics[id])
+	}
+	return rootTopics, nil
+}
+
+func (s *testTopicSource) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	topic, ok := s.topics[id]
+	if !ok {
+		return nil, nil
+	}
+	return &topic, nil
+}
+
+func (s *testTopicSource) GetSubtopics(ctx context.Context, id string) ([]models.Topic, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	topic, ok := s.topics[id]
+	if !ok || topic.SubtopicIds == nil {
+		return nil, nil
+	}
+	var subtopics []models.Topic
+	for _, subtopicID := range *topic.SubtopicIds {
+		if subtopic, ok := s.topics[subtopicID]; ok {
+			subtopics = append(subtopics, subtopic)
+		}
+	}
+	return subtopics, nil
+}
+
+func (s *testTopicSource) set(topic models.Topic) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.topics[topic.ID] = topic
+	s.calls = 0
+}
+
+func TestInvalidateTopics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given populated census and data topic caches", t, func() {
+		source := newTestTopicSource([]string{"4445", "6734"},
+			models.Topic{ID: "4445", Slug: "census", Title: "Census", SubtopicIds: &[]string{"5678"}},
+			models.Topic{ID: "5678", Slug: "census-sub", Title: "Census Sub", SubtopicIds: &[]string{"8901"}},
+			models.Topic{ID: "8901", Slug: "census-sub-sub", Title: "Census Sub Sub"},
+			models.Topic{ID: "6734", Slug: "economy", Title: "Economy", SubtopicIds: &[]string{"1834"}},
+			models.Topic{ID: "1834", Slug: "environmentalaccounts", Title: "Environmental Accounts"},
+		)
+
+		censusTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		censusTopicCache.AddUpdateFunc(CensusTopicID, UpdateCensusTopic(ctx, "4445", testCrawlConfig, source))
+
+		dataTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		dataTopicCache.AddUpdateFunc(DataTopicCacheKey, UpdateDataTopicCache(ctx, testCrawlConfig, source))
+
+		So(censusTopicCache.UpdateContent(ctx), ShouldBeNil)
+		So(dataTopicCache.UpdateContent(ctx), ShouldBeNil)
+
+		invalidator := NewInvalidator(List{CensusTopic: censusTopicCache, DataTopic: dataTopicCache}, testCrawlConfig, source)
+
+		Convey("When a census subtopic is changed to have a new subtopic instead of its existing one", func() {
+			source.set(models.Topic{ID: "5678", Slug: "census-sub", Title: "Census Sub Renamed", SubtopicIds: &[]string{"9999"}})
+			source.set(models.Topic{ID: "9999", Slug: "census-new", Title: "Census New"})
+
+			err := invalidator.InvalidateTopics(ctx, []string{"5678"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the subtree of the changed topic is refreshed in the census topic cache", func() {
+				censusTopic, err := censusTopicCache.GetCensusData(ctx)
+				So(err, ShouldBeNil)
+
+				subtopic, ok := censusTopic.List.Get("5678")
+				So(ok, ShouldBeTrue)
+				So(subtopic.LocaliseKeyName, ShouldEqual, "Census Sub Renamed")
+				So(censusTopic.List.CheckTopicIDExists("9999"), ShouldBeTrue)
+				So(censusTopic.List.CheckTopicIDExists("8901"), ShouldBeFalse)
+				So(censusTopic.Query, ShouldContainSubstring, "9999")
+				So(censusTopic.Query, ShouldNotContainSubstring, "8901")
+			})
+
+			Convey("And the subtree of the changed topic is refreshed in the data topic cache", func() {
+				dataTopic, err := dataTopicCache.GetData(ctx, DataTopicCacheKey)
+				So(err, ShouldBeNil)
+
+				subtopic, ok := dataTopic.List.Get("9999")
+				So(ok, ShouldBeTrue)
+				So(subtopic.ParentID, ShouldEqual, "5678")
+				So(subtopic.ParentSlug, ShouldEqual, "census-sub")
+				So(dataTopic.List.CheckTopicIDExists("8901"), ShouldBeFalse)
+				So(dataTopic.List.CheckTopicIDExists("1834"), ShouldBeTrue)
+			})
+
+			Convey("And only the changed topic's subtree is fetched from the source", func() {
+				// census: topic + its subtopics, data: topic before refresh + topic and subtopic during the crawl
+				So(source.calls, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When a topic which is not part of the census topic is changed", func() {
+			censusTopicBefore, err := censusTopicCache.GetCensusData(ctx)
+			So(err, ShouldBeNil)
+
+			source.set(models.Topic{ID: "1834", Slug: "environmentalaccounts", Title: "Environmental Accounts Renamed"})
+			err = invalidator.InvalidateTopics(ctx, []string{"1834"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the census topic cache is left unchanged", func() {
+				censusTopic, err := censusTopicCache.GetCensusData(ctx)
+				So(err, ShouldBeNil)
+				So(censusTopic, ShouldEqual, censusTopicBefore)
+			})
+
+			Convey("And the topic is refreshed in the data topic cache", func() {
+				dataTopic, err := dataTopicCache.GetData(ctx, DataTopicCacheKey)
+				So(err, ShouldBeNil)
+
+				subtopic, ok := dataTopic.List.Get("1834")
+				So(ok, ShouldBeTrue)
+				So(subtopic.LocaliseKeyName, ShouldEqual, "Environmental Accounts Renamed")
+				So(subtopic.ParentID, ShouldEqual, "6734")
+			})
+		})
+
+		Convey("When the source fails to get the changed topic", func() {
+			source.set(models.Topic{ID: "5678", Slug: "census-sub", Title: "Census Sub"})
+			source.mutex.Lock()
+			source.err = errors.New("topic api unavailable")
+			source.mutex.Unlock()
+
+			err := invalidator.InvalidateTopics(ctx, []string{"5678"})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("And the cached topics are kept", func() {
+				censusTopic, err := censusTopicCache.GetCensusData(ctx)
+				So(err, ShouldBeNil)
+				So(censusTopic.List.CheckTopicIDExists("5678"), ShouldBeTrue)
+				So(censusTopic.List.CheckTopicIDExists("8901"), ShouldBeTrue)
+
+				dataTopic, err := dataTopicCache.GetData(ctx, DataTopicCacheKey)
+				So(err, ShouldBeNil)
+				So(dataTopic.List.CheckTopicIDExists("5678"), ShouldBeTrue)
+				So(dataTopic.List.CheckTopicIDExists("8901"), ShouldBeTrue)
+			})
+		})
+
+		Convey("When several topics are changed together", func() {
+			navigationCache, err := NewNavigationCache(ctx, nil)
+			So(err, ShouldBeNil)
+			navigationUpdates := 0
+			navigationCache.AddUpdateFunc(navigationCache.GetCachingKeyForNavigationLanguage(englishLang), func() *models.Navigation {
+				navigationUpdates++
+				return &models.Navigation{}
+			})
+			invalidator := NewInvalidator(List{CensusTopic: censusTopicCache, DataTopic: dataTopicCache, Navigation: navigationCache}, testCrawlConfig, source)
+
+			source.set(models.Topic{ID: "5678", Slug: "census-sub", Title: "Census Sub Renamed", SubtopicIds: &[]string{"8901"}})
+			source.set(models.Topic{ID: "1834", Slug: "environmentalaccounts", Title: "Environmental Accounts Renamed"})
+
+			err = invalidator.InvalidateTopics(ctx, []string{"5678", "1834"})
+			So(err, ShouldBeNil)
+
+			Convey("Then each topic is refreshed in the topic caches", func() {
+				censusTopic, err := censusTopicCache.GetCensusData(ctx)
+				So(err, ShouldBeNil)
+				subtopic, ok := censusTopic.List.Get("5678")
+				So(ok, ShouldBeTrue)
+				So(subtopic.LocaliseKeyName, ShouldEqual, "Census Sub Renamed")
+
+				dataTopic, err := dataTopicCache.GetData(ctx, DataTopicCacheKey)
+				So(err, ShouldBeNil)
+				subtopic, ok = dataTopic.List.Get("1834")
+				So(ok, ShouldBeTrue)
+				So(subtopic.LocaliseKeyName, ShouldEqual, "Environmental Accounts Renamed")
+			})
+
+			Convey("And the navigation cache is refreshed once", func() {
+				So(navigationUpdates, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a topic which is not in the data topic cache is changed", func() {
+			source.set(models.Topic{ID: "6734", Slug: "economy", Title: "Economy", SubtopicIds: &[]string{"1834", "2000"}})
+			source.set(models.Topic{ID: "2000", Slug: "new-topic", Title: "New Topic"})
+
+			err := invalidator.InvalidateTopics(ctx, []string{"2000"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the whole data topic cache is updated", func() {
+				dataTopic, err := dataTopicCache.GetData(ctx, DataTopicCacheKey)
+				So(err, ShouldBeNil)
+
+				subtopic, ok := dataTopic.List.Get("2000")
+				So(ok, ShouldBeTrue)
+				So(subtopic.ParentID, ShouldEqual, "6734")
+			})
+		})
+	})
+}
@@ -2,10 +2,14 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	dpcache "github.com/ONSdigital/dp-cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 )
@@ -13,6 +17,10 @@ import (
 // NavigationCache is a wrapper to dpcache.Cache which has additional fields and methods specifically for caching navigation data
 type NavigationCache struct {
 	*dpcache.Cache
+	tracker   *updateTracker
+	snapshots *snapshotStore
+	// metricsName is the cache label of the metrics of the cache, which are not recorded if it is empty
+	metricsName string
 }
 
 // NewNavigationCache create a navigation cache object to be used in the service which will update at every updateInterval
@@ -31,17 +39,106 @@ func NewNavigationCache(ctx context.Context, updateInterval *time.Duration) (*Na
 		return nil, err
 	}
 
-	navigationCache := &NavigationCache{cache}
+	navigationCache := &NavigationCache{Cache: cache, tracker: newUpdateTracker()}
 
 	return navigationCache, nil
 }
 
 // AddUpdateFunc adds an update function to the cache
+// If the update returns no navigation data, the previously cached navigation data is kept
 func (nc *NavigationCache) AddUpdateFunc(key string, updateFunc func() *models.Navigation) {
+	nc.tracker.register(key)
 	nc.UpdateFuncs[key] = func() (interface{}, error) {
 		// error handled in updateFunc
-		return updateFunc(), nil
+		navigation := updateFunc()
+		if navigation == nil {
+			nc.recordRefresh(metrics.OutcomeFailure)
+			if cachedNavigation, ok := nc.Get(key); ok {
+				return cachedNavigation, nil
+			}
+			return navigation, nil
+		}
+
+		nc.tracker.setUpdated(key)
+		nc.recordRefresh(metrics.OutcomeSuccess)
+		nc.saveSnapshot(key, navigation)
+		return navigation, nil
+	}
+}
+
+// EnableSnapshot enables writing the navigation data to snapshot files, named after `name`, in the directory `dir` on
+// each successful update so that it can be loaded by LoadSnapshot on startup if it is not older than maxAge
+func (nc *NavigationCache) EnableSnapshot(dir, name string, maxAge time.Duration) {
+	nc.snapshots = newSnapshotStore(dir, name, maxAge)
+}
+
+// EnableMetrics enables recording the lookups and refreshes of the cache, labelled with `name`
+func (nc *NavigationCache) EnableMetrics(name string) {
+	nc.metricsName = name
+}
+
+func (nc *NavigationCache) recordLookup(found bool) {
+	if nc.metricsName == "" {
+		return
+	}
+	if found {
+		metrics.CacheLookups.WithLabelValues(nc.metricsName, metrics.ResultHit).Inc()
+	} else {
+		metrics.CacheLookups.WithLabelValues(nc.metricsName, metrics.ResultMiss).Inc()
+	}
+}
+
+func (nc *NavigationCache) recordRefresh(outcome string) {
+	if nc.metricsName != "" {
+		metrics.CacheRefreshes.WithLabelValues(nc.metricsName, outcome).Inc()
+	}
+}
+
+// LoadSnapshot populates the cache with the snapshots of the navigation data for every key, if snapshots are enabled
+// Any snapshot which is missing, invalid or too old is ignored and the key is left to be populated by its update function
+func (nc *NavigationCache) LoadSnapshot(ctx context.Context) {
+	if nc.snapshots == nil {
+		return
 	}
+
+	for _, key := range nc.tracker.keys() {
+		logData := log.Data{"key": key, "snapshot": nc.snapshots.path(key)}
+
+		navigation := &models.Navigation{}
+		createdAt, err := nc.snapshots.load(key, navigation)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Info(ctx, "no navigation cache snapshot to load", logData)
+			continue
+		}
+		if err != nil {
+			log.Warn(ctx, "failed to load navigation cache snapshot", log.FormatErrors([]error{err}), logData)
+			continue
+		}
+
+		nc.Set(key, navigation)
+		nc.tracker.setUpdatedAt(key, createdAt)
+		log.Info(ctx, "loaded navigation cache snapshot", logData)
+	}
+}
+
+func (nc *NavigationCache) saveSnapshot(key string, navigation *models.Navigation) {
+	if nc.snapshots == nil {
+		return
+	}
+
+	if err := nc.snapshots.save(key, navigation); err != nil {
+		log.Error(context.Background(), "failed to save navigation cache snapshot", err, log.Data{"key": key, "snapshot": nc.snapshots.path(key)})
+	}
+}
+
+// IsReady returns true once the navigation data for every key has been successfully populated
+func (nc *NavigationCache) IsReady() bool {
+	return nc.tracker.isReady()
+}
+
+// Checker returns a healthcheck checker reporting on whether the navigation cache has been populated and is up to date
+func (nc *NavigationCache) Checker(name string, staleThreshold, startupGrace time.Duration) healthcheck.Checker {
+	return nc.tracker.checker(name, staleThreshold, startupGrace)
 }
 
 func (nc *NavigationCache) GetCachingKeyForNavigationLanguage(lang string) string {
@@ -51,6 +148,7 @@ func (nc *NavigationCache) GetCachingKeyForNavigationLanguage(lang string) strin
 func (nc *NavigationCache) GetNavigationData(ctx context.Context, lang string) (*models.Navigation, error) {
 	key := nc.GetCachingKeyForNavigationLanguage(lang)
 	navigationCacheInterface, ok := nc.Get(key)
+	nc.recordLookup(ok)
 	if !ok {
 		err := fmt.Errorf("cached navigation data with key %s not found", key)
 		log.Error(ctx, "failed to get cached navigation data", err)
@@ -5,256 +5,93 @@ import (
 	"errors"
 	"net/http"
 
-	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-topic-api/models"
 	topicCli "github.com/ONSdigital/dp-topic-api/sdk"
-	"github.com/ONSdigital/log.go/v2/log"
 )
 
-// UpdateCensusTopic is a function to update the census topic cache in publishing (private) mode.
-// This function talks to the dp-topic-api via its private endpoints to retrieve the census topic and its subtopic ids
-// The data returned by the dp-topic-api is of type *models.PrivateSubtopics which is then transformed in this function for the controller
-// If an error has occurred, this is captured in log.Error and then an empty census topic is returned
-func UpdateCensusTopic(ctx context.Context, serviceAuthToken string, topicClient topicCli.Clienter) func() *cache.Topic {
-	return func() *cache.Topic {
-		// get root topics from dp-topic-api
-		rootTopics, err := topicClient.GetRootTopicsPrivate(ctx, topicCli.Headers{ServiceAuthToken: serviceAuthToken})
-		if err != nil {
-			log.Error(ctx, "failed to get private census root topics from topic-api", err)
-			return cache.GetEmptyCensusTopic()
-		}
-
-		// dereference root topics items to allow ranging through them
-		var rootTopicItems []models.TopicResponse
-		if rootTopics.PrivateItems != nil {
-			rootTopicItems = *rootTopics.PrivateItems
-		} else {
-			err := errors.New("census root topic private items is nil")
-			log.Error(ctx, "failed to dereference private census root topics items pointer", err)
-			return cache.GetEmptyCensusTopic()
-		}
-
-		var censusTopicCache *cache.Topic
-
-		// go through each root topic, find census topic and gets its data for caching which includes subtopic ids
-		for i := range rootTopicItems {
-			if rootTopicItems[i].Current.ID == cache.CensusTopicID {
-				censusTopicCache = getRootTopicCachePrivate(ctx, serviceAuthToken, topicClient, *rootTopicItems[i].Current)
-				break
-			}
-		}
-
-		if censusTopicCache == nil {
-			err := errors.New("not found")
-			log.Error(ctx, "failed to get census root topics to cache", err)
-			return cache.GetEmptyCensusTopic()
-		}
-
-		return censusTopicCache
-	}
+// TopicSource is a cache.TopicSource which retrieves topics from the dp-topic-api via its private endpoints in publishing (private) mode
+type TopicSource struct {
+	serviceAuthToken string
+	topicClient      topicCli.Clienter
 }
 
-// UpdateDataTopicCache is a function to update the data topic cache in publishing (private) mode.
-// This function talks to the dp-topic-api via its private endpoints to retrieve the root topic and its subtopic ids
-// The data returned by the dp-topic-api is of type *models.PrivateSubtopics which is then transformed in this function for the controller
-// If an error has occurred, this is captured in log.Error and then an empty topic is returned
-func UpdateDataTopicCache(ctx context.Context, serviceAuthToken string, topicClient topicCli.Clienter) func() *cache.Topic {
-	return func() *cache.Topic {
-		processedTopics := make(map[string]struct{})
-
-		// get root topics from dp-topic-api
-		rootTopics, err := topicClient.GetRootTopicsPrivate(ctx, topicCli.Headers{ServiceAuthToken: serviceAuthToken})
-		if err != nil {
-			log.Error(ctx, "failed to get data root topics from topic-api", err)
-			return cache.GetEmptyTopic()
-		}
-
-		// deference root topics items to allow ranging through them
-		var rootTopicItems []models.TopicResponse
-		if rootTopics.PrivateItems != nil {
-			rootTopicItems = *rootTopics.PrivateItems
-		} else {
-			err := errors.New("data root topic private items is nil")
-			log.Error(ctx, "failed to dereference data root topics items pointer", err)
-			return cache.GetEmptyTopic()
-		}
-
-		// Initialize dataTopicCache
-		dataTopicCache := &cache.Topic{
-			ID:              cache.DataTopicCacheKey,
-			LocaliseKeyName: "Root",
-			List:            cache.NewSubTopicsMap(),
-		}
-
-		// recursively process root topics and their subtopics
-		for i := range rootTopicItems {
-			processTopic(ctx, serviceAuthToken, topicClient, rootTopicItems[i].ID, dataTopicCache, processedTopics, "", "", 0)
-		}
-
-		// Check if any data topics were found
-		if len(dataTopicCache.List.GetSubtopics()) == 0 {
-			err := errors.New("data root topic found, but no subtopics were returned")
-			log.Error(ctx, "No private topics loaded into cache - data root topic found, but no subtopics were returned", err)
-			return cache.GetEmptyTopic()
-		}
-		return dataTopicCache
+// NewTopicSource creates a topic source which uses the private endpoints of the dp-topic-api
+func NewTopicSource(serviceAuthToken string, topicClient topicCli.Clienter) *TopicSource {
+	return &TopicSource{
+		serviceAuthToken: serviceAuthToken,
+		topicClient:      topicClient,
 	}
 }
 
-func processTopic(ctx context.Context, serviceAuthToken string, topicClient topicCli.Clienter, topicID string, dataTopicCache *cache.Topic, processedTopics map[string]struct{}, parentTopicID, parentTopicSlug string, depth int) {
-	log.Info(ctx, "processing private topic", log.Data{
-		"topic_id":     topicID,
-		"parent_topic": parentTopicSlug,
-		"depth":        depth,
-	})
-
-	// Check if the topic has already been processed
-	if _, exists := processedTopics[topicID]; exists {
-		err := errors.New("topic already processed")
-		log.Error(ctx, "skipping already processed private topic", err, log.Data{
-			"topic_id": topicID,
-			"depth":    depth,
-		})
-		return
-	}
+// Name returns the name of the topic source
+func (s *TopicSource) Name() string {
+	return "private"
+}
 
-	// Get the topic details from the topic client
-	dataTopic, err := topicClient.GetTopicPrivate(ctx, topicCli.Headers{ServiceAuthToken: serviceAuthToken}, topicID)
+// GetRootTopics returns the current version of the root topics from the dp-topic-api
+func (s *TopicSource) GetRootTopics(ctx context.Context) ([]models.Topic, error) {
+	rootTopics, err := s.topicClient.GetRootTopicsPrivate(ctx, s.headers())
 	if err != nil {
-		log.Error(ctx, "failed to get private topic details from topic-api", err, log.Data{
-			"topic_id": topicID,
-			"depth":    depth,
-		})
-		return
+		return nil, err
 	}
 
-	if dataTopic != nil {
-		// Initialize subtopic list for the current topic if it doesn't exist
-		subtopic := mapTopicModelToCache(*dataTopic.Current, parentTopicID, parentTopicSlug)
-
-		// Add the current topic to the dataTopicCache's List
-		dataTopicCache.List.AppendSubtopicID(dataTopic.Current.ID, subtopic)
-
-		// Mark this topic as processed
-		processedTopics[topicID] = struct{}{}
-
-		// Process each subtopic recursively
-		if dataTopic.Current.SubtopicIds != nil {
-			for _, subTopicID := range *dataTopic.Current.SubtopicIds {
-				processTopic(ctx, serviceAuthToken, topicClient, subTopicID, dataTopicCache, processedTopics, topicID, dataTopic.Current.Slug, depth+1)
-			}
-		}
+	if rootTopics.PrivateItems == nil {
+		return nil, errors.New("root topic private items is nil")
 	}
-}
 
-func mapTopicModelToCache(topic models.Topic, parentID, parentSlug string) cache.Subtopic {
-	return cache.Subtopic{
-		ID:              topic.ID,
-		Slug:            topic.Slug,
-		LocaliseKeyName: topic.Title,
-		ReleaseDate:     topic.ReleaseDate,
-		ParentID:        parentID,
-		ParentSlug:      parentSlug,
-	}
+	return currentTopics(*rootTopics.PrivateItems), nil
 }
 
-func getRootTopicCachePrivate(ctx context.Context, serviceAuthToken string, topicClient topicCli.Clienter, rootTopic models.Topic) *cache.Topic {
-	rootTopicCache := &cache.Topic{
-		ID:              rootTopic.ID,
-		Slug:            rootTopic.Slug,
-		LocaliseKeyName: rootTopic.Title,
+// GetTopic returns the current version of the topic for the id from the dp-topic-api
+func (s *TopicSource) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	topic, err := s.topicClient.GetTopicPrivate(ctx, s.headers(), id)
+	if err != nil {
+		return nil, err
 	}
 
-	subtopic := cache.Subtopic{
-		ID:              rootTopic.ID,
-		Slug:            rootTopic.Slug,
-		LocaliseKeyName: rootTopic.Title,
-		ReleaseDate:     rootTopic.ReleaseDate,
+	if topic == nil || topic.Current == nil {
+		return nil, nil
 	}
 
-	subtopicsIDMap := cache.NewSubTopicsMap()
-	subtopicsIDMap.AppendSubtopicID(rootTopic.ID, subtopic)
-
-	processedTopics := make(map[string]struct{})
-
-	processSubtopicsPrivate(ctx, serviceAuthToken, subtopicsIDMap, topicClient, rootTopic.ID, processedTopics, 0)
-
-	rootTopicCache.List = subtopicsIDMap
-	rootTopicCache.Query = subtopicsIDMap.GetSubtopicsIDsQuery()
-
-	return rootTopicCache
+	return topic.Current, nil
 }
 
-func processSubtopicsPrivate(ctx context.Context, serviceAuthToken string, subtopicsIDMap *cache.Subtopics, topicClient topicCli.Clienter, topLevelTopicID string, processedTopics map[string]struct{}, depth int) {
-	log.Info(ctx, "Processing private census sub-topic at depth", log.Data{
-		"topic_id": topLevelTopicID,
-		"depth":    depth,
-	})
-
-	// Check if this topic has already been processed
-	if _, exists := processedTopics[topLevelTopicID]; exists {
-		err := errors.New("topic already processed")
-		log.Error(ctx, "skipping already processed private sub-topic", err, log.Data{
-			"topic_id": topLevelTopicID,
-			"depth":    depth,
-		})
-		return
-	}
-
-	// Mark this topic as processed
-	processedTopics[topLevelTopicID] = struct{}{}
-
-	// Get subtopics from dp-topic-api
-	subTopics, err := topicClient.GetSubtopicsPrivate(ctx, topicCli.Headers{ServiceAuthToken: serviceAuthToken}, topLevelTopicID)
+// GetSubtopics returns the current version of the subtopics of the topic for the id from the dp-topic-api
+func (s *TopicSource) GetSubtopics(ctx context.Context, id string) ([]models.Topic, error) {
+	subTopics, err := s.topicClient.GetSubtopicsPrivate(ctx, s.headers(), id)
 	if err != nil {
-		if err.Error() != http.StatusText(http.StatusNotFound) {
-			log.Error(ctx, "failed to get private subtopics from topic-api", err, log.Data{
-				"topic_id": topLevelTopicID,
-				"depth":    depth,
-			})
+		if err.Status() == http.StatusNotFound {
+			// the topic has no subtopics
+			return nil, nil
 		}
-
-		// Stop as there are no subtopics items or failed to get subtopics
-		return
+		return nil, err
 	}
 
-	// Dereference sub-topics items to allow ranging through them
-	var subTopicItems []models.TopicResponse
 	if subTopics.PrivateItems == nil {
-		err := errors.New("items is nil")
-		log.Error(ctx, "failed to dereference private sub-topics items pointer", err, log.Data{
-			"topic_id": topLevelTopicID,
-			"depth":    depth,
-		})
-		return
-	}
-	subTopicItems = *subTopics.PrivateItems
-
-	// Stop recursion if subTopicItems is empty
-	if len(subTopicItems) == 0 {
-		log.Info(ctx, "No private subtopics found", log.Data{
-			"topic_id": topLevelTopicID,
-			"depth":    depth,
-		})
-		return
+		return nil, errors.New("sub-topics private items is nil")
 	}
 
-	// Process each subtopic item sequentially
-	for _, subTopicItem := range subTopicItems {
-		subtopic := cache.Subtopic{
-			ID:              subTopicItem.ID,
-			Slug:            subTopicItem.Current.Slug,
-			LocaliseKeyName: subTopicItem.Current.Title,
-			ReleaseDate:     subTopicItem.Current.ReleaseDate,
-		}
+	return currentTopics(*subTopics.PrivateItems), nil
+}
 
-		subtopicsIDMap.AppendSubtopicID(subTopicItem.ID, subtopic)
+func (s *TopicSource) headers() topicCli.Headers {
+	return topicCli.Headers{ServiceAuthToken: s.serviceAuthToken}
+}
 
-		if subTopicItem.Current.SubtopicIds == nil || len(*subTopicItem.Current.SubtopicIds) == 0 {
+// currentTopics returns the current version of each topic, skipping topics without one
+func currentTopics(topicResponses []models.TopicResponse) []models.Topic {
+	topics := make([]models.Topic, 0, len(topicResponses))
+	for i := range topicResponses {
+		if topicResponses[i].Current == nil {
 			continue
 		}
 
-		// Recursively process subtopics of the subtopic
-		processSubtopicsPrivate(ctx, serviceAuthToken, subtopicsIDMap, topicClient, subTopicItem.ID, processedTopics, depth+1)
+		topic := *topicResponses[i].Current
+		if topicResponses[i].ID != "" {
+			topic.ID = topicResponses[i].ID
+		}
+		topics = append(topics, topic)
 	}
+
+	return topics
 }
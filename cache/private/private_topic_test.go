@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-topic-api/models"
@@ -24,6 +25,13 @@ const (
 )
 
 var (
+	testCrawlConfig = cache.CrawlConfig{
+		Workers:      2,
+		CallTimeout:  time.Second,
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+	}
+
 	// root topic level (when GetRootTopics is called)
 	testRootTopicsPrivate = &models.PrivateSubtopics{
 		TotalCount:   2,
@@ -162,7 +170,7 @@ func mockGetSubtopicsIDsPrivate(ctx context.Context, subtopicsChan chan models.T
 		rootTopic = testCensusRootTopic
 	}
 
-	testTopicCache := getRootTopicCachePrivate(ctx, "", topicClient, rootTopic)
+	testTopicCache := cache.GetRootTopicCache(ctx, testCrawlConfig, NewTopicSource("", topicClient), rootTopic)
 
 	return testTopicCache.Query
 }
@@ -193,7 +201,7 @@ func TestUpdateCensusTopic(t *testing.T) {
 
 	Convey("Given census root topic does exist and has subtopics", t, func() {
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, "", mockedTopicClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource("", mockedTopicClient))()
 
 			Convey("Then the census topic cache is returned", func() {
 				So(respCensusTopicCache, ShouldNotBeNil)
@@ -218,10 +226,10 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, "", failedRootTopicClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource("", failedRootTopicClient))()
 
-			Convey("Then an empty census topic cache should be returned", func() {
-				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
+			Convey("Then no census topic is returned", func() {
+				So(respCensusTopicCache, ShouldBeNil)
 			})
 		})
 	})
@@ -236,10 +244,10 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, "", rootTopicsNilClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource("", rootTopicsNilClient))()
 
-			Convey("Then an empty census topic cache should be returned", func() {
-				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
+			Convey("Then no census topic is returned", func() {
+				So(respCensusTopicCache, ShouldBeNil)
 			})
 		})
 	})
@@ -257,7 +265,7 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopicPrivate is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, "", censusTopicNotExistClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource("", censusTopicNotExistClient))()
 
 			Convey("Then an empty census topic cache should be returned", func() {
 				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
@@ -270,8 +278,6 @@ func TestUpdateDataTopicCache(t *testing.T) {
 	ctx := context.Background()
 	serviceAuthToken := "test-token"
 
-	emptyTopic := cache.GetEmptyTopic()
-
 	Convey("Given root topics exist and have subtopics with no duplicate topics", t, func() {
 		mockClient := &mockTopic.ClienterMock{
 			GetRootTopicsPrivateFunc: func(ctx context.Context, reqHeaders sdk.Headers) (*models.PrivateSubtopics, topicCliErr.Error) {
@@ -321,7 +327,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache (Private) is called", func() {
-			respTopic := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+			respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
 			Convey("Then the topics cache is returned", func() {
 				So(respTopic, ShouldNotBeNil)
@@ -341,7 +347,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 			}
 
 			Convey("When UpdateDataTopicCache (Private) is called", func() {
-				respTopic := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+				respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
 				Convey("Then the topics cache is returned with expected number of topics excluding duplicates", func() {
 					So(respTopic, ShouldNotBeNil)
@@ -362,7 +368,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 			}
 
 			Convey("When UpdateDataTopicCache (Private) is called", func() {
-				respTopic := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+				respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
 				Convey("Then the topics cache is returned as expected with no duplicates and does not get stuck in a loop", func() {
 					So(respTopic, ShouldNotBeNil)
@@ -385,10 +391,10 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache (Private) is called", func() {
-			respTopic := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+			respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopic, ShouldResemble, emptyTopic)
+			Convey("Then no data topic is returned", func() {
+				So(respTopic, ShouldBeNil)
 			})
 		})
 	})
@@ -412,10 +418,10 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache (Private) is called", func() {
-			respTopic := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+			respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopic, ShouldResemble, emptyTopic)
+			Convey("Then no data topic is returned", func() {
+				So(respTopic, ShouldBeNil)
 			})
 		})
 	})
@@ -431,10 +437,11 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache (Private) is called", func() {
-			respTopics := UpdateDataTopicCache(ctx, serviceAuthToken, mockClient)()
+			respTopics := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(serviceAuthToken, mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopics, ShouldResemble, emptyTopic)
+			Convey("Then an empty data topic is returned", func() {
+				So(respTopics.ID, ShouldEqual, expectedDataTopicCache.ID)
+				So(respTopics.List.GetSubtopics(), ShouldBeEmpty)
 			})
 		})
 	})
@@ -462,7 +469,7 @@ func TestGetRootTopicCachePrivate(t *testing.T) {
 
 	Convey("Given topic has subtopics", t, func() {
 		Convey("When getRootTopicCachePrivate is called", func() {
-			respCensusTopicCache := getRootTopicCachePrivate(ctx, "", mockedTopicClient, testCensusRootTopic)
+			respCensusTopicCache := cache.GetRootTopicCache(ctx, testCrawlConfig, NewTopicSource("", mockedTopicClient), testCensusRootTopic)
 
 			Convey("Then the census topic cache is returned", func() {
 				So(respCensusTopicCache, ShouldNotBeNil)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
 	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	topicCli "github.com/ONSdigital/dp-topic-api/sdk"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -17,6 +18,8 @@ func UpdateNavigationData(ctx context.Context, cfg *config.Config, lang string,
 	}
 
 	return func() *topicModel.Navigation {
+		ctx, span := tracing.Start(ctx, "cache refresh navigation", tracing.AttrCache.String("navigation"))
+
 		headers := topicCli.Headers{}
 		options := topicCli.Options{}
 
@@ -28,6 +31,7 @@ func UpdateNavigationData(ctx context.Context, cfg *config.Config, lang string,
 		}
 
 		navigationData, err := topicClient.GetNavigationPublic(ctx, headers, options)
+		tracing.End(span, err)
 		if err != nil {
 			logData := log.Data{
 				"headers": headers,
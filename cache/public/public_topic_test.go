@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-topic-api/models"
@@ -24,6 +25,13 @@ const (
 )
 
 var (
+	testCrawlConfig = cache.CrawlConfig{
+		Workers:      2,
+		CallTimeout:  time.Second,
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+	}
+
 	// root topic level (when GetRootTopicsPublic is called)
 	testRootTopics = &models.PublicSubtopics{
 		TotalCount:  2,
@@ -113,7 +121,7 @@ func mockGetSubtopicsIDsPublic(ctx context.Context, subtopicsChan chan models.To
 		rootTopic = testCensusRootTopic
 	}
 
-	testTopicCache := getRootTopicCachePublic(ctx, topicClient, rootTopic)
+	testTopicCache := cache.GetRootTopicCache(ctx, testCrawlConfig, NewTopicSource(topicClient), rootTopic)
 
 	return testTopicCache.Query
 }
@@ -144,7 +152,7 @@ func TestUpdateCensusTopic(t *testing.T) {
 
 	Convey("Given census root topic does exist and has subtopics", t, func() {
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, mockedTopicClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource(mockedTopicClient))()
 
 			Convey("Then the census topic cache is returned", func() {
 				So(respCensusTopicCache, ShouldNotBeNil)
@@ -169,10 +177,10 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, failedRootTopicClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource(failedRootTopicClient))()
 
-			Convey("Then an empty census topic cache should be returned", func() {
-				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
+			Convey("Then no census topic is returned", func() {
+				So(respCensusTopicCache, ShouldBeNil)
 			})
 		})
 	})
@@ -187,10 +195,10 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, rootTopicsNilClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource(rootTopicsNilClient))()
 
-			Convey("Then an empty census topic cache should be returned", func() {
-				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
+			Convey("Then no census topic is returned", func() {
+				So(respCensusTopicCache, ShouldBeNil)
 			})
 		})
 	})
@@ -208,7 +216,7 @@ func TestUpdateCensusTopic(t *testing.T) {
 		}
 
 		Convey("When UpdateCensusTopic is called", func() {
-			respCensusTopicCache := UpdateCensusTopic(ctx, censusTopicNotExistClient)()
+			respCensusTopicCache := cache.UpdateCensusTopic(ctx, cache.CensusTopicID, testCrawlConfig, NewTopicSource(censusTopicNotExistClient))()
 
 			Convey("Then an empty census topic cache should be returned", func() {
 				So(respCensusTopicCache, ShouldResemble, cache.GetEmptyCensusTopic())
@@ -220,8 +228,6 @@ func TestUpdateCensusTopic(t *testing.T) {
 func TestUpdateDataTopicCache(t *testing.T) {
 	ctx := context.Background()
 
-	emptyTopic := cache.GetEmptyTopic()
-
 	Convey("Given root topics exist and have subtopics with no duplicate topics", t, func() {
 		mockClient := &mockTopicCli.ClienterMock{
 			GetRootTopicsPublicFunc: func(ctx context.Context, reqHeaders sdk.Headers) (*models.PublicSubtopics, topicCliErr.Error) {
@@ -253,7 +259,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache is called", func() {
-			respTopic := UpdateDataTopicCache(ctx, mockClient)()
+			respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
 			Convey("Then the topics cache is returned as expected", func() {
 				So(respTopic, ShouldNotBeNil)
@@ -273,7 +279,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 			}
 
 			Convey("When UpdateDataTopicCache is called", func() {
-				respTopic := UpdateDataTopicCache(ctx, mockClient)()
+				respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
 				Convey("Then the topics cache is returned with expected number of topics excluding duplicates", func() {
 					So(respTopic, ShouldNotBeNil)
@@ -294,7 +300,7 @@ func TestUpdateDataTopicCache(t *testing.T) {
 			}
 
 			Convey("When UpdateDataTopicCache is called", func() {
-				respTopic := UpdateDataTopicCache(ctx, mockClient)()
+				respTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
 				Convey("Then the topics cache is returned as expected with no duplicates and does not get stuck in a loop", func() {
 					So(respTopic, ShouldNotBeNil)
@@ -317,10 +323,10 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache is called", func() {
-			respTopics := UpdateDataTopicCache(ctx, mockClient)()
+			respTopics := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopics, ShouldResemble, emptyTopic)
+			Convey("Then no data topic is returned", func() {
+				So(respTopics, ShouldBeNil)
 			})
 		})
 	})
@@ -339,10 +345,10 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache is called", func() {
-			respTopics := UpdateDataTopicCache(ctx, mockClient)()
+			respTopics := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopics, ShouldResemble, emptyTopic)
+			Convey("Then no data topic is returned", func() {
+				So(respTopics, ShouldBeNil)
 			})
 		})
 	})
@@ -358,10 +364,11 @@ func TestUpdateDataTopicCache(t *testing.T) {
 		}
 
 		Convey("When UpdateDataTopicCache is called", func() {
-			respTopics := UpdateDataTopicCache(ctx, mockClient)()
+			respTopics := cache.UpdateDataTopicCache(ctx, testCrawlConfig, NewTopicSource(mockClient))()
 
-			Convey("Then an empty topic cache should be returned", func() {
-				So(respTopics, ShouldResemble, emptyTopic)
+			Convey("Then an empty data topic is returned", func() {
+				So(respTopics.ID, ShouldEqual, expectedDataTopicCache.ID)
+				So(respTopics.List.GetSubtopics(), ShouldBeEmpty)
 			})
 		})
 	})
@@ -389,7 +396,7 @@ func TestGetRootTopicCachePublic(t *testing.T) {
 
 	Convey("Given topic has subtopics", t, func() {
 		Convey("When getRootTopicCache is called", func() {
-			respCensusTopicCache := getRootTopicCachePublic(ctx, mockedTopicClient, testCensusRootTopic)
+			respCensusTopicCache := cache.GetRootTopicCache(ctx, testCrawlConfig, NewTopicSource(mockedTopicClient), testCensusRootTopic)
 
 			Convey("Then the census topic cache is returned", func() {
 				So(respCensusTopicCache, ShouldNotBeNil)
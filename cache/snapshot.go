@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot file format, snapshots with a different version are ignored on load
+const snapshotVersion = 1
+
+var (
+	errSnapshotVersionMismatch  = errors.New("snapshot version does not match")
+	errSnapshotChecksumMismatch = errors.New("snapshot checksum does not match its data")
+	errSnapshotTooOld           = errors.New("snapshot is older than the maximum age")
+)
+
+// snapshot is the content of a snapshot file which is written to disk on each successful cache refresh
+type snapshot struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Checksum  string          `json:"checksum"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// snapshotStore reads and writes the snapshots of a cache in a local directory
+type snapshotStore struct {
+	dir    string
+	name   string
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+func newSnapshotStore(dir, name string, maxAge time.Duration) *snapshotStore {
+	return &snapshotStore{
+		dir:    dir,
+		name:   name,
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+func (s *snapshotStore) path(key string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s___%s.json", s.name, key))
+}
+
+// save writes the data for the key to its snapshot file, replacing any previous snapshot
+func (s *snapshotStore) save(key string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: s.now().UTC(),
+		Checksum:  checksum(b),
+		Data:      b,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.dir, 0o750); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written snapshot is never loaded
+	tmpFile, err := os.CreateTemp(s.dir, s.name+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), s.path(key))
+}
+
+// load reads the snapshot file for the key into data and returns the time the snapshot was created
+func (s *snapshotStore) load(key string, data interface{}) (time.Time, error) {
+	content, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var snap snapshot
+	if err = json.Unmarshal(content, &snap); err != nil {
+		return time.Time{}, err
+	}
+
+	if snap.Version != snapshotVersion {
+		return time.Time{}, errSnapshotVersionMismatch
+	}
+
+	if snap.Checksum != checksum(snap.Data) {
+		return time.Time{}, errSnapshotChecksumMismatch
+	}
+
+	if s.now().Sub(snap.CreatedAt) > s.maxAge {
+		return time.Time{}, errSnapshotTooOld
+	}
+
+	if err = json.Unmarshal(snap.Data, data); err != nil {
+		return time.Time{}, err
+	}
+
+	return snap.CreatedAt, nil
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
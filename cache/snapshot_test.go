@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a snapshot store", t, func() {
+		store := newSnapshotStore(t.TempDir(), "test-cache", time.Hour)
+		data := map[string]string{"id": "1234"}
+
+		Convey("When data is saved", func() {
+			err := store.save("key", data)
+			So(err, ShouldBeNil)
+
+			Convey("Then the same data is loaded", func() {
+				var loaded map[string]string
+				createdAt, err := store.load("key", &loaded)
+				So(err, ShouldBeNil)
+				So(loaded, ShouldResemble, data)
+				So(createdAt, ShouldNotBeZeroValue)
+			})
+
+			Convey("And the snapshot is older than the maximum age", func() {
+				store.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+				Convey("Then loading the snapshot fails", func() {
+					var loaded map[string]string
+					_, err := store.load("key", &loaded)
+					So(err, ShouldEqual, errSnapshotTooOld)
+				})
+			})
+
+			Convey("And the snapshot data has been modified", func() {
+				content, err := os.ReadFile(store.path("key"))
+				So(err, ShouldBeNil)
+				err = os.WriteFile(store.path("key"), []byte(strings.Replace(string(content), "1234", "5678", 1)), 0o600)
+				So(err, ShouldBeNil)
+
+				Convey("Then loading the snapshot fails", func() {
+					var loaded map[string]string
+					_, err := store.load("key", &loaded)
+					So(err, ShouldEqual, errSnapshotChecksumMismatch)
+				})
+			})
+		})
+
+		Convey("When a snapshot with a different version exists", func() {
+			err := os.WriteFile(store.path("key"), []byte(`{"version":0,"checksum":"","data":{}}`), 0o600)
+			So(err, ShouldBeNil)
+
+			Convey("Then loading the snapshot fails", func() {
+				var loaded map[string]string
+				_, err := store.load("key", &loaded)
+				So(err, ShouldEqual, errSnapshotVersionMismatch)
+			})
+		})
+
+		Convey("When no snapshot exists", func() {
+			Convey("Then loading the snapshot fails with a not exist error", func() {
+				var loaded map[string]string
+				_, err := store.load("missing", &loaded)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestTopicCacheSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic cache with snapshots enabled", t, func() {
+		dir := t.TempDir()
+
+		testTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		testTopicCache.EnableSnapshot(dir, "census-topic-cache", time.Hour)
+		testTopicCache.AddUpdateFunc(CensusTopicID, GetMockCensusTopic)
+
+		Convey("When the cache is updated successfully", func() {
+			err := testTopicCache.UpdateContent(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then a snapshot is written", func() {
+				_, err := os.Stat(filepath.Join(dir, "census-topic-cache___"+CensusTopicID+".json"))
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And a new topic cache loads the snapshot", func() {
+				newTopicCache, err := NewTopicCache(ctx, nil)
+				So(err, ShouldBeNil)
+				newTopicCache.EnableSnapshot(dir, "census-topic-cache", time.Hour)
+				newTopicCache.AddUpdateFunc(CensusTopicID, GetEmptyCensusTopic)
+				newTopicCache.LoadSnapshot(ctx)
+
+				Convey("Then the cache is populated with the snapshot topic and is ready", func() {
+					censusTopic, err := newTopicCache.GetCensusData(ctx)
+					So(err, ShouldBeNil)
+					So(censusTopic.ID, ShouldEqual, CensusTopicID)
+					So(censusTopic.Query, ShouldEqual, GetMockCensusTopic().Query)
+					So(censusTopic.List.GetSubtopics(), ShouldHaveLength, 3)
+					So(newTopicCache.IsReady(), ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
+
+func TestNavigationCacheSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a navigation cache with snapshots enabled that has been updated", t, func() {
+		dir := t.TempDir()
+
+		testNavigationCache, err := NewNavigationCache(ctx, nil)
+		So(err, ShouldBeNil)
+		testNavigationCache.EnableSnapshot(dir, NavigationCacheKey, time.Hour)
+		key := testNavigationCache.GetCachingKeyForNavigationLanguage(englishLang)
+		testNavigationCache.AddUpdateFunc(key, func() *models.Navigation {
+			return &models.Navigation{Description: "test navigation"}
+		})
+		err = testNavigationCache.UpdateContent(ctx)
+		So(err, ShouldBeNil)
+
+		Convey("When a new navigation cache loads the snapshot", func() {
+			newNavigationCache, err := NewNavigationCache(ctx, nil)
+			So(err, ShouldBeNil)
+			newNavigationCache.EnableSnapshot(dir, NavigationCacheKey, time.Hour)
+			newNavigationCache.AddUpdateFunc(key, func() *models.Navigation { return nil })
+			newNavigationCache.LoadSnapshot(ctx)
+
+			Convey("Then the cache is populated with the snapshot navigation data", func() {
+				navigation, err := newNavigationCache.GetNavigationData(ctx, englishLang)
+				So(err, ShouldBeNil)
+				So(navigation.Description, ShouldEqual, "test navigation")
+				So(newNavigationCache.IsReady(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package static
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// Publisher publishes changes to the topics of a static topic source and notifies its invalidators of each changed topic,
+// acting as a local fake of the topic change notifications of the dp-topic-api for tests and local development
+type Publisher struct {
+	source       *TopicSource
+	invalidators []cache.TopicInvalidator
+}
+
+// NewPublisher creates a publisher which changes the topics of the source and notifies the invalidators
+func NewPublisher(source *TopicSource, invalidators ...cache.TopicInvalidator) *Publisher {
+	return &Publisher{
+		source:       source,
+		invalidators: invalidators,
+	}
+}
+
+// Publish adds or replaces the topic in the source and notifies the invalidators that it has changed
+func (p *Publisher) Publish(ctx context.Context, topic models.Topic) error {
+	p.source.mutex.Lock()
+	p.source.topics[topic.ID] = topic
+	p.source.mutex.Unlock()
+
+	return p.notify(ctx, topic.ID)
+}
+
+// Delete removes the topic from the source and notifies the invalidators that it has changed
+func (p *Publisher) Delete(ctx context.Context, topicID string) error {
+	p.source.mutex.Lock()
+	delete(p.source.topics, topicID)
+	p.source.mutex.Unlock()
+
+	return p.notify(ctx, topicID)
+}
+
+func (p *Publisher) notify(ctx context.Context, topicID string) error {
+	var errs []error
+	for _, invalidator := range p.invalidators {
+		if err := invalidator.InvalidateTopics(ctx, []string{topicID}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
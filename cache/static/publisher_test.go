@@ -0,0 +1,51 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a data topic cache populated from a static topic source", t, func() {
+		source, err := NewTopicSource(testFixturePath)
+		So(err, ShouldBeNil)
+
+		dataTopicCache, err := cache.NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		dataTopicCache.AddUpdateFunc(cache.DataTopicCacheKey, cache.UpdateDataTopicCache(ctx, testCrawlConfig, source))
+		So(dataTopicCache.UpdateContent(ctx), ShouldBeNil)
+
+		invalidator := cache.NewInvalidator(cache.List{DataTopic: dataTopicCache}, testCrawlConfig, source)
+		publisher := NewPublisher(source, invalidator)
+
+		Convey("When a changed topic is published", func() {
+			err := publisher.Publish(ctx, models.Topic{ID: "1834", Title: "Environmental Accounts Renamed", Slug: "environmentalaccounts"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the data topic cache contains the change", func() {
+				subtopic, err := dataTopicCache.GetTopic(ctx, "environmentalaccounts", "economy")
+				So(err, ShouldBeNil)
+				So(subtopic.LocaliseKeyName, ShouldEqual, "Environmental Accounts Renamed")
+			})
+		})
+
+		Convey("When a topic is deleted", func() {
+			err := publisher.Delete(ctx, "5678")
+			So(err, ShouldBeNil)
+
+			Convey("Then the topic and its subtopics are removed from the data topic cache", func() {
+				dataTopic, err := dataTopicCache.GetData(ctx, cache.DataTopicCacheKey)
+				So(err, ShouldBeNil)
+				So(dataTopic.List.CheckTopicIDExists("5678"), ShouldBeFalse)
+				So(dataTopic.List.CheckTopicIDExists("8901"), ShouldBeFalse)
+				So(dataTopic.List.CheckTopicIDExists("1235"), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package static
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// TopicSource is a cache.TopicSource which reads topics from a static JSON fixture file,
+// allowing the topic caches to be populated without the dp-topic-api e.g. for local development
+type TopicSource struct {
+	mutex        *sync.RWMutex
+	rootTopicIDs []string
+	topics       map[string]models.Topic
+}
+
+// fixture is the content of a topic fixture file
+type fixture struct {
+	RootTopicIDs []string       `json:"root_topic_ids"`
+	Topics       []models.Topic `json:"topics"`
+}
+
+// NewTopicSource creates a topic source from the JSON fixture file at path
+func NewTopicSource(path string) (*TopicSource, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f fixture
+	if err = json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse topic fixture: %w", err)
+	}
+
+	topics := make(map[string]models.Topic, len(f.Topics))
+	for i := range f.Topics {
+		topics[f.Topics[i].ID] = f.Topics[i]
+	}
+
+	for _, id := range f.RootTopicIDs {
+		if _, ok := topics[id]; !ok {
+			return nil, fmt.Errorf("root topic %q not found in topic fixture", id)
+		}
+	}
+
+	return &TopicSource{
+		mutex:        &sync.RWMutex{},
+		rootTopicIDs: f.RootTopicIDs,
+		topics:       topics,
+	}, nil
+}
+
+// Name returns the name of the topic source
+func (s *TopicSource) Name() string {
+	return "static"
+}
+
+// GetRootTopics returns the root topics from the fixture
+func (s *TopicSource) GetRootTopics(ctx context.Context) ([]models.Topic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	rootTopics := make([]models.Topic, 0, len(s.rootTopicIDs))
+	for _, id := range s.rootTopicIDs {
+		rootTopics = append(rootTopics, s.topics[id])
+	}
+
+	return rootTopics, nil
+}
+
+// GetTopic returns the topic for the id from the fixture, or nil if it does not exist
+func (s *TopicSource) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	topic, ok := s.topics[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &topic, nil
+}
+
+// GetSubtopics returns the subtopics of the topic for the id from the fixture, skipping any subtopic which does not exist
+func (s *TopicSource) GetSubtopics(ctx context.Context, id string) ([]models.Topic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	topic, ok := s.topics[id]
+	if !ok || topic.SubtopicIds == nil {
+		return nil, nil
+	}
+
+	subtopics := make([]models.Topic, 0, len(*topic.SubtopicIds))
+	for _, subtopicID := range *topic.SubtopicIds {
+		if subtopic, ok := s.topics[subtopicID]; ok {
+			subtopics = append(subtopics, subtopic)
+		}
+	}
+
+	return subtopics, nil
+}
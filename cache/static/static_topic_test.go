@@ -0,0 +1,113 @@
+package static
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testFixturePath = "testdata/topics.json"
+
+var testCrawlConfig = cache.CrawlConfig{
+	Workers:      2,
+	CallTimeout:  time.Second,
+	MaxRetries:   1,
+	RetryBackoff: time.Millisecond,
+}
+
+func TestNewTopicSource(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a valid topic fixture file", t, func() {
+		Convey("When NewTopicSource is called", func() {
+			source, err := NewTopicSource(testFixturePath)
+
+			Convey("Then the topic source is returned", func() {
+				So(err, ShouldBeNil)
+				So(source, ShouldNotBeNil)
+				So(source.Name(), ShouldEqual, "static")
+			})
+		})
+	})
+
+	Convey("Given a topic fixture file which does not exist", t, func() {
+		Convey("When NewTopicSource is called", func() {
+			source, err := NewTopicSource("testdata/missing.json")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(source, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestTopicSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic source from a fixture file", t, func() {
+		source, err := NewTopicSource(testFixturePath)
+		So(err, ShouldBeNil)
+
+		Convey("When GetRootTopics is called", func() {
+			rootTopics, err := source.GetRootTopics(ctx)
+
+			Convey("Then the root topics are returned in order", func() {
+				So(err, ShouldBeNil)
+				So(rootTopics, ShouldHaveLength, 2)
+				So(rootTopics[0].ID, ShouldEqual, "4445")
+				So(rootTopics[1].ID, ShouldEqual, "6734")
+			})
+		})
+
+		Convey("When GetTopic is called with an unknown id", func() {
+			topic, err := source.GetTopic(ctx, "unknown")
+
+			Convey("Then no topic is returned", func() {
+				So(err, ShouldBeNil)
+				So(topic, ShouldBeNil)
+			})
+		})
+
+		Convey("When GetSubtopics is called for a topic with a subtopic missing from the fixture", func() {
+			subtopics, err := source.GetSubtopics(ctx, "6734")
+
+			Convey("Then only the subtopics in the fixture are returned", func() {
+				So(err, ShouldBeNil)
+				So(subtopics, ShouldHaveLength, 1)
+				So(subtopics[0].ID, ShouldEqual, "1834")
+			})
+		})
+
+		Convey("When the census topic cache is updated from the source", func() {
+			censusTopic := cache.UpdateCensusTopic(ctx, "4445", testCrawlConfig, source)()
+
+			Convey("Then the census topic and all of its subtopics are cached", func() {
+				So(censusTopic.ID, ShouldEqual, "4445")
+				So(censusTopic.LocaliseKeyName, ShouldEqual, "Census")
+				So(censusTopic.Query, ShouldContainSubstring, "5678")
+				So(censusTopic.Query, ShouldContainSubstring, "1235")
+				So(censusTopic.Query, ShouldContainSubstring, "8901")
+			})
+		})
+
+		Convey("When the data topic cache is updated from the source", func() {
+			dataTopic := cache.UpdateDataTopicCache(ctx, testCrawlConfig, source)()
+
+			Convey("Then every topic in the fixture is cached with its parent", func() {
+				So(dataTopic.ID, ShouldEqual, cache.DataTopicCacheKey)
+				So(dataTopic.List.GetSubtopics(), ShouldHaveLength, 6)
+
+				subtopic, ok := dataTopic.List.Get("8901")
+				So(ok, ShouldBeTrue)
+				So(subtopic.ParentID, ShouldEqual, "5678")
+				So(subtopic.ParentSlug, ShouldEqual, "censussub1")
+			})
+		})
+	})
+}
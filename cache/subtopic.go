@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"encoding/json"
 	"strings"
 	"sync"
 	"time"
@@ -107,3 +108,63 @@ func (t *Subtopics) AppendSubtopicID(id string, subtopic Subtopic) {
 
 	t.subtopicsMap[id] = subtopic
 }
+
+// withoutSubtree returns a copy of the subtopics excluding the subtopic `id` and all of its descendants
+func (t *Subtopics) withoutSubtree(id string) *Subtopics {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	children := make(map[string][]string)
+	for subtopicID, subtopic := range t.subtopicsMap {
+		children[subtopic.ParentID] = append(children[subtopic.ParentID], subtopicID)
+	}
+
+	subtree := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[parentID] {
+			if !subtree[childID] {
+				subtree[childID] = true
+				queue = append(queue, childID)
+			}
+		}
+	}
+
+	subtopics := NewSubTopicsMap()
+	for subtopicID, subtopic := range t.subtopicsMap {
+		if !subtree[subtopicID] {
+			subtopics.subtopicsMap[subtopicID] = subtopic
+		}
+	}
+
+	return subtopics
+}
+
+// MarshalJSON encodes the subtopics as a map of subtopic id to subtopic so that they can be persisted
+func (t *Subtopics) MarshalJSON() ([]byte, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return json.Marshal(t.subtopicsMap)
+}
+
+// UnmarshalJSON decodes a map of subtopic id to subtopic into the subtopics
+func (t *Subtopics) UnmarshalJSON(b []byte) error {
+	subtopicsMap := make(map[string]Subtopic)
+	if err := json.Unmarshal(b, &subtopicsMap); err != nil {
+		return err
+	}
+
+	if t.mutex == nil {
+		t.mutex = &sync.RWMutex{}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.subtopicsMap = subtopicsMap
+
+	return nil
+}
@@ -135,3 +135,30 @@ func TestGetSubtopicsIDsQuery(t *testing.T) {
 		})
 	})
 }
+
+func TestWithoutSubtree(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given subtopics with parents", t, func() {
+		subtopics := NewSubTopicsMap()
+		subtopics.AppendSubtopicID("1", Subtopic{ID: "1"})
+		subtopics.AppendSubtopicID("2", Subtopic{ID: "2", ParentID: "1"})
+		subtopics.AppendSubtopicID("3", Subtopic{ID: "3", ParentID: "2"})
+		subtopics.AppendSubtopicID("4", Subtopic{ID: "4", ParentID: "1"})
+
+		Convey("When withoutSubtree is called", func() {
+			remaining := subtopics.withoutSubtree("2")
+
+			Convey("Then the subtopic and its descendants are excluded from the copy", func() {
+				So(remaining.CheckTopicIDExists("1"), ShouldBeTrue)
+				So(remaining.CheckTopicIDExists("2"), ShouldBeFalse)
+				So(remaining.CheckTopicIDExists("3"), ShouldBeFalse)
+				So(remaining.CheckTopicIDExists("4"), ShouldBeTrue)
+			})
+
+			Convey("And the original subtopics are unchanged", func() {
+				So(subtopics.GetSubtopics(), ShouldHaveLength, 4)
+			})
+		})
+	})
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	dpcache "github.com/ONSdigital/dp-cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -16,6 +20,16 @@ var CensusTopicID string
 // TopicCache is a wrapper to dpcache.Cache which has additional fields and methods specifically for caching topics
 type TopicCache struct {
 	*dpcache.Cache
+	// updateMutex serialises the updates of the cache with the refreshes of changed topics, so that neither overwrites
+	// the other with stale data
+	updateMutex    *sync.Mutex
+	updateInterval *time.Duration
+	stop           chan struct{}
+	stopOnce       *sync.Once
+	tracker        *updateTracker
+	snapshots      *snapshotStore
+	// metricsName is the cache label of the metrics of the cache, which are not recorded if it is empty
+	metricsName string
 }
 
 // Topic represents the data which is cached for a topic to be used by the dp-frontend-search-controller
@@ -46,13 +60,21 @@ func NewTopicCache(ctx context.Context, updateInterval *time.Duration) (*TopicCa
 		return nil, err
 	}
 
-	topicCache := &TopicCache{cache}
+	topicCache := &TopicCache{
+		Cache:          cache,
+		updateMutex:    &sync.Mutex{},
+		updateInterval: updateInterval,
+		stop:           make(chan struct{}),
+		stopOnce:       &sync.Once{},
+		tracker:        newUpdateTracker(),
+	}
 
 	return topicCache, nil
 }
 
 func (dc *TopicCache) GetData(ctx context.Context, key string) (*Topic, error) {
 	topicCacheInterface, ok := dc.Get(key)
+	dc.recordLookup(ok)
 	if !ok {
 		err := fmt.Errorf("cached topic data with key %s not found", key)
 		log.Error(ctx, "failed to get cached topic data", err)
@@ -77,13 +99,203 @@ func (dc *TopicCache) GetData(ctx context.Context, key string) (*Topic, error) {
 
 // AddUpdateFunc adds an update function to the topic cache for a topic with the `title` passed to the function
 // This update function will then be triggered once or at every fixed interval as per the prior setup of the TopicCache
+// If the update fails and returns nil, the previously cached topic is kept so that stale data is served instead of no data.
+// An empty topic is a successful update, e.g. when the census topic does not exist, so it populates the cache
 func (dc *TopicCache) AddUpdateFunc(title string, updateFunc func() *Topic) {
+	dc.tracker.register(title)
 	dc.UpdateFuncs[title] = func() (interface{}, error) {
 		// error handling is done within the updateFunc
-		return updateFunc(), nil
+		topic := updateFunc()
+		if topic == nil {
+			dc.recordRefresh(metrics.OutcomeFailure)
+			if cachedTopic, ok := dc.Get(title); ok {
+				return cachedTopic, nil
+			}
+			return GetEmptyTopic(), nil
+		}
+
+		dc.tracker.setUpdated(title)
+		dc.recordRefresh(metrics.OutcomeSuccess)
+		dc.saveSnapshot(title, topic)
+		return topic, nil
+	}
+}
+
+// EnableSnapshot enables writing the topics to snapshot files, named after `name`, in the directory `dir` on each
+// successful update so that they can be loaded by LoadSnapshot on startup if they are not older than maxAge
+func (dc *TopicCache) EnableSnapshot(dir, name string, maxAge time.Duration) {
+	dc.snapshots = newSnapshotStore(dir, name, maxAge)
+}
+
+// EnableMetrics enables recording the lookups and refreshes of the cache, labelled with `name`
+func (dc *TopicCache) EnableMetrics(name string) {
+	dc.metricsName = name
+}
+
+func (dc *TopicCache) recordLookup(found bool) {
+	if dc.metricsName == "" {
+		return
+	}
+	if found {
+		metrics.CacheLookups.WithLabelValues(dc.metricsName, metrics.ResultHit).Inc()
+	} else {
+		metrics.CacheLookups.WithLabelValues(dc.metricsName, metrics.ResultMiss).Inc()
+	}
+}
+
+func (dc *TopicCache) recordRefresh(outcome string) {
+	if dc.metricsName != "" {
+		metrics.CacheRefreshes.WithLabelValues(dc.metricsName, outcome).Inc()
+	}
+}
+
+// LoadSnapshot populates the cache with the snapshots of the topics added to the cache, if snapshots are enabled
+// Any snapshot which is missing, invalid or too old is ignored and the topic is left to be populated by its update function
+func (dc *TopicCache) LoadSnapshot(ctx context.Context) {
+	if dc.snapshots == nil {
+		return
+	}
+
+	for _, key := range dc.tracker.keys() {
+		logData := log.Data{"key": key, "snapshot": dc.snapshots.path(key)}
+
+		topic := GetEmptyTopic()
+		createdAt, err := dc.snapshots.load(key, topic)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Info(ctx, "no topic cache snapshot to load", logData)
+			continue
+		}
+		if err != nil {
+			log.Warn(ctx, "failed to load topic cache snapshot", log.FormatErrors([]error{err}), logData)
+			continue
+		}
+
+		if topic.isEmpty() {
+			log.Warn(ctx, "ignoring empty topic cache snapshot", logData)
+			continue
+		}
+
+		dc.Set(key, topic)
+		dc.tracker.setUpdatedAt(key, createdAt)
+		log.Info(ctx, "loaded topic cache snapshot", logData)
+	}
+}
+
+func (dc *TopicCache) saveSnapshot(key string, topic *Topic) {
+	if dc.snapshots == nil {
+		return
+	}
+
+	if err := dc.snapshots.save(key, topic); err != nil {
+		log.Error(context.Background(), "failed to save topic cache snapshot", err, log.Data{"key": key, "snapshot": dc.snapshots.path(key)})
 	}
 }
 
+// refresh replaces the cached topic for the key with the topic returned by refreshFunc, the cache is left unchanged if it returns nil.
+// The topic is updated by its update function instead if it has not been cached yet or refreshFunc returns errFullUpdateRequired.
+// A refreshed topic is not written to the snapshot, nor does it count as an update of the whole cache for its health
+func (dc *TopicCache) refresh(key string, refreshFunc func(cachedTopic *Topic) (*Topic, error)) error {
+	dc.updateMutex.Lock()
+	defer dc.updateMutex.Unlock()
+
+	cachedTopic, ok := dc.Get(key)
+	if topic, isTopic := cachedTopic.(*Topic); ok && isTopic && !topic.isEmpty() {
+		refreshedTopic, err := refreshFunc(topic)
+		if !errors.Is(err, errFullUpdateRequired) {
+			if err != nil {
+				return err
+			}
+			if refreshedTopic != nil {
+				dc.Set(key, refreshedTopic)
+			}
+			return nil
+		}
+	}
+
+	updateFunc, ok := dc.UpdateFuncs[key]
+	if !ok {
+		return fmt.Errorf("no update function for cached topic with key %s", key)
+	}
+
+	topic, err := updateFunc()
+	if err != nil {
+		return err
+	}
+	dc.Set(key, topic)
+
+	return nil
+}
+
+// UpdateContent updates each topic in the cache with its update function. Each topic is stored while the updates are
+// serialised with refreshes, unlike dpcache which stores the topic after its update function has returned
+func (dc *TopicCache) UpdateContent(_ context.Context) error {
+	dc.updateMutex.Lock()
+	defer dc.updateMutex.Unlock()
+
+	for key, updateFunc := range dc.UpdateFuncs {
+		topic, err := updateFunc()
+		if err != nil {
+			return fmt.Errorf("failed to update topic cache for %s. error: %w", key, err)
+		}
+		dc.Set(key, topic)
+	}
+	return nil
+}
+
+// StartAndManageUpdates updates the cache once and then at every update interval, if there is one, until the cache is
+// closed or the context is done
+func (dc *TopicCache) StartAndManageUpdates(ctx context.Context, errorChannel chan error) {
+	if err := dc.UpdateContent(ctx); err != nil {
+		errorChannel <- err
+		dc.Close()
+		return
+	}
+
+	if dc.updateInterval == nil || len(dc.UpdateFuncs) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(*dc.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := dc.UpdateContent(ctx); err != nil {
+				log.Error(ctx, "failed to update topic cache", err)
+				errorChannel <- err
+			}
+		case <-dc.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close stops the updates of the cache and resets the cached topics
+func (dc *TopicCache) Close() {
+	dc.stopOnce.Do(func() { close(dc.stop) })
+
+	dc.updateMutex.Lock()
+	defer dc.updateMutex.Unlock()
+
+	for key := range dc.UpdateFuncs {
+		dc.Set(key, "")
+	}
+	dc.UpdateFuncs = make(map[string]func() (interface{}, error))
+}
+
+// IsReady returns true once every topic added to the cache has been successfully populated
+func (dc *TopicCache) IsReady() bool {
+	return dc.tracker.isReady()
+}
+
+// Checker returns a healthcheck checker reporting on whether the topic cache has been populated and is up to date
+func (dc *TopicCache) Checker(name string, staleThreshold, startupGrace time.Duration) healthcheck.Checker {
+	return dc.tracker.checker(name, staleThreshold, startupGrace)
+}
+
 // GetDataTopicCacheKey gets the constant value set for the root topic cache key
 func (dc *TopicCache) GetDataTopicCacheKey() string {
 	return DataTopicCacheKey
@@ -136,10 +348,27 @@ func (dc *TopicCache) GetTopicFromSubtopic(subtopic *Subtopic) *Topic {
 		ID:              subtopic.ID,
 		Slug:            subtopic.Slug,
 		LocaliseKeyName: subtopic.LocaliseKeyName,
+		ReleaseDate:     subtopic.ReleaseDate,
 		List:            NewSubTopicsMap(),
 	}
 }
 
+// isEmpty returns true when the topic has no subtopics
+func (t *Topic) isEmpty() bool {
+	return t == nil || t.List == nil || len(t.List.GetSubtopics()) == 0
+}
+
+// withList returns a copy of the topic with the list of subtopics replaced
+func (t *Topic) withList(list *Subtopics) *Topic {
+	topic := *t
+	topic.List = list
+	if t.Query != "" {
+		topic.Query = list.GetSubtopicsIDsQuery()
+	}
+
+	return &topic
+}
+
 // GetEmptyCensusTopic returns an empty census topic cache in the event when updating the cache of the census topic fails
 func GetEmptyCensusTopic() *Topic {
 	return &Topic{
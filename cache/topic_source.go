@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// errFullUpdateRequired is returned when the subtree of a changed topic cannot be refreshed on its own
+var errFullUpdateRequired = errors.New("full update of the cached topic is required")
+
+// TopicSource retrieves topics from where they are stored, e.g. the public or private endpoints of the dp-topic-api,
+// so that the topic caches can be populated in the same way regardless of where the topics come from
+type TopicSource interface {
+	// Name returns the name of the source which is used in logs
+	Name() string
+	// GetRootTopics returns the root topics
+	GetRootTopics(ctx context.Context) ([]models.Topic, error)
+	// GetTopic returns the topic for the id, or nil if it does not exist
+	GetTopic(ctx context.Context, id string) (*models.Topic, error)
+	// GetSubtopics returns the subtopics of the topic for the id, or an empty list if it has none
+	GetSubtopics(ctx context.Context, id string) ([]models.Topic, error)
+}
+
+// UpdateCensusTopic is a function to update the census topic cache from the topic source.
+// The census root topic, with the id given, and all of its subtopics are retrieved from the source and transformed to *Topic for the controller
+// If an error has occurred, this is captured in log.Error and then nil is returned. If the census root topic does not
+// exist, an empty census topic is returned as the topics were successfully retrieved
+func UpdateCensusTopic(ctx context.Context, censusTopicID string, crawlConfig CrawlConfig, source TopicSource) func() *Topic {
+	return func() *Topic {
+		var err error
+		ctx, span := tracing.Start(ctx, "cache refresh census_topic", tracing.AttrCache.String("census_topic"))
+		defer func() { tracing.End(span, err) }()
+
+		logData := log.Data{"source": source.Name()}
+
+		rootTopics, err := source.GetRootTopics(ctx)
+		if err != nil {
+			log.Error(ctx, "failed to get census root topics from topic source", err, logData)
+			return nil
+		}
+
+		// go through each root topic, find census topic and gets its data for caching which includes subtopic ids
+		for i := range rootTopics {
+			if rootTopics[i].ID == censusTopicID {
+				return GetRootTopicCache(ctx, crawlConfig, source, rootTopics[i])
+			}
+		}
+
+		logData["census_topic_id"] = censusTopicID
+		log.Warn(ctx, "census root topic not found, caching an empty census topic", logData)
+		return GetEmptyCensusTopic()
+	}
+}
+
+// UpdateDataTopicCache is a function to update the data topic cache from the topic source.
+// Every root topic and all of their subtopics are retrieved from the source and transformed to *Topic for the controller
+// If an error has occurred, this is captured in log.Error and then nil is returned. If there are no root topics, an
+// empty data topic is returned as the topics were successfully retrieved
+func UpdateDataTopicCache(ctx context.Context, crawlConfig CrawlConfig, source TopicSource) func() *Topic {
+	return func() *Topic {
+		var err error
+		ctx, span := tracing.Start(ctx, "cache refresh data_topic", tracing.AttrCache.String("data_topic"))
+		defer func() { tracing.End(span, err) }()
+
+		logData := log.Data{"source": source.Name()}
+
+		rootTopics, err := source.GetRootTopics(ctx)
+		if err != nil {
+			log.Error(ctx, "failed to get root data topics from topic source", err, logData)
+			return nil
+		}
+
+		dataTopicCache := &Topic{
+			ID:              DataTopicCacheKey,
+			LocaliseKeyName: "Root",
+			List:            NewSubTopicsMap(),
+		}
+
+		if len(rootTopics) == 0 {
+			log.Warn(ctx, "no root data topics found, caching an empty data topic", logData)
+			return dataTopicCache
+		}
+
+		// crawl root topics and their subtopics
+		roots := make([]CrawlTask, 0, len(rootTopics))
+		for i := range rootTopics {
+			roots = append(roots, CrawlTask{ID: rootTopics[i].ID})
+		}
+		Crawl(ctx, source.Name()+" data topics", crawlConfig, roots, visitTopic(source, dataTopicCache.List))
+
+		if len(dataTopicCache.List.GetSubtopics()) == 0 {
+			err = errors.New("data root topic found, but no subtopics were returned")
+			log.Error(ctx, "no topics loaded into cache - data root topic found, but no subtopics were returned", err, logData)
+			return nil
+		}
+		return dataTopicCache
+	}
+}
+
+// GetRootTopicCache crawls all the subtopics of the root topic from the topic source and returns them as a *Topic
+func GetRootTopicCache(ctx context.Context, crawlConfig CrawlConfig, source TopicSource, rootTopic models.Topic) *Topic {
+	rootTopicCache := &Topic{
+		ID:              rootTopic.ID,
+		Slug:            rootTopic.Slug,
+		LocaliseKeyName: rootTopic.Title,
+		ReleaseDate:     rootTopic.ReleaseDate,
+	}
+
+	subtopicsIDMap := NewSubTopicsMap()
+	subtopicsIDMap.AppendSubtopicID(rootTopic.ID, Subtopic{
+		ID:              rootTopic.ID,
+		Slug:            rootTopic.Slug,
+		LocaliseKeyName: rootTopic.Title,
+		ReleaseDate:     rootTopic.ReleaseDate,
+	})
+
+	Crawl(ctx, source.Name()+" census topics", crawlConfig, []CrawlTask{{ID: rootTopic.ID}}, visitSubtopics(source, subtopicsIDMap))
+
+	rootTopicCache.List = subtopicsIDMap
+	rootTopicCache.Query = subtopicsIDMap.GetSubtopicsIDsQuery()
+
+	return rootTopicCache
+}
+
+// refreshDataTopicSubtree returns a copy of the data topic in which the changed topic and all of its subtopics have been
+// crawled again from the topic source. If the changed topic is not in the data topic, errFullUpdateRequired is returned
+func refreshDataTopicSubtree(ctx context.Context, crawlConfig CrawlConfig, source TopicSource, dataTopic *Topic, topicID string) (*Topic, error) {
+	cachedTopic, exists := dataTopic.List.Get(topicID)
+	if !exists {
+		return nil, errFullUpdateRequired
+	}
+
+	// get the topic before removing its subtree so that a failure to get it does not remove it from the cache
+	topic, err := source.GetTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := dataTopic.List.withoutSubtree(topicID)
+	if topic != nil {
+		root := CrawlTask{ID: topicID, ParentID: cachedTopic.ParentID, ParentSlug: cachedTopic.ParentSlug}
+		Crawl(ctx, source.Name()+" data topic subtree", crawlConfig, []CrawlTask{root}, visitTopic(source, list))
+	}
+
+	return dataTopic.withList(list), nil
+}
+
+// refreshCensusTopicSubtree returns a copy of the census topic in which the changed topic and all of its subtopics have
+// been crawled again from the topic source, or nil if the changed topic is not part of the census topic.
+// If the changed topic is the census root topic, errFullUpdateRequired is returned
+func refreshCensusTopicSubtree(ctx context.Context, crawlConfig CrawlConfig, source TopicSource, censusTopic *Topic, topicID string) (*Topic, error) {
+	if topicID == censusTopic.ID {
+		return nil, errFullUpdateRequired
+	}
+
+	cachedTopic, exists := censusTopic.List.Get(topicID)
+	if !exists {
+		return nil, nil
+	}
+
+	// get the topic before removing its subtree so that a failure to get it does not remove it from the cache
+	topic, err := source.GetTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := censusTopic.List.withoutSubtree(topicID)
+	if topic != nil {
+		list.AppendSubtopicID(topic.ID, Subtopic{
+			ID:              topic.ID,
+			Slug:            topic.Slug,
+			LocaliseKeyName: topic.Title,
+			ReleaseDate:     topic.ReleaseDate,
+			ParentID:        cachedTopic.ParentID,
+		})
+		if topic.SubtopicIds != nil && len(*topic.SubtopicIds) > 0 {
+			Crawl(ctx, source.Name()+" census topic subtree", crawlConfig, []CrawlTask{{ID: topicID}}, visitSubtopics(source, list))
+		}
+	}
+
+	return censusTopic.withList(list), nil
+}
+
+// visitTopic returns a crawl visit function which gets a topic from the topic source,
+// adds it to the data topics list and returns its subtopics to be visited next
+func visitTopic(source TopicSource, dataTopics *Subtopics) CrawlVisitFunc {
+	return func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+		dataTopic, err := source.GetTopic(ctx, task.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if dataTopic == nil {
+			return 0, nil, nil
+		}
+
+		dataTopics.AppendSubtopicID(dataTopic.ID, Subtopic{
+			ID:              dataTopic.ID,
+			Slug:            dataTopic.Slug,
+			LocaliseKeyName: dataTopic.Title,
+			ReleaseDate:     dataTopic.ReleaseDate,
+			ParentID:        task.ParentID,
+			ParentSlug:      task.ParentSlug,
+		})
+
+		var next []CrawlTask
+		if dataTopic.SubtopicIds != nil {
+			for _, subTopicID := range *dataTopic.SubtopicIds {
+				next = append(next, task.Child(subTopicID, dataTopic.Slug))
+			}
+		}
+
+		return 1, next, nil
+	}
+}
+
+// visitSubtopics returns a crawl visit function which gets the subtopics of a topic from the topic source, adds them
+// to the subtopics map and returns the subtopics which have subtopics of their own to be visited next
+func visitSubtopics(source TopicSource, subtopicsIDMap *Subtopics) CrawlVisitFunc {
+	return func(ctx context.Context, task CrawlTask) (int, []CrawlTask, error) {
+		subTopics, err := source.GetSubtopics(ctx, task.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		var next []CrawlTask
+		for i := range subTopics {
+			subtopicsIDMap.AppendSubtopicID(subTopics[i].ID, Subtopic{
+				ID:              subTopics[i].ID,
+				Slug:            subTopics[i].Slug,
+				LocaliseKeyName: subTopics[i].Title,
+				ReleaseDate:     subTopics[i].ReleaseDate,
+				ParentID:        task.ID,
+			})
+
+			if subTopics[i].SubtopicIds == nil || len(*subTopics[i].SubtopicIds) == 0 {
+				continue
+			}
+
+			next = append(next, task.Child(subTopics[i].ID, subTopics[i].Slug))
+		}
+
+		return len(subTopics), next, nil
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -211,6 +213,96 @@ func TestAddUpdateFunc(t *testing.T) {
 	})
 }
 
+func TestTopicCacheMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic cache with metrics enabled", t, func() {
+		mockTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+		mockTopicCache.EnableMetrics("test_topic")
+
+		topic := GetMockCensusTopic()
+		mockTopicCache.AddUpdateFunc("test", func() *Topic { return topic })
+
+		hits := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("test_topic", metrics.ResultHit))
+		misses := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("test_topic", metrics.ResultMiss))
+		successes := testutil.ToFloat64(metrics.CacheRefreshes.WithLabelValues("test_topic", metrics.OutcomeSuccess))
+		failures := testutil.ToFloat64(metrics.CacheRefreshes.WithLabelValues("test_topic", metrics.OutcomeFailure))
+
+		Convey("When the cache is refreshed and looked up", func() {
+			_, err = mockTopicCache.UpdateFuncs["test"]()
+			So(err, ShouldBeNil)
+			topic = nil
+			_, err = mockTopicCache.UpdateFuncs["test"]()
+			So(err, ShouldBeNil)
+
+			_, _ = mockTopicCache.GetData(ctx, "missing")
+
+			Convey("Then the outcome of each refresh and the result of the lookup are recorded", func() {
+				So(testutil.ToFloat64(metrics.CacheRefreshes.WithLabelValues("test_topic", metrics.OutcomeSuccess)), ShouldEqual, successes+1)
+				So(testutil.ToFloat64(metrics.CacheRefreshes.WithLabelValues("test_topic", metrics.OutcomeFailure)), ShouldEqual, failures+1)
+				So(testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("test_topic", metrics.ResultMiss)), ShouldEqual, misses+1)
+				So(testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("test_topic", metrics.ResultHit)), ShouldEqual, hits)
+			})
+		})
+	})
+}
+
+func TestRefreshDuringUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic cache which is being updated", t, func() {
+		mockTopicCache, err := NewTopicCache(ctx, nil)
+		So(err, ShouldBeNil)
+
+		topicWithQuery := func(query string) *Topic {
+			topic := GetMockCensusTopic()
+			topic.Query = query
+			return topic
+		}
+		mockTopicCache.Set("test", topicWithQuery("stale"))
+
+		updating := make(chan struct{})
+		release := make(chan struct{})
+		mockTopicCache.AddUpdateFunc("test", func() *Topic {
+			close(updating)
+			<-release
+			return topicWithQuery("updated")
+		})
+
+		updated := make(chan error)
+		go func() { updated <- mockTopicCache.UpdateContent(ctx) }()
+		<-updating
+
+		Convey("When the topic is refreshed before the update has finished", func() {
+			refreshed := make(chan error)
+			var refreshedQuery string
+			go func() {
+				refreshed <- mockTopicCache.refresh("test", func(cachedTopic *Topic) (*Topic, error) {
+					refreshedQuery = cachedTopic.Query
+					return topicWithQuery(cachedTopic.Query + ",refreshed"), nil
+				})
+			}()
+
+			close(release)
+			So(<-updated, ShouldBeNil)
+			So(<-refreshed, ShouldBeNil)
+
+			Convey("Then the refresh waits for the update and is not overwritten by it", func() {
+				So(refreshedQuery, ShouldEqual, "updated")
+
+				topic, err := mockTopicCache.GetData(ctx, "test")
+				So(err, ShouldBeNil)
+				So(topic.Query, ShouldEqual, "updated,refreshed")
+			})
+		})
+	})
+}
+
 func TestGetEmptyCensusTopic(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,26 @@
+package clock
+
+import "time"
+
+// Clock provides the current time, so that behaviour which depends on the time can be tested with fixed times
+type Clock interface {
+	Now() time.Time
+}
+
+// System is a Clock which returns the current system time
+type System struct{}
+
+// Now returns the current system time
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a Clock which always returns the same time
+type Fixed struct {
+	Time time.Time
+}
+
+// Now returns the fixed time
+func (f Fixed) Now() time.Time {
+	return f.Time
+}
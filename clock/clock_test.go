@@ -0,0 +1,32 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a fixed clock", t, func() {
+		fixedTime := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+		clk := Fixed{Time: fixedTime}
+
+		Convey("Then it always returns the fixed time", func() {
+			So(clk.Now(), ShouldEqual, fixedTime)
+			So(clk.Now(), ShouldEqual, fixedTime)
+		})
+	})
+
+	Convey("Given the system clock", t, func() {
+		clk := System{}
+
+		Convey("Then it returns the current time", func() {
+			before := time.Now()
+			now := clk.Now()
+			So(now, ShouldHappenOnOrBetween, before, time.Now())
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package coalesce
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
+	"golang.org/x/sync/singleflight"
+)
+
+// SearchClient is the search api client which makes a single call for identical searches made at the same time, e.g.
+// by the many users loading a list page during a release, and shares its response between them.
+//
+// Searches are only identical if their query and every header are the same, so responses are never shared between
+// different collections or access tokens
+type SearchClient struct {
+	searchSDK.Clienter
+	group   *singleflight.Group
+	timeout time.Duration
+}
+
+// NewSearchClient wraps the search api client to coalesce identical searches. A shared call is not cancelled when the
+// request which started it is, so it is given the timeout instead, no timeout if 0
+func NewSearchClient(client searchSDK.Clienter, timeout time.Duration) *SearchClient {
+	return &SearchClient{
+		Clienter: client,
+		group:    &singleflight.Group{},
+		timeout:  timeout,
+	}
+}
+
+// GetReleaseCalendarEntries gets the release calendar entries from the search api
+func (c *SearchClient) GetReleaseCalendarEntries(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+	return do(ctx, c, key("GetReleaseCalendarEntries", options), func(ctx context.Context) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+		return c.Clienter.GetReleaseCalendarEntries(ctx, options)
+	})
+}
+
+// GetSearch gets the search results from the search api
+func (c *SearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	return do(ctx, c, key("GetSearch", options), func(ctx context.Context) (*searchModels.SearchResponse, searchError.Error) {
+		return c.Clienter.GetSearch(ctx, options)
+	})
+}
+
+// PostSearchURIs gets the search results of the uris from the search api
+func (c *SearchClient) PostSearchURIs(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+	k := key("PostSearchURIs", options, urisRequest)
+	return do(ctx, c, k, func(ctx context.Context) (*searchModels.SearchResponse, searchError.Error) {
+		return c.Clienter.PostSearchURIs(ctx, options, urisRequest)
+	})
+}
+
+type result[T any] struct {
+	resp *T
+	err  searchError.Error
+}
+
+// do makes the call, or waits for the identical call already being made, returning a copy of its response so that
+// callers cannot change each other's. A caller stops waiting when its own context is done
+func do[T any](ctx context.Context, c *SearchClient, k string, call func(ctx context.Context) (*T, searchError.Error)) (*T, searchError.Error) {
+	resultC := c.group.DoChan(k, func() (interface{}, error) {
+		callCtx := context.WithoutCancel(ctx)
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(callCtx, c.timeout)
+			defer cancel()
+		}
+
+		resp, err := call(callCtx)
+		return result[T]{resp: resp, err: err}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, searchError.StatusError{Code: http.StatusInternalServerError, Err: ctx.Err()}
+	case shared := <-resultC:
+		r := shared.Val.(result[T])
+		if r.resp == nil {
+			return nil, r.err
+		}
+		resp := *r.resp
+		return &resp, r.err
+	}
+}
+
+// key identifies a search by the method, query, headers and any other arguments of the call. It is hashed so that the
+// access tokens in the headers are not kept
+func key(method string, options searchSDK.Options, args ...any) string {
+	var b strings.Builder
+	b.WriteString(method)
+	b.WriteString("\n")
+	b.WriteString(options.Query.Encode())
+
+	headerNames := make([]string, 0, len(options.Headers))
+	for name := range options.Headers {
+		headerNames = append(headerNames, name)
+	}
+	slices.Sort(headerNames)
+
+	for _, name := range headerNames {
+		fmt.Fprintf(&b, "\n%s:%q", http.CanonicalHeaderKey(name), options.Headers[name])
+	}
+
+	for _, arg := range args {
+		fmt.Fprintf(&b, "\n%#v", arg)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
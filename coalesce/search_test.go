@@ -0,0 +1,161 @@
+package coalesce
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// blockingSearchClient is a search api client whose searches wait until they are released
+type blockingSearchClient struct {
+	searchSDK.Clienter
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *blockingSearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	c.calls.Add(1)
+	<-c.release
+	return &searchModels.SearchResponse{Count: 10}, nil
+}
+
+func searchOptions(collectionID, accessToken string) searchSDK.Options {
+	return searchSDK.Options{
+		Query: url.Values{"q": {"inflation"}, "limit": {"10"}},
+		Headers: http.Header{
+			searchSDK.CollectionID: {collectionID},
+			"X-Florence-Token":     {accessToken},
+		},
+	}
+}
+
+// searchConcurrently makes the searches at the same time, releasing the search api once they have all been made
+func searchConcurrently(client *SearchClient, fake *blockingSearchClient, options []searchSDK.Options) []*searchModels.SearchResponse {
+	responses := make([]*searchModels.SearchResponse, len(options))
+	wg := sync.WaitGroup{}
+	for i := range options {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], _ = client.GetSearch(context.Background(), options[i])
+		}()
+	}
+
+	// wait for the searches to reach the client before releasing them
+	time.Sleep(50 * time.Millisecond)
+	close(fake.release)
+	wg.Wait()
+
+	return responses
+}
+
+func TestSearchClient(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given identical searches made at the same time", t, func() {
+		fake := &blockingSearchClient{release: make(chan struct{})}
+		client := NewSearchClient(fake, time.Second)
+
+		options := []searchSDK.Options{
+			searchOptions("", "token-a"),
+			searchOptions("", "token-a"),
+			searchOptions("", "token-a"),
+		}
+
+		Convey("When they are made", func() {
+			responses := searchConcurrently(client, fake, options)
+
+			Convey("Then a single call is made to the search api and its response shared", func() {
+				So(fake.calls.Load(), ShouldEqual, 1)
+				for i := range responses {
+					So(responses[i].Count, ShouldEqual, 10)
+				}
+			})
+
+			Convey("Then each search is given its own copy of the response", func() {
+				responses[0].Count = 0
+				So(responses[1].Count, ShouldEqual, 10)
+			})
+		})
+	})
+
+	Convey("Given searches for different collections and access tokens made at the same time", t, func() {
+		fake := &blockingSearchClient{release: make(chan struct{})}
+		client := NewSearchClient(fake, time.Second)
+
+		options := []searchSDK.Options{
+			searchOptions("", "token-a"),
+			searchOptions("", "token-b"),
+			searchOptions("collection-1", "token-a"),
+			searchOptions("collection-2", "token-a"),
+		}
+
+		Convey("When they are made", func() {
+			searchConcurrently(client, fake, options)
+
+			Convey("Then a call is made to the search api for each of them", func() {
+				So(fake.calls.Load(), ShouldEqual, 4)
+			})
+		})
+	})
+
+	Convey("Given a search which is waiting on an identical search", t, func() {
+		fake := &blockingSearchClient{release: make(chan struct{})}
+		defer close(fake.release)
+		client := NewSearchClient(fake, time.Second)
+
+		go client.GetSearch(context.Background(), searchOptions("", "token-a")) //nolint:errcheck // the first search is never released
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		Convey("When its own context is done", func() {
+			resp, err := client.GetSearch(ctx, searchOptions("", "token-a"))
+
+			Convey("Then it stops waiting and returns an error", func() {
+				So(resp, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Status(), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the options of a search", t, func() {
+		options := searchOptions("collection-1", "token-a")
+
+		Convey("Then the key does not depend on the order of the headers or query", func() {
+			reordered := searchSDK.Options{
+				Query: url.Values{"limit": {"10"}, "q": {"inflation"}},
+				Headers: http.Header{
+					"X-Florence-Token":     {"token-a"},
+					searchSDK.CollectionID: {"collection-1"},
+				},
+			}
+			So(key("GetSearch", reordered), ShouldEqual, key("GetSearch", options))
+		})
+
+		Convey("Then the key differs by method, query, header and argument", func() {
+			So(key("GetReleaseCalendarEntries", options), ShouldNotEqual, key("GetSearch", options))
+			So(key("GetSearch", searchOptions("collection-2", "token-a")), ShouldNotEqual, key("GetSearch", options))
+			So(key("GetSearch", searchOptions("collection-1", "token-b")), ShouldNotEqual, key("GetSearch", options))
+			So(key("PostSearchURIs", options, []string{"/a", "/b"}), ShouldNotEqual, key("PostSearchURIs", options, []string{"/a,/b"}))
+		})
+
+		Convey("Then the key does not contain the access token", func() {
+			So(key("GetSearch", options), ShouldNotContainSubstring, "token-a")
+		})
+	})
+}
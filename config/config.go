@@ -9,39 +9,83 @@ import (
 
 // Config represents service configuration for dp-frontend-search-controller
 type Config struct {
+	AggregationPagesPath           string        `envconfig:"AGGREGATION_PAGES_PATH"`
+	AnalyticsBatchSize             int           `envconfig:"ANALYTICS_BATCH_SIZE"`
+	AnalyticsClickSecret           string        `envconfig:"ANALYTICS_CLICK_SECRET" json:"-"`
+	AnalyticsFilePath              string        `envconfig:"ANALYTICS_FILE_PATH"`
+	AnalyticsFlushInterval         time.Duration `envconfig:"ANALYTICS_FLUSH_INTERVAL"`
+	AnalyticsHTTPURL               string        `envconfig:"ANALYTICS_HTTP_URL"`
+	AnalyticsSampleRate            float64       `envconfig:"ANALYTICS_SAMPLE_RATE"`
+	AnalyticsSink                  string        `envconfig:"ANALYTICS_SINK"`
 	APIRouterURL                   string        `envconfig:"API_ROUTER_URL"`
 	BindAddr                       string        `envconfig:"BIND_ADDR"`
 	CacheCensusTopicUpdateInterval time.Duration `envconfig:"CACHE_CENSUS_TOPICS_UPDATE_INTERVAL"`
 	CacheDataTopicUpdateInterval   time.Duration `envconfig:"CACHE_DATA_TOPICS_UPDATE_INTERVAL"`
+	CacheHealthCheckStaleThreshold time.Duration `envconfig:"CACHE_HEALTHCHECK_STALE_THRESHOLD"`
+	CacheHealthCheckStartupGrace   time.Duration `envconfig:"CACHE_HEALTHCHECK_STARTUP_GRACE"`
+	CacheInvalidationWebhookSecret string        `envconfig:"CACHE_INVALIDATION_WEBHOOK_SECRET" json:"-"`
 	CacheNavigationUpdateInterval  time.Duration `envconfig:"CACHE_NAVIGATION_UPDATE_INTERVAL"`
+	CacheSnapshotDir               string        `envconfig:"CACHE_SNAPSHOT_DIR"`
+	CacheSnapshotMaxAge            time.Duration `envconfig:"CACHE_SNAPSHOT_MAX_AGE"`
+	CacheTopicCrawlCallTimeout     time.Duration `envconfig:"CACHE_TOPIC_CRAWL_CALL_TIMEOUT"`
+	CacheTopicCrawlMaxRetries      int           `envconfig:"CACHE_TOPIC_CRAWL_MAX_RETRIES"`
+	CacheTopicCrawlRetryBackoff    time.Duration `envconfig:"CACHE_TOPIC_CRAWL_RETRY_BACKOFF"`
+	CacheTopicCrawlWorkers         int           `envconfig:"CACHE_TOPIC_CRAWL_WORKERS"`
 	CensusTopicID                  string        `envconfig:"CENSUS_TOPIC_ID"`
+	CircuitBreakerFailureThreshold int           `envconfig:"CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
+	CircuitBreakerOpenTimeout      time.Duration `envconfig:"CIRCUIT_BREAKER_OPEN_TIMEOUT"`
 	Debug                          bool          `envconfig:"DEBUG"`
 	DefaultLimit                   int           `envconfig:"DEFAULT_LIMIT"`
 	DefaultMaximumLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
 	DefaultMaximumSearchResults    int           `envconfig:"DEFAULT_MAXIMUM_SEARCH_RESULTS"`
 	DefaultOffset                  int           `envconfig:"DEFAULT_OFFSET"`
 	DefaultPage                    int           `envconfig:"DEFAULT_PAGE"`
+	DependencyMaxRetries           int           `envconfig:"DEPENDENCY_MAX_RETRIES"`
+	DependencyRetryBackoff         time.Duration `envconfig:"DEPENDENCY_RETRY_BACKOFF"`
 	*DefaultSort
-	EnableAggregationPages                  bool          `envconfig:"ENABLE_AGGREGATION_PAGES"`
-	EnableNLPSearch                         bool          `envconfig:"ENABLE_NLP_SEARCH"`
-	EnableTopicAggregationPages             bool          `envconfig:"ENABLE_TOPIC_AGGREGATION_PAGES"`
-	FeedbackAPIURL                          string        `envconfig:"FEEDBACK_API_URL"`
-	EnableCensusDimensionsFilterOption      bool          `envconfig:"ENABLE_CENSUS_DIMENSIONS_FILTER_OPTION"`
-	EnableCensusPopulationTypesFilterOption bool          `envconfig:"ENABLE_CENSUS_POPULATION_TYPE_FILTER_OPTION"`
-	EnableCensusTopicFilterOption           bool          `envconfig:"ENABLE_CENSUS_TOPIC_FILTER_OPTION"`
-	EnableNewNavBar                         bool          `envconfig:"ENABLE_NEW_NAV_BAR"`
-	GracefulShutdownTimeout                 time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckCriticalTimeout              time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	HealthCheckInterval                     time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	OTBatchTimeout                          time.Duration `encconfig:"OTEL_BATCH_TIMEOUT"`
-	OTExporterOTLPEndpoint                  string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
-	OTServiceName                           string        `envconfig:"OTEL_SERVICE_NAME"`
-	OtelEnabled                             bool          `envconfig:"OTEL_ENABLED"`
-	IsPublishing                            bool          `envconfig:"IS_PUBLISHING"`
-	PatternLibraryAssetsPath                string        `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
-	ServiceAuthToken                        string        `envconfig:"SERVICE_AUTH_TOKEN"   json:"-"`
-	SiteDomain                              string        `envconfig:"SITE_DOMAIN"`
-	SupportedLanguages                      []string      `envconfig:"SUPPORTED_LANGUAGES"`
+	EnableAggregationPages                  bool              `envconfig:"ENABLE_AGGREGATION_PAGES"`
+	EnableCacheReadinessGate                bool              `envconfig:"ENABLE_CACHE_READINESS_GATE"`
+	EnableCollectionPreviewDiff             bool              `envconfig:"ENABLE_COLLECTION_PREVIEW_DIFF"`
+	EnableNLPSearch                         bool              `envconfig:"ENABLE_NLP_SEARCH"`
+	EnableRateLimiting                      bool              `envconfig:"ENABLE_RATE_LIMITING"`
+	EnableSearchCoalescing                  bool              `envconfig:"ENABLE_SEARCH_COALESCING"`
+	EnableTopicAggregationPages             bool              `envconfig:"ENABLE_TOPIC_AGGREGATION_PAGES"`
+	FeedbackAPIURL                          string            `envconfig:"FEEDBACK_API_URL"`
+	EnableCensusDimensionsFilterOption      bool              `envconfig:"ENABLE_CENSUS_DIMENSIONS_FILTER_OPTION"`
+	EnableCensusPopulationTypesFilterOption bool              `envconfig:"ENABLE_CENSUS_POPULATION_TYPE_FILTER_OPTION"`
+	EnableCensusTopicFilterOption           bool              `envconfig:"ENABLE_CENSUS_TOPIC_FILTER_OPTION"`
+	EnableNewNavBar                         bool              `envconfig:"ENABLE_NEW_NAV_BAR"`
+	GracefulShutdownTimeout                 time.Duration     `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckCriticalTimeout              time.Duration     `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	HealthCheckInterval                     time.Duration     `envconfig:"HEALTHCHECK_INTERVAL"`
+	ICalendarExportMaxResults               int               `envconfig:"ICALENDAR_EXPORT_MAX_RESULTS"`
+	OTBatchTimeout                          time.Duration     `encconfig:"OTEL_BATCH_TIMEOUT"`
+	OTExporterOTLPEndpoint                  string            `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTServiceName                           string            `envconfig:"OTEL_SERVICE_NAME"`
+	OtelEnabled                             bool              `envconfig:"OTEL_ENABLED"`
+	IsPublishing                            bool              `envconfig:"IS_PUBLISHING"`
+	LogRedactedQueryParams                  []string          `envconfig:"LOG_REDACTED_QUERY_PARAMS"`
+	MigrationLinkQueryParams                []string          `envconfig:"MIGRATION_LINK_QUERY_PARAMS"`
+	MigrationLinkRedirects                  map[string]string `envconfig:"MIGRATION_LINK_REDIRECTS"`
+	NavigationTimeout                       time.Duration     `envconfig:"NAVIGATION_TIMEOUT"`
+	PatternLibraryAssetsPath                string            `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
+	PreviousReleasesYearCountsCacheTTL      time.Duration     `envconfig:"PREVIOUS_RELEASES_YEAR_COUNTS_CACHE_TTL"`
+	RateLimitAllowedIPs                     []string          `envconfig:"RATE_LIMIT_ALLOWED_IPS"`
+	RateLimitExportBurst                    int               `envconfig:"RATE_LIMIT_EXPORT_BURST"`
+	RateLimitExportPerMinute                int               `envconfig:"RATE_LIMIT_EXPORT_PER_MINUTE"`
+	RateLimitRSSBurst                       int               `envconfig:"RATE_LIMIT_RSS_BURST"`
+	RateLimitRSSPerMinute                   int               `envconfig:"RATE_LIMIT_RSS_PER_MINUTE"`
+	RateLimitSearchBurst                    int               `envconfig:"RATE_LIMIT_SEARCH_BURST"`
+	RateLimitSearchPerMinute                int               `envconfig:"RATE_LIMIT_SEARCH_PER_MINUTE"`
+	RateLimitTrustedProxies                 []string          `envconfig:"RATE_LIMIT_TRUSTED_PROXIES"`
+	ReleaseCalendarTopicMaxResults          int               `envconfig:"RELEASE_CALENDAR_TOPIC_MAX_RESULTS"`
+	SearchCountTimeout                      time.Duration     `envconfig:"SEARCH_COUNT_TIMEOUT"`
+	SearchTimeout                           time.Duration     `envconfig:"SEARCH_TIMEOUT"`
+	ServiceAuthToken                        string            `envconfig:"SERVICE_AUTH_TOKEN"   json:"-"`
+	SiteDomain                              string            `envconfig:"SITE_DOMAIN"`
+	SupportedLanguages                      []string          `envconfig:"SUPPORTED_LANGUAGES"`
+	TopicFixturePath                        string            `envconfig:"TOPIC_FIXTURE_PATH"`
+	ZebedeeTimeout                          time.Duration     `envconfig:"ZEBEDEE_TIMEOUT"`
 }
 
 type DefaultSort struct {
@@ -78,18 +122,39 @@ func get() (*Config, error) {
 	}
 
 	cfg := &Config{
+		AggregationPagesPath:           "",
+		AnalyticsBatchSize:             100,
+		AnalyticsClickSecret:           "",
+		AnalyticsFilePath:              "",
+		AnalyticsFlushInterval:         5 * time.Second,
+		AnalyticsHTTPURL:               "",
+		AnalyticsSampleRate:            1,
+		AnalyticsSink:                  "",
 		APIRouterURL:                   "http://localhost:23200/v1",
 		BindAddr:                       ":25000",
 		CacheCensusTopicUpdateInterval: 30 * time.Minute,
 		CacheDataTopicUpdateInterval:   30 * time.Minute,
+		CacheHealthCheckStaleThreshold: 90 * time.Minute,
+		CacheHealthCheckStartupGrace:   5 * time.Minute,
+		CacheInvalidationWebhookSecret: "",
 		CacheNavigationUpdateInterval:  30 * time.Minute,
+		CacheSnapshotDir:               "",
+		CacheSnapshotMaxAge:            24 * time.Hour,
+		CacheTopicCrawlCallTimeout:     10 * time.Second,
+		CacheTopicCrawlMaxRetries:      2,
+		CacheTopicCrawlRetryBackoff:    500 * time.Millisecond,
+		CacheTopicCrawlWorkers:         10,
 		CensusTopicID:                  "4445",
+		CircuitBreakerFailureThreshold: 5,
+		CircuitBreakerOpenTimeout:      30 * time.Second,
 		Debug:                          false,
 		DefaultLimit:                   10,
 		DefaultMaximumLimit:            50,
 		DefaultMaximumSearchResults:    500,
 		DefaultOffset:                  0,
 		DefaultPage:                    1,
+		DependencyMaxRetries:           2,
+		DependencyRetryBackoff:         100 * time.Millisecond,
 		DefaultSort: &DefaultSort{
 			Aggregation:      "release_date",
 			Dataset:          "release_date",
@@ -102,20 +167,46 @@ func get() (*Config, error) {
 		EnableCensusPopulationTypesFilterOption: false,
 		EnableCensusDimensionsFilterOption:      false,
 		EnableAggregationPages:                  false,
+		EnableCacheReadinessGate:                true,
+		EnableCollectionPreviewDiff:             false,
 		EnableTopicAggregationPages:             false,
 		EnableNewNavBar:                         false,
 		EnableNLPSearch:                         false,
+		EnableRateLimiting:                      false,
+		EnableSearchCoalescing:                  true,
 		GracefulShutdownTimeout:                 5 * time.Second,
 		HealthCheckCriticalTimeout:              90 * time.Second,
 		HealthCheckInterval:                     30 * time.Second,
+		ICalendarExportMaxResults:               1000,
 		OTBatchTimeout:                          5 * time.Second,
 		OTExporterOTLPEndpoint:                  "localhost:4317",
 		OTServiceName:                           "dp-frontend-search-controller",
 		OtelEnabled:                             false,
 		IsPublishing:                            false,
-		ServiceAuthToken:                        "",
-		SiteDomain:                              "localhost",
-		SupportedLanguages:                      []string{"en", "cy"},
+		LogRedactedQueryParams:                  []string{"q"},
+		MigrationLinkQueryParams:                []string{"page"},
+		MigrationLinkRedirects: map[string]string{
+			"previousreleases": "/editions",
+			"relateddata":      "/related-data",
+		},
+		NavigationTimeout:                  2 * time.Second,
+		PreviousReleasesYearCountsCacheTTL: 10 * time.Minute,
+		RateLimitAllowedIPs:                []string{},
+		RateLimitExportBurst:               5,
+		RateLimitExportPerMinute:           10,
+		RateLimitRSSBurst:                  10,
+		RateLimitRSSPerMinute:              30,
+		RateLimitSearchBurst:               20,
+		RateLimitSearchPerMinute:           60,
+		RateLimitTrustedProxies:            []string{"10.0.0.0/8", "172.16.0.0/12", "127.0.0.1"},
+		ReleaseCalendarTopicMaxResults:     500,
+		SearchCountTimeout:                 5 * time.Second,
+		SearchTimeout:                      10 * time.Second,
+		ServiceAuthToken:                   "",
+		SiteDomain:                         "localhost",
+		SupportedLanguages:                 []string{"en", "cy"},
+		TopicFixturePath:                   "",
+		ZebedeeTimeout:                     5 * time.Second,
 	}
 
 	return cfg, envconfig.Process("", cfg)
@@ -21,18 +21,39 @@ func TestConfig(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(cfg, ShouldNotBeNil)
 
+				So(cfg.AggregationPagesPath, ShouldEqual, "")
+				So(cfg.AnalyticsBatchSize, ShouldEqual, 100)
+				So(cfg.AnalyticsClickSecret, ShouldEqual, "")
+				So(cfg.AnalyticsFilePath, ShouldEqual, "")
+				So(cfg.AnalyticsFlushInterval, ShouldEqual, 5*time.Second)
+				So(cfg.AnalyticsHTTPURL, ShouldEqual, "")
+				So(cfg.AnalyticsSampleRate, ShouldEqual, 1)
+				So(cfg.AnalyticsSink, ShouldEqual, "")
 				So(cfg.APIRouterURL, ShouldEqual, "http://localhost:23200/v1")
 				So(cfg.BindAddr, ShouldEqual, ":25000")
 				So(cfg.CacheCensusTopicUpdateInterval, ShouldEqual, 30*time.Minute)
 				So(cfg.CacheDataTopicUpdateInterval, ShouldEqual, 30*time.Minute)
+				So(cfg.CacheHealthCheckStaleThreshold, ShouldEqual, 90*time.Minute)
+				So(cfg.CacheHealthCheckStartupGrace, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheInvalidationWebhookSecret, ShouldEqual, "")
 				So(cfg.CacheNavigationUpdateInterval, ShouldEqual, 30*time.Minute)
+				So(cfg.CacheSnapshotDir, ShouldEqual, "")
+				So(cfg.CacheSnapshotMaxAge, ShouldEqual, 24*time.Hour)
+				So(cfg.CacheTopicCrawlCallTimeout, ShouldEqual, 10*time.Second)
+				So(cfg.CacheTopicCrawlMaxRetries, ShouldEqual, 2)
+				So(cfg.CacheTopicCrawlRetryBackoff, ShouldEqual, 500*time.Millisecond)
+				So(cfg.CacheTopicCrawlWorkers, ShouldEqual, 10)
 				So(cfg.CensusTopicID, ShouldEqual, "4445")
+				So(cfg.CircuitBreakerFailureThreshold, ShouldEqual, 5)
+				So(cfg.CircuitBreakerOpenTimeout, ShouldEqual, 30*time.Second)
 				So(cfg.Debug, ShouldBeFalse)
 				So(cfg.DefaultLimit, ShouldEqual, 10)
 				So(cfg.DefaultMaximumLimit, ShouldEqual, 50)
 				So(cfg.DefaultMaximumSearchResults, ShouldEqual, 500)
 				So(cfg.DefaultOffset, ShouldEqual, 0)
 				So(cfg.DefaultPage, ShouldEqual, 1)
+				So(cfg.DependencyMaxRetries, ShouldEqual, 2)
+				So(cfg.DependencyRetryBackoff, ShouldEqual, 100*time.Millisecond)
 				So(cfg.DefaultSort.Aggregation, ShouldEqual, "release_date")
 				So(cfg.DefaultSort.Dataset, ShouldEqual, "release_date")
 				So(cfg.DefaultSort.Other, ShouldEqual, "relevance")
@@ -40,16 +61,39 @@ func TestConfig(t *testing.T) {
 				So(cfg.DefaultSort.RelatedData, ShouldEqual, "title")
 				So(cfg.EnableCensusTopicFilterOption, ShouldBeFalse)
 				So(cfg.EnableAggregationPages, ShouldBeFalse)
+				So(cfg.EnableCacheReadinessGate, ShouldBeTrue)
+				So(cfg.EnableCollectionPreviewDiff, ShouldBeFalse)
 				So(cfg.EnableTopicAggregationPages, ShouldBeFalse)
 				So(cfg.EnableNewNavBar, ShouldBeFalse)
 				So(cfg.EnableNLPSearch, ShouldBeFalse)
+				So(cfg.EnableRateLimiting, ShouldBeFalse)
+				So(cfg.EnableSearchCoalescing, ShouldBeTrue)
 				So(cfg.GracefulShutdownTimeout, ShouldEqual, 5*time.Second)
 				So(cfg.HealthCheckCriticalTimeout, ShouldEqual, 90*time.Second)
 				So(cfg.HealthCheckInterval, ShouldEqual, 30*time.Second)
+				So(cfg.ICalendarExportMaxResults, ShouldEqual, 1000)
 				So(cfg.IsPublishing, ShouldBeFalse)
+				So(cfg.LogRedactedQueryParams, ShouldResemble, []string{"q"})
+				So(cfg.MigrationLinkQueryParams, ShouldResemble, []string{"page"})
+				So(cfg.MigrationLinkRedirects, ShouldResemble, map[string]string{"previousreleases": "/editions", "relateddata": "/related-data"})
+				So(cfg.NavigationTimeout, ShouldEqual, 2*time.Second)
 				So(cfg.PatternLibraryAssetsPath, ShouldEqual, "//cdn.ons.gov.uk/dis-design-system-go/v0.2.0")
+				So(cfg.PreviousReleasesYearCountsCacheTTL, ShouldEqual, 10*time.Minute)
+				So(cfg.RateLimitAllowedIPs, ShouldBeEmpty)
+				So(cfg.RateLimitExportBurst, ShouldEqual, 5)
+				So(cfg.RateLimitExportPerMinute, ShouldEqual, 10)
+				So(cfg.RateLimitRSSBurst, ShouldEqual, 10)
+				So(cfg.RateLimitRSSPerMinute, ShouldEqual, 30)
+				So(cfg.RateLimitSearchBurst, ShouldEqual, 20)
+				So(cfg.RateLimitSearchPerMinute, ShouldEqual, 60)
+				So(cfg.RateLimitTrustedProxies, ShouldResemble, []string{"10.0.0.0/8", "172.16.0.0/12", "127.0.0.1"})
+				So(cfg.ReleaseCalendarTopicMaxResults, ShouldEqual, 500)
+				So(cfg.SearchCountTimeout, ShouldEqual, 5*time.Second)
+				So(cfg.SearchTimeout, ShouldEqual, 10*time.Second)
 				So(cfg.SiteDomain, ShouldEqual, "localhost")
 				So(cfg.SupportedLanguages, ShouldResemble, []string{"en", "cy"})
+				So(cfg.TopicFixturePath, ShouldEqual, "")
+				So(cfg.ZebedeeTimeout, ShouldEqual, 5*time.Second)
 			})
 
 			Convey("Then a second call to config should return the same config", func() {
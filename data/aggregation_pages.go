@@ -0,0 +1,177 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/assets"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+)
+
+// ReleaseCalendarHandler is the handler of the release calendar page, which has its own search and page model
+const ReleaseCalendarHandler = "release-calendar"
+
+// AggregationPage describes a data aggregation (list) page
+type AggregationPage struct {
+	Template string `json:"template"`
+	Path     string `json:"path"`
+	// Handler serves the page with a handler other than the data aggregation one, e.g. ReleaseCalendarHandler
+	Handler string `json:"handler,omitempty"`
+	// TopicPaths serves the page under the path of any data topic as well, e.g. /economy/datalist
+	TopicPaths   bool     `json:"topic_paths,omitempty"`
+	ContentTypes []string `json:"content_types,omitempty"`
+	// Categories are the localise key names of the categories shown in the content type filter, all are shown if empty
+	Categories      []string           `json:"categories,omitempty"`
+	Filters         AggregationFilters `json:"filters"`
+	DefaultSort     string             `json:"default_sort,omitempty"`
+	RSS             bool               `json:"rss,omitempty"`
+	Title           string             `json:"title"`
+	LocaliseKeyName string             `json:"localise_key"`
+}
+
+// AggregationFilters are the filters enabled on a data aggregation page
+type AggregationFilters struct {
+	Date              bool `json:"date,omitempty"`
+	Topic             bool `json:"topic,omitempty"`
+	SingleContentType bool `json:"single_content_type,omitempty"`
+	TimeSeriesExport  bool `json:"time_series_export,omitempty"`
+}
+
+// AggregationPages is the registry of data aggregation pages
+type AggregationPages []AggregationPage
+
+var (
+	aggregationPagesMutex sync.RWMutex
+	aggregationPages      = mustParseAggregationPages(assets.AggregationPages)
+)
+
+// GetAggregationPages returns the registry of data aggregation pages in use
+func GetAggregationPages() AggregationPages {
+	aggregationPagesMutex.RLock()
+	defer aggregationPagesMutex.RUnlock()
+
+	return aggregationPages
+}
+
+// SetAggregationPages replaces the registry of data aggregation pages in use. It must be called before the routes are set up
+func SetAggregationPages(pages AggregationPages) {
+	aggregationPagesMutex.Lock()
+	defer aggregationPagesMutex.Unlock()
+
+	aggregationPages = pages
+}
+
+// GetAggregationPage returns the data aggregation page of the template from the registry in use
+func GetAggregationPage(template string) (AggregationPage, bool) {
+	return GetAggregationPages().Get(template)
+}
+
+// Get returns the data aggregation page of the template
+func (pages AggregationPages) Get(template string) (AggregationPage, bool) {
+	for i := range pages {
+		if pages[i].Template == template {
+			return pages[i], true
+		}
+	}
+
+	return AggregationPage{}, false
+}
+
+// GetDefaultSort returns the default sort of the page, or the default aggregation sort in config if it has none
+func (page AggregationPage) GetDefaultSort(cfg *config.Config) string {
+	if page.DefaultSort != "" {
+		return page.DefaultSort
+	}
+
+	return cfg.DefaultSort.Aggregation
+}
+
+// LoadAggregationPages reads the registry of data aggregation pages from a JSON file
+func LoadAggregationPages(path string) (AggregationPages, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // path is set through config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aggregation pages: %w", err)
+	}
+
+	return ParseAggregationPages(b)
+}
+
+// ParseAggregationPages parses and validates a registry of data aggregation pages in JSON
+func ParseAggregationPages(b []byte) (AggregationPages, error) {
+	var pages AggregationPages
+	if err := json.Unmarshal(b, &pages); err != nil {
+		return nil, fmt.Errorf("failed to parse aggregation pages: %w", err)
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("no aggregation pages found")
+	}
+
+	templates := make(map[string]bool, len(pages))
+	paths := make(map[string]bool, len(pages))
+
+	for i := range pages {
+		if err := pages[i].validate(); err != nil {
+			return nil, err
+		}
+
+		if templates[pages[i].Template] {
+			return nil, fmt.Errorf("duplicate aggregation page template: %s", pages[i].Template)
+		}
+		if paths[pages[i].Path] {
+			return nil, fmt.Errorf("duplicate aggregation page path: %s", pages[i].Path)
+		}
+
+		templates[pages[i].Template] = true
+		paths[pages[i].Path] = true
+	}
+
+	return pages, nil
+}
+
+// validate checks that the page can be routed to and that its categories and default sort exist
+func (page AggregationPage) validate() error {
+	if page.Template == "" {
+		return fmt.Errorf("aggregation page with path %q has no template", page.Path)
+	}
+
+	if !strings.HasPrefix(page.Path, "/") {
+		return fmt.Errorf("aggregation page %s has an invalid path: %q", page.Template, page.Path)
+	}
+
+	if page.Handler != "" && page.Handler != ReleaseCalendarHandler {
+		return fmt.Errorf("aggregation page %s has an unknown handler: %s", page.Template, page.Handler)
+	}
+
+	if page.DefaultSort != "" {
+		if _, found := sortOptions[page.DefaultSort]; !found {
+			return fmt.Errorf("aggregation page %s has an unknown default sort: %s", page.Template, page.DefaultSort)
+		}
+	}
+
+	for _, categoryName := range page.Categories {
+		isCategory := slices.ContainsFunc(Categories, func(category Category) bool {
+			return category.LocaliseKeyName == categoryName
+		})
+
+		if !isCategory {
+			return fmt.Errorf("aggregation page %s has an unknown category: %s", page.Template, categoryName)
+		}
+	}
+
+	return nil
+}
+
+func mustParseAggregationPages(b []byte) AggregationPages {
+	pages, err := ParseAggregationPages(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return pages
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAggregationPages(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the aggregation pages embedded in assets", t, func() {
+		pages := GetAggregationPages()
+
+		Convey("Then every list page is in the registry", func() {
+			templates := make([]string, 0, len(pages))
+			for i := range pages {
+				templates = append(templates, pages[i].Template)
+			}
+
+			So(templates, ShouldResemble, []string{
+				"all-adhocs", "home-datalist", "published-requests", "home-list", "home-methodology",
+				"time-series-tool", "home-publications", "all-methodologies", "release-calendar",
+			})
+		})
+
+		Convey("When the page of a template is requested", func() {
+			page, found := GetAggregationPage("home-datalist")
+
+			Convey("Then the page is returned", func() {
+				So(found, ShouldBeTrue)
+				So(page.Path, ShouldEqual, "/datalist")
+				So(page.TopicPaths, ShouldBeTrue)
+				So(page.ContentTypes, ShouldResemble, []string{"static_adhoc", "timeseries", "dataset_landing_page", "reference_tables"})
+				So(page.Categories, ShouldResemble, []string{"Data"})
+				So(page.Filters, ShouldResemble, AggregationFilters{Date: true, SingleContentType: true})
+				So(page.RSS, ShouldBeTrue)
+				So(page.Title, ShouldEqual, "Published data")
+				So(page.LocaliseKeyName, ShouldEqual, "DataList")
+			})
+		})
+
+		Convey("When the page of an unknown template is requested", func() {
+			page, found := GetAggregationPage("unknown")
+
+			Convey("Then no page is returned", func() {
+				So(found, ShouldBeFalse)
+				So(page, ShouldResemble, AggregationPage{})
+			})
+		})
+	})
+}
+
+func TestGetDefaultSort(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a page without a default sort", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		page := AggregationPage{Template: "home-list"}
+
+		Convey("Then the default aggregation sort in config is used", func() {
+			So(page.GetDefaultSort(cfg), ShouldEqual, cfg.DefaultSort.Aggregation)
+		})
+	})
+
+	Convey("Given a page with a default sort", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		page := AggregationPage{Template: "home-list", DefaultSort: Title.Query}
+
+		Convey("Then the default sort of the page is used", func() {
+			So(page.GetDefaultSort(cfg), ShouldEqual, Title.Query)
+		})
+	})
+}
+
+func TestParseAggregationPages(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a valid registry of aggregation pages", t, func() {
+		b := []byte(`[{"template": "home-list", "path": "/staticlist", "content_types": ["static_page"], "default_sort": "title", "title": "Information pages", "localise_key": "HomeList"}]`)
+
+		Convey("When it is parsed", func() {
+			pages, err := ParseAggregationPages(b)
+
+			Convey("Then the pages are returned", func() {
+				So(err, ShouldBeNil)
+				So(pages, ShouldResemble, AggregationPages{
+					{
+						Template:        "home-list",
+						Path:            "/staticlist",
+						ContentTypes:    []string{"static_page"},
+						DefaultSort:     "title",
+						Title:           "Information pages",
+						LocaliseKeyName: "HomeList",
+					},
+				})
+			})
+		})
+	})
+
+	invalidRegistries := []struct {
+		name     string
+		registry string
+	}{
+		{name: "invalid json", registry: `{`},
+		{name: "no pages", registry: `[]`},
+		{name: "page without a template", registry: `[{"path": "/staticlist"}]`},
+		{name: "page with a relative path", registry: `[{"template": "home-list", "path": "staticlist"}]`},
+		{name: "page with an unknown handler", registry: `[{"template": "home-list", "path": "/staticlist", "handler": "unknown"}]`},
+		{name: "page with an unknown default sort", registry: `[{"template": "home-list", "path": "/staticlist", "default_sort": "unknown"}]`},
+		{name: "page with an unknown category", registry: `[{"template": "home-list", "path": "/staticlist", "categories": ["Unknown"]}]`},
+		{name: "duplicate templates", registry: `[{"template": "home-list", "path": "/staticlist"}, {"template": "home-list", "path": "/otherlist"}]`},
+		{name: "duplicate paths", registry: `[{"template": "home-list", "path": "/staticlist"}, {"template": "other-list", "path": "/staticlist"}]`},
+	}
+
+	Convey("Given an invalid registry of aggregation pages", t, func() {
+		for _, tc := range invalidRegistries {
+			Convey("When a registry with "+tc.name+" is parsed", func() {
+				pages, err := ParseAggregationPages([]byte(tc.registry))
+
+				Convey("Then an error is returned", func() {
+					So(err, ShouldNotBeNil)
+					So(pages, ShouldBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestLoadAggregationPages(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a registry of aggregation pages in a file", t, func() {
+		path := filepath.Join(t.TempDir(), "aggregation-pages.json")
+		err := os.WriteFile(path, []byte(`[{"template": "home-list", "path": "/staticlist", "title": "Information pages", "localise_key": "HomeList"}]`), 0o600)
+		So(err, ShouldBeNil)
+
+		Convey("When it is loaded", func() {
+			pages, err := LoadAggregationPages(path)
+
+			Convey("Then the pages are returned", func() {
+				So(err, ShouldBeNil)
+				So(pages, ShouldHaveLength, 1)
+				So(pages[0].Template, ShouldEqual, "home-list")
+			})
+		})
+	})
+
+	Convey("Given the file of the registry does not exist", t, func() {
+		Convey("When it is loaded", func() {
+			pages, err := LoadAggregationPages(filepath.Join(t.TempDir(), "missing.json"))
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(pages, ShouldBeNil)
+			})
+		})
+	})
+}
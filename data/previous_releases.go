@@ -0,0 +1,62 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	Edition          = "edition"
+	GroupBy          = "group-by"
+	GroupByYear      = "year"
+	EditionFilterErr = "edition-error"
+)
+
+// YearCount represents the number of releases published in a year
+type YearCount struct {
+	Year  int
+	Count int
+}
+
+// reviewEditionFilter retrieves the edition text filter from the query and checks that it does not contain special characters
+func reviewEditionFilter(ctx context.Context, urlQuery url.Values, validatedQueryParams *SearchURLParams) error {
+	editionFilter := strings.TrimSpace(urlQuery.Get(Edition))
+
+	if err := checkForSpecialCharacters(ctx, editionFilter); err != nil {
+		return err
+	}
+
+	validatedQueryParams.EditionFilter = editionFilter
+
+	return nil
+}
+
+// GetPreviousReleasesQuery gets the query that needs to be passed to the search-api to get the previous releases matching the edition text filter
+func GetPreviousReleasesQuery(validatedQueryParams SearchURLParams, parentType string) url.Values {
+	apiQuery := SetParentTypeOnSearchAPIQuery(validatedQueryParams, parentType)
+
+	if validatedQueryParams.EditionFilter != "" {
+		apiQuery.Set("q", validatedQueryParams.EditionFilter)
+	}
+
+	return apiQuery
+}
+
+// GetReleaseYearsQuery gets a page of the previous releases query without the date range or edition filter, so every
+// year with releases can be counted
+func GetReleaseYearsQuery(searchQuery url.Values, limit, offset int) url.Values {
+	apiQuery := url.Values{}
+	for key, values := range searchQuery {
+		apiQuery[key] = append([]string{}, values...)
+	}
+
+	apiQuery.Del("fromDate")
+	apiQuery.Del("toDate")
+	apiQuery.Del("q")
+	apiQuery.Set("limit", strconv.Itoa(limit))
+	apiQuery.Set("offset", strconv.Itoa(offset))
+
+	return apiQuery
+}
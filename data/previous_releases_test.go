@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitReviewPreviousReleasesQueryWithParams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a previous releases query with filters", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"edition":      []string{" March "},
+			"after-year":   []string{"2020"},
+			"before-year":  []string{"2020"},
+			"before-month": []string{"12"},
+			"before-day":   []string{"31"},
+			"group-by":     []string{"year"},
+		}
+
+		Convey("When ReviewPreviousReleasesQueryWithParams is called", func() {
+			validatedQueryParams, validationErrs := ReviewPreviousReleasesQueryWithParams(ctx, cfg, urlQuery, "/foo/bar")
+
+			Convey("Then the filters are validated", func() {
+				So(validationErrs, ShouldBeEmpty)
+				So(validatedQueryParams.URIPrefix, ShouldEqual, "/foo/bar")
+				So(validatedQueryParams.EditionFilter, ShouldEqual, "March")
+				So(validatedQueryParams.AfterDate.String(), ShouldEqual, "2020-01-01")
+				So(validatedQueryParams.BeforeDate.String(), ShouldEqual, "2020-12-31")
+				So(validatedQueryParams.GroupByYear, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a previous releases query with an invalid date range and edition filter", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"edition":     []string{"Mawrth ✓"},
+			"after-year":  []string{"2021"},
+			"before-year": []string{"2020"},
+		}
+
+		Convey("When ReviewPreviousReleasesQueryWithParams is called", func() {
+			_, validationErrs := ReviewPreviousReleasesQueryWithParams(ctx, cfg, urlQuery, "/foo/bar")
+
+			Convey("Then the validation errors are returned", func() {
+				So(validationErrs, ShouldHaveLength, 2)
+				So(validationErrs[0].ID, ShouldEqual, DateToErr)
+				So(validationErrs[1].ID, ShouldEqual, EditionFilterErr)
+			})
+		})
+	})
+}
+
+func TestUnitGetPreviousReleasesQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given validated previous releases parameters with an edition filter", t, func() {
+		validatedQueryParams := SearchURLParams{
+			EditionFilter: "March",
+			URIPrefix:     "/foo/bar",
+			Limit:         10,
+			Offset:        10,
+		}
+
+		Convey("When GetPreviousReleasesQuery is called", func() {
+			apiQuery := GetPreviousReleasesQuery(validatedQueryParams, "bulletin")
+
+			Convey("Then the edition filter is searched for in releases of the same type", func() {
+				So(apiQuery.Get("q"), ShouldEqual, "March")
+				So(apiQuery.Get("content_type"), ShouldEqual, "bulletin")
+				So(apiQuery.Get("uri_prefix"), ShouldEqual, "/foo/bar")
+			})
+
+			Convey("And the query to count the years of the releases does not have a date range or edition filter", func() {
+				yearsQuery := GetReleaseYearsQuery(apiQuery, 100, 200)
+
+				So(yearsQuery.Has("q"), ShouldBeFalse)
+				So(yearsQuery.Get("uri_prefix"), ShouldEqual, "/foo/bar")
+				So(yearsQuery.Has("fromDate"), ShouldBeFalse)
+				So(yearsQuery.Has("toDate"), ShouldBeFalse)
+				So(yearsQuery.Get("limit"), ShouldEqual, "100")
+				So(yearsQuery.Get("offset"), ShouldEqual, "200")
+				So(apiQuery.Get("offset"), ShouldEqual, "10")
+			})
+		})
+	})
+}
@@ -31,6 +31,9 @@ type SearchURLParams struct {
 	Offset               int
 	NLPWeightingEnabled  bool
 	URIPrefix            string
+	ReleaseType          ReleaseType
+	EditionFilter        string
+	GroupByYear          bool
 }
 
 const (
@@ -106,16 +109,42 @@ func handleValidationError(ctx context.Context, err error, description, id strin
 }
 
 // ReviewDataAggregationQueryWithParams ensures that all search parameter values given by the user are reviewed
-func ReviewDataAggregationQueryWithParams(ctx context.Context, cfg *config.Config, urlQuery url.Values) (sp SearchURLParams, validationErrs []core.ErrorItem) {
+func ReviewDataAggregationQueryWithParams(ctx context.Context, cfg *config.Config, urlQuery url.Values, template string) (sp SearchURLParams, validationErrs []core.ErrorItem) {
 	sp.Query = urlQuery.Get("q")
 	paginationErr := reviewPagination(ctx, cfg, urlQuery, &sp)
 	validationErrs = handleValidationError(ctx, paginationErr, "unable to review pagination for aggregation", PaginationErr, validationErrs)
 
+	dateErrs := reviewDateRange(urlQuery, &sp)
+	validationErrs = append(validationErrs, dateErrs...)
+
+	page, _ := GetAggregationPage(template)
+	reviewSort(ctx, urlQuery, &sp, page.GetDefaultSort(cfg))
+
+	contentTypeFilterError := reviewFilters(ctx, urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, contentTypeFilterError, "invalid content type filters set for aggregation", ContentTypeFilterErr, validationErrs)
+
+	topicFilterErr := reviewTopicFiltersForDataAggregation(urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, topicFilterErr, "invalid topic filters set for aggregation", TopicFilterErr, validationErrs)
+
+	populationTypeFilterErr := reviewPopulationTypeFilters(urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, populationTypeFilterErr, "invalid population types set for aggregation", PopulationTypeFilterErr, validationErrs)
+
+	dimensionsFilterErr := reviewDimensionsFilters(urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, dimensionsFilterErr, "invalid dimensions set for aggregation", DimensionsFilterErr, validationErrs)
+
+	queryStringErr := checkForSpecialCharacters(ctx, sp.Query)
+	validationErrs = handleValidationError(ctx, queryStringErr, "the query string did not pass review", QueryStringErr, validationErrs)
+
+	return sp, validationErrs
+}
+
+// reviewDateRange reviews the released after and released before dates and checks that they form a valid date range
+func reviewDateRange(urlQuery url.Values, validatedQueryParams *SearchURLParams) (validationErrs []core.ErrorItem) {
 	fromDate, vErrs := GetStartDate(urlQuery)
 	if len(vErrs) > 0 {
 		validationErrs = append(validationErrs, vErrs...)
 	}
-	sp.AfterDate = fromDate
+	validatedQueryParams.AfterDate = fromDate
 
 	toDate, vErrs := GetEndDate(urlQuery)
 	if len(vErrs) > 0 {
@@ -135,26 +164,9 @@ func ReviewDataAggregationQueryWithParams(ctx context.Context, cfg *config.Confi
 			toDate.fieldsetErrID = DateToErr
 		}
 	}
-	sp.BeforeDate = toDate
+	validatedQueryParams.BeforeDate = toDate
 
-	reviewSort(ctx, urlQuery, &sp, cfg.DefaultSort.Aggregation)
-
-	contentTypeFilterError := reviewFilters(ctx, urlQuery, &sp)
-	validationErrs = handleValidationError(ctx, contentTypeFilterError, "invalid content type filters set for aggregation", ContentTypeFilterErr, validationErrs)
-
-	topicFilterErr := reviewTopicFiltersForDataAggregation(urlQuery, &sp)
-	validationErrs = handleValidationError(ctx, topicFilterErr, "invalid topic filters set for aggregation", TopicFilterErr, validationErrs)
-
-	populationTypeFilterErr := reviewPopulationTypeFilters(urlQuery, &sp)
-	validationErrs = handleValidationError(ctx, populationTypeFilterErr, "invalid population types set for aggregation", PopulationTypeFilterErr, validationErrs)
-
-	dimensionsFilterErr := reviewDimensionsFilters(urlQuery, &sp)
-	validationErrs = handleValidationError(ctx, dimensionsFilterErr, "invalid dimensions set for aggregation", DimensionsFilterErr, validationErrs)
-
-	queryStringErr := checkForSpecialCharacters(ctx, sp.Query)
-	validationErrs = handleValidationError(ctx, queryStringErr, "the query string did not pass review", QueryStringErr, validationErrs)
-
-	return sp, validationErrs
+	return validationErrs
 }
 
 // ReviewPreviousReleasesQueryWithParams ensures that all search parameter values given by the user are reviewed
@@ -166,6 +178,14 @@ func ReviewPreviousReleasesQueryWithParams(ctx context.Context, cfg *config.Conf
 	paginationErr := reviewPagination(ctx, cfg, urlQuery, &sp)
 	validationErrs = handleValidationError(ctx, paginationErr, "unable to review pagination for previous releases", PaginationErr, validationErrs)
 	reviewSort(ctx, urlQuery, &sp, cfg.DefaultSort.PreviousReleases)
+
+	dateErrs := reviewDateRange(urlQuery, &sp)
+	validationErrs = append(validationErrs, dateErrs...)
+
+	editionFilterErr := reviewEditionFilter(ctx, urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, editionFilterErr, "the edition filter did not pass review", EditionFilterErr, validationErrs)
+
+	sp.GroupByYear = urlQuery.Get(GroupBy) == GroupByYear
 	return sp, validationErrs
 }
 
@@ -177,7 +197,10 @@ func ReviewRelatedDataQueryWithParams(ctx context.Context, cfg *config.Config, u
 	}
 	paginationErr := reviewPagination(ctx, cfg, urlQuery, &sp)
 	validationErrs = handleValidationError(ctx, paginationErr, "unable to review pagination for related data", PaginationErr, validationErrs)
-	reviewSort(ctx, urlQuery, &sp, cfg.DefaultSort.RelatedData)
+	reviewRelatedDataSort(ctx, urlQuery, &sp, cfg.DefaultSort.RelatedData)
+
+	contentTypeFilterError := reviewRelatedDataFilters(ctx, urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, contentTypeFilterError, "invalid content type filters set for related data", ContentTypeFilterErr, validationErrs)
 	return sp, validationErrs
 }
 
@@ -223,25 +246,8 @@ func GetSearchAPIQuery(validatedQueryParams SearchURLParams, censusTopicCache *c
 // GetDataAggregationQuery gets the query that needs to be passed to the search-api to get data aggregation results
 func GetDataAggregationQuery(validatedQueryParams SearchURLParams, template string) url.Values {
 	apiQuery := createSearchAPIQuery(validatedQueryParams)
-	var contentTypes = ""
-	switch template {
-	case "all-adhocs":
-		contentTypes = "static_adhoc"
-	case "all-methodologies":
-		contentTypes = "static_qmi," + "static_methodology," + "static_methodology_download"
-	case "home-datalist":
-		contentTypes = "static_adhoc," + "timeseries," + "dataset_landing_page," + "reference_tables"
-	case "home-publications":
-		contentTypes = "bulletin," + "article," + "article_download," + "compendium_landing_page"
-	case "published-requests":
-		contentTypes = "static_foi"
-	case "home-list":
-		contentTypes = "static_page"
-	case "home-methodology":
-		contentTypes = "static_qmi," + "static_methodology," + "static_methodology_download"
-	case "time-series-tool":
-		contentTypes = "timeseries"
-	}
+	page, _ := GetAggregationPage(template)
+	contentTypes := strings.Join(page.ContentTypes, ",")
 
 	if apiQuery.Get("content_type") == "" {
 		apiQuery.Set("content_type", contentTypes)
@@ -247,6 +247,53 @@ func TestUnitGetSearchAPIQuerySuccess(t *testing.T) {
 	})
 }
 
+func TestUnitGetDataAggregationQuerySuccess(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given validated query parameters without content type filters", t, func() {
+		validatedQueryParams := SearchURLParams{
+			Query: "housing",
+			Sort: Sort{
+				Query:           "release_date",
+				LocaliseKeyName: "ReleaseDate",
+			},
+			Limit:  10,
+			Offset: 0,
+		}
+
+		Convey("When GetDataAggregationQuery is called for a page in the registry", func() {
+			apiQuery := GetDataAggregationQuery(validatedQueryParams, "home-publications")
+
+			Convey("Then the content types of the page are requested", func() {
+				So(apiQuery["q"], ShouldResemble, []string{"housing"})
+				So(apiQuery["content_type"], ShouldResemble, []string{"bulletin,article,article_download,compendium_landing_page"})
+			})
+		})
+	})
+
+	Convey("Given validated query parameters with a content type filter", t, func() {
+		validatedQueryParams := SearchURLParams{
+			Filter: Filter{
+				Query:           []string{"article"},
+				LocaliseKeyName: []string{"Article"},
+			},
+			Sort: Sort{
+				Query:           "release_date",
+				LocaliseKeyName: "ReleaseDate",
+			},
+			Limit: 10,
+		}
+
+		Convey("When GetDataAggregationQuery is called for a page in the registry", func() {
+			apiQuery := GetDataAggregationQuery(validatedQueryParams, "home-publications")
+
+			Convey("Then the content types of the filter are requested", func() {
+				So(apiQuery["content_type"], ShouldResemble, []string{"article,article_download"})
+			})
+		})
+	})
+}
+
 func TestUnitCreateSearchAPIQuerySuccess(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	errs "github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+var (
+	// RelatedDataContentTypes represent the content types the related data of a release can be filtered by
+	RelatedDataContentTypes = []ContentType{Datasets, TimeSeries, UserRequestedData}
+
+	// RelatedDataSortOptions represent the list of all related data sort options
+	RelatedDataSortOptions = []Sort{ReleaseDate, Title}
+)
+
+// GetRelatedDataCategories returns the category of the related data content types where all the count is set to zero
+func GetRelatedDataCategories() []Category {
+	category := Category{
+		LocaliseKeyName: Data.LocaliseKeyName,
+		ContentTypes:    make([]ContentType, len(RelatedDataContentTypes)),
+	}
+
+	// To get a different reference of SubTypes - deep copy
+	for i, contentType := range RelatedDataContentTypes {
+		category.ContentTypes[i] = contentType
+		category.ContentTypes[i].Types = append([]string{}, contentType.Types...)
+	}
+
+	return []Category{category}
+}
+
+// GetRelatedDataTypes gets all the types of the related data content types chosen by the user
+func GetRelatedDataTypes(validatedQueryParams SearchURLParams) []string {
+	return getSubFilters(validatedQueryParams.Filter.Query)
+}
+
+// reviewRelatedDataFilters retrieves filters from query and checks if they are one of the related data content types
+func reviewRelatedDataFilters(ctx context.Context, urlQuery url.Values, validatedQueryParams *SearchURLParams) error {
+	if err := reviewFilters(ctx, urlQuery, validatedQueryParams); err != nil {
+		return err
+	}
+
+	for _, filter := range validatedQueryParams.Filter.Query {
+		isRelatedDataFilter := slices.ContainsFunc(RelatedDataContentTypes, func(contentType ContentType) bool {
+			return contentType.Group == filter
+		})
+
+		if !isRelatedDataFilter {
+			log.Info(ctx, "filter not available for related data", log.Data{"requested_filter": filter})
+			return errs.ErrContentTypeNotFound
+		}
+	}
+
+	return nil
+}
+
+// reviewRelatedDataSort retrieves sort from query and checks if it is one of the related data sort options
+func reviewRelatedDataSort(ctx context.Context, urlQuery url.Values, validatedQueryParams *SearchURLParams, defaultSort string) {
+	reviewSort(ctx, urlQuery, validatedQueryParams, defaultSort)
+
+	if !slices.Contains(RelatedDataSortOptions, validatedQueryParams.Sort) {
+		log.Warn(ctx, "sort chosen not available in related data sort options - using default sort", log.Data{
+			"default": defaultSort,
+		})
+
+		validatedQueryParams.Sort = sortOptions[defaultSort]
+	}
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitReviewRelatedDataQueryWithParams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a related data query with content type filters and a sort", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"filter": []string{"datasets", "user_requested_data"},
+			"sort":   []string{"release_date"},
+		}
+
+		Convey("When ReviewRelatedDataQueryWithParams is called", func() {
+			validatedQueryParams, validationErrs := ReviewRelatedDataQueryWithParams(ctx, cfg, urlQuery, "/foo/bar")
+
+			Convey("Then the filters and sort are validated", func() {
+				So(validationErrs, ShouldBeEmpty)
+				So(validatedQueryParams.Filter.Query, ShouldResemble, []string{"datasets", "user_requested_data"})
+				So(validatedQueryParams.Sort, ShouldResemble, ReleaseDate)
+			})
+
+			Convey("And the types of the content types chosen are returned", func() {
+				So(GetRelatedDataTypes(validatedQueryParams), ShouldResemble, []string{"dataset_landing_page", "timeseries_dataset", "static_adhoc"})
+			})
+		})
+	})
+
+	Convey("Given a related data query with a sort not available for related data", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"sort": []string{"relevance"},
+		}
+
+		Convey("When ReviewRelatedDataQueryWithParams is called", func() {
+			validatedQueryParams, validationErrs := ReviewRelatedDataQueryWithParams(ctx, cfg, urlQuery, "/foo/bar")
+
+			Convey("Then the default sort is used", func() {
+				So(validationErrs, ShouldBeEmpty)
+				So(validatedQueryParams.Sort, ShouldResemble, Title)
+			})
+		})
+	})
+
+	Convey("Given a related data query with a content type filter not available for related data", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"filter": []string{"bulletin"},
+		}
+
+		Convey("When ReviewRelatedDataQueryWithParams is called", func() {
+			_, validationErrs := ReviewRelatedDataQueryWithParams(ctx, cfg, urlQuery, "/foo/bar")
+
+			Convey("Then a content type filter error is returned", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].ID, ShouldEqual, ContentTypeFilterErr)
+			})
+		})
+	})
+}
+
+func TestUnitGetRelatedDataCategories(t *testing.T) {
+	t.Parallel()
+
+	Convey("When GetRelatedDataCategories is called", t, func() {
+		categories := GetRelatedDataCategories()
+
+		Convey("Then the related data content types are returned with no counts", func() {
+			So(categories, ShouldHaveLength, 1)
+			So(categories[0].Count, ShouldEqual, 0)
+			So(categories[0].ContentTypes, ShouldHaveLength, 3)
+			So(categories[0].ContentTypes[0].Group, ShouldEqual, Datasets.Group)
+			So(categories[0].ContentTypes[1].Group, ShouldEqual, TimeSeries.Group)
+			So(categories[0].ContentTypes[2].Group, ShouldEqual, UserRequestedData.Group)
+		})
+
+		Convey("And changing them does not change the related data content types", func() {
+			categories[0].ContentTypes[0].Count = 1
+			categories[0].ContentTypes[0].Types[0] = "changed"
+
+			So(RelatedDataContentTypes[0].Count, ShouldEqual, 0)
+			So(RelatedDataContentTypes[0].Types[0], ShouldEqual, "dataset_landing_page")
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/log.go/v2/log"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+)
+
+// ReleaseType represents a tab of the release calendar
+type ReleaseType struct {
+	Query           string `json:"query"`
+	LocaliseKeyName string `json:"localise_key"`
+}
+
+var (
+	// Upcoming - releases which are yet to be published
+	Upcoming = ReleaseType{
+		Query:           "type-upcoming",
+		LocaliseKeyName: "ReleaseTypeUpcoming",
+	}
+
+	// Published - releases which have been published
+	Published = ReleaseType{
+		Query:           "type-published",
+		LocaliseKeyName: "ReleaseTypePublished",
+	}
+
+	// Cancelled - releases which have been cancelled
+	Cancelled = ReleaseType{
+		Query:           "type-cancelled",
+		LocaliseKeyName: "ReleaseTypeCancelled",
+	}
+
+	// ReleaseTypes represent the tabs of the release calendar in the order they are shown
+	ReleaseTypes = []ReleaseType{Upcoming, Published, Cancelled}
+)
+
+// ReviewReleaseCalendarQuery ensures that all release calendar parameter values given by the user are reviewed
+func ReviewReleaseCalendarQuery(ctx context.Context, cfg *config.Config, urlQuery url.Values, dataTopicCache *cache.Topic) (sp SearchURLParams, validationErrs []core.ErrorItem) {
+	sp.Query = urlQuery.Get("q")
+
+	paginationErr := reviewPagination(ctx, cfg, urlQuery, &sp)
+	validationErrs = handleValidationError(ctx, paginationErr, "unable to review pagination for release calendar", PaginationErr, validationErrs)
+
+	dateErrs := reviewDateRange(urlQuery, &sp)
+	validationErrs = append(validationErrs, dateErrs...)
+
+	reviewReleaseType(ctx, urlQuery, &sp)
+
+	reviewSort(ctx, urlQuery, &sp, ReleaseDate.Query)
+
+	topicFilterErr := reviewReleaseCalendarTopicFilters(ctx, urlQuery, &sp, dataTopicCache)
+	validationErrs = handleValidationError(ctx, topicFilterErr, "invalid topic filters set for release calendar", TopicFilterErr, validationErrs)
+
+	queryStringErr := checkForSpecialCharacters(ctx, sp.Query)
+	validationErrs = handleValidationError(ctx, queryStringErr, "the query string did not pass review", QueryStringErr, validationErrs)
+
+	return sp, validationErrs
+}
+
+// reviewReleaseType retrieves the release type from the query and checks if it is one of the release types, upcoming releases are shown by default
+func reviewReleaseType(ctx context.Context, urlQuery url.Values, validatedQueryParams *SearchURLParams) {
+	releaseTypeQuery := urlQuery.Get(Type)
+
+	validatedQueryParams.ReleaseType = Upcoming
+	for _, releaseType := range ReleaseTypes {
+		if releaseType.Query == releaseTypeQuery {
+			validatedQueryParams.ReleaseType = releaseType
+			return
+		}
+	}
+
+	if releaseTypeQuery != "" {
+		log.Warn(ctx, "release type chosen not available - using default release type", log.Data{
+			"release_type": releaseTypeQuery,
+			"default":      Upcoming.Query,
+		})
+	}
+}
+
+// reviewReleaseCalendarTopicFilters retrieves topic ids from query, checks if they are one of the data topics, and updates validatedQueryParams
+func reviewReleaseCalendarTopicFilters(ctx context.Context, urlQuery url.Values, validatedQueryParams *SearchURLParams, dataTopicCache *cache.Topic) error {
+	topicIDs := strings.Split(urlQuery.Get("topics"), ",")
+
+	dataTopicIDs := make(map[string]bool)
+	if dataTopicCache != nil && dataTopicCache.List != nil {
+		for _, subtopic := range dataTopicCache.List.GetSubtopics() {
+			dataTopicIDs[subtopic.ID] = true
+		}
+	}
+
+	validatedTopicFilters := []string{}
+	for i := range topicIDs {
+		topicID := strings.TrimSpace(topicIDs[i])
+		if topicID == "" {
+			continue
+		}
+
+		if !dataTopicIDs[topicID] {
+			err := errs.ErrTopicNotFound
+			log.Error(ctx, "failed to find topic id in data topic cache", err, log.Data{"topic_id": topicID})
+			return err
+		}
+
+		validatedTopicFilters = append(validatedTopicFilters, topicID)
+	}
+
+	validatedQueryParams.TopicFilter = strings.Join(validatedTopicFilters, ",")
+
+	return nil
+}
+
+// GetReleaseCalendarQuery gets the query that needs to be passed to the release calendar endpoint of the search-api
+func GetReleaseCalendarQuery(validatedQueryParams SearchURLParams) url.Values {
+	return url.Values{
+		"query":    []string{validatedQueryParams.Query},
+		"fromDate": []string{validatedQueryParams.AfterDate.String()},
+		"toDate":   []string{validatedQueryParams.BeforeDate.String()},
+		Type:       []string{validatedQueryParams.ReleaseType.Query},
+		"sort":     []string{getReleaseCalendarSort(validatedQueryParams)},
+		"limit":    []string{strconv.Itoa(validatedQueryParams.Limit)},
+		"offset":   []string{strconv.Itoa(validatedQueryParams.Offset)},
+	}
+}
+
+// getReleaseCalendarSort maps the sort to the sort of the release calendar endpoint, where upcoming releases are
+// sorted with the soonest release first and other releases with the most recent release first
+func getReleaseCalendarSort(validatedQueryParams SearchURLParams) string {
+	switch validatedQueryParams.Sort.Query {
+	case Relevance.Query:
+		return Relevance.Query
+	case Title.Query:
+		return "title_asc"
+	}
+
+	if validatedQueryParams.ReleaseType == Upcoming {
+		return "release_date_asc"
+	}
+	return "release_date_desc"
+}
+
+// GetReleaseCalendarTopics returns the root data topics which the release calendar can be filtered by, ordered by name
+func GetReleaseCalendarTopics(dataTopicCache *cache.Topic) []Topic {
+	if dataTopicCache == nil || dataTopicCache.List == nil {
+		return nil
+	}
+
+	topics := []Topic{}
+	for _, subtopic := range dataTopicCache.List.GetSubtopics() {
+		if subtopic.ParentID != "" {
+			continue
+		}
+
+		topics = append(topics, Topic{
+			LocaliseKeyName: subtopic.LocaliseKeyName,
+			Query:           subtopic.ID,
+			ShowInWebUI:     true,
+		})
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].LocaliseKeyName < topics[j].LocaliseKeyName
+	})
+
+	return topics
+}
+
+// GetReleaseCalendarTopicIDs returns the ids of the topics filtered by and of every topic beneath them in the data topic
+// cache, which are the canonical topics of the releases to show. Nil is returned if the releases are not filtered
+func GetReleaseCalendarTopicIDs(topicFilter string, dataTopicCache *cache.Topic) map[string]bool {
+	if topicFilter == "" {
+		return nil
+	}
+
+	topicIDs := make(map[string]bool)
+	for _, topicID := range strings.Split(topicFilter, ",") {
+		topicIDs[topicID] = true
+	}
+
+	if dataTopicCache == nil || dataTopicCache.List == nil {
+		return topicIDs
+	}
+
+	parentIDs := make(map[string]string)
+	for _, subtopic := range dataTopicCache.List.GetSubtopics() {
+		parentIDs[subtopic.ID] = subtopic.ParentID
+	}
+
+	for id := range parentIDs {
+		// the depth stops a cycle in the cache from being walked forever
+		for ancestor, depth := parentIDs[id], 0; ancestor != "" && depth < len(parentIDs); ancestor, depth = parentIDs[ancestor], depth+1 {
+			if topicIDs[ancestor] {
+				topicIDs[id] = true
+				break
+			}
+		}
+	}
+
+	return topicIDs
+}
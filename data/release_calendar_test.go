@@ -0,0 +1,157 @@
+package data
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitReviewReleaseCalendarQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dataTopicCache := cache.GetMockRootTopic(cache.DataTopicCacheKey)
+
+	Convey("Given a valid release calendar query", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		urlQuery := url.Values{
+			"q":            []string{"inflation"},
+			"release-type": []string{"type-published"},
+			"topics":       []string{"6734,1234"},
+			"after-year":   []string{"2023"},
+			"after-month":  []string{"1"},
+			"after-day":    []string{"1"},
+			"page":         []string{"2"},
+		}
+
+		Convey("When ReviewReleaseCalendarQuery is called", func() {
+			validatedQueryParams, validationErrs := ReviewReleaseCalendarQuery(ctx, cfg, urlQuery, dataTopicCache)
+
+			Convey("Then the query parameters are validated", func() {
+				So(validationErrs, ShouldBeEmpty)
+				So(validatedQueryParams.Query, ShouldEqual, "inflation")
+				So(validatedQueryParams.ReleaseType, ShouldResemble, Published)
+				So(validatedQueryParams.TopicFilter, ShouldEqual, "6734,1234")
+				So(validatedQueryParams.AfterDate.String(), ShouldEqual, "2023-01-01")
+				So(validatedQueryParams.CurrentPage, ShouldEqual, 2)
+				So(validatedQueryParams.Sort.Query, ShouldEqual, ReleaseDate.Query)
+			})
+		})
+	})
+
+	Convey("Given a release calendar query without a release type", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		Convey("When ReviewReleaseCalendarQuery is called", func() {
+			validatedQueryParams, validationErrs := ReviewReleaseCalendarQuery(ctx, cfg, url.Values{"release-type": []string{"type-unknown"}}, dataTopicCache)
+
+			Convey("Then upcoming releases are shown", func() {
+				So(validationErrs, ShouldBeEmpty)
+				So(validatedQueryParams.ReleaseType, ShouldResemble, Upcoming)
+			})
+		})
+	})
+
+	Convey("Given a release calendar query with a topic which is not a data topic", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		Convey("When ReviewReleaseCalendarQuery is called", func() {
+			_, validationErrs := ReviewReleaseCalendarQuery(ctx, cfg, url.Values{"topics": []string{"9999"}}, dataTopicCache)
+
+			Convey("Then a validation error is returned", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].ID, ShouldEqual, TopicFilterErr)
+			})
+		})
+	})
+}
+
+func TestUnitGetReleaseCalendarQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given validated upcoming release calendar parameters sorted by release date", t, func() {
+		validatedQueryParams := SearchURLParams{
+			Query:       "inflation",
+			ReleaseType: Upcoming,
+			Sort:        ReleaseDate,
+			Limit:       10,
+			Offset:      20,
+		}
+
+		Convey("When GetReleaseCalendarQuery is called", func() {
+			apiQuery := GetReleaseCalendarQuery(validatedQueryParams)
+
+			Convey("Then the parameters are named as the release calendar endpoint reads them", func() {
+				So(slices.Sorted(maps.Keys(apiQuery)), ShouldResemble, []string{"fromDate", "limit", "offset", "query", "release-type", "sort", "toDate"})
+			})
+
+			Convey("And the soonest releases are requested first", func() {
+				So(apiQuery.Get("query"), ShouldEqual, "inflation")
+				So(apiQuery.Get("release-type"), ShouldEqual, "type-upcoming")
+				So(apiQuery.Get("sort"), ShouldEqual, "release_date_asc")
+				So(apiQuery.Get("limit"), ShouldEqual, "10")
+				So(apiQuery.Get("offset"), ShouldEqual, "20")
+			})
+		})
+
+		Convey("When published releases are requested", func() {
+			validatedQueryParams.ReleaseType = Published
+			apiQuery := GetReleaseCalendarQuery(validatedQueryParams)
+
+			Convey("Then the most recent releases are requested first", func() {
+				So(apiQuery.Get("sort"), ShouldEqual, "release_date_desc")
+			})
+		})
+	})
+}
+
+func TestUnitGetReleaseCalendarTopics(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the data topic cache", t, func() {
+		dataTopicCache := cache.GetMockRootTopic(cache.DataTopicCacheKey)
+
+		Convey("When GetReleaseCalendarTopics is called", func() {
+			topics := GetReleaseCalendarTopics(dataTopicCache)
+
+			Convey("Then the root topics are returned ordered by name", func() {
+				So(topics, ShouldResemble, []Topic{
+					{LocaliseKeyName: "Economy", Query: "6734", ShowInWebUI: true},
+					{LocaliseKeyName: "International Migration", Query: "1234", ShowInWebUI: true},
+				})
+			})
+		})
+	})
+}
+
+func TestUnitGetReleaseCalendarTopicIDs(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the data topic cache", t, func() {
+		dataTopicCache := cache.GetMockRootTopic(cache.DataTopicCacheKey)
+
+		Convey("When the releases are filtered by a root topic", func() {
+			topicIDs := GetReleaseCalendarTopicIDs("6734", dataTopicCache)
+
+			Convey("Then the topic and every topic beneath it are returned", func() {
+				So(topicIDs, ShouldResemble, map[string]bool{"6734": true, "1834": true, "8268": true, "3687": true})
+			})
+		})
+
+		Convey("When the releases are not filtered", func() {
+			Convey("Then no topics are returned", func() {
+				So(GetReleaseCalendarTopicIDs("", dataTopicCache), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -25,6 +25,21 @@ Feature: Healthcheck endpoint should inform the health of service
             "status": "OK",
             "status_code": 200,
             "message": "api-router is ok"
+          },
+          {
+            "name": "Census topic cache",
+            "status": "OK",
+            "message": "census topic cache is waiting to be populated"
+          },
+          {
+            "name": "Data topic cache",
+            "status": "OK",
+            "message": "data topic cache is waiting to be populated"
+          },
+          {
+            "name": "Navigation cache",
+            "status": "OK",
+            "message": "navigation cache is ok"
           }
         ]
       }
@@ -54,6 +69,21 @@ Feature: Healthcheck endpoint should inform the health of service
             "status": "WARNING",
             "status_code": 429,
             "message": "api-router is degraded, but at least partially functioning"
+          },
+          {
+            "name": "Census topic cache",
+            "status": "OK",
+            "message": "census topic cache is waiting to be populated"
+          },
+          {
+            "name": "Data topic cache",
+            "status": "OK",
+            "message": "data topic cache is waiting to be populated"
+          },
+          {
+            "name": "Navigation cache",
+            "status": "OK",
+            "message": "navigation cache is ok"
           }
         ]
       }
@@ -83,6 +113,21 @@ Feature: Healthcheck endpoint should inform the health of service
             "status": "CRITICAL",
             "status_code": 500,
             "message": "api-router functionality is unavailable or non-functioning"
+          },
+          {
+            "name": "Census topic cache",
+            "status": "OK",
+            "message": "census topic cache is waiting to be populated"
+          },
+          {
+            "name": "Data topic cache",
+            "status": "OK",
+            "message": "data topic cache is waiting to be populated"
+          },
+          {
+            "name": "Navigation cache",
+            "status": "OK",
+            "message": "navigation cache is ok"
           }
         ]
       }
@@ -114,6 +159,21 @@ Feature: Healthcheck endpoint should inform the health of service
             "status": "CRITICAL",
             "status_code": 500,
             "message": "api-router functionality is unavailable or non-functioning"
+          },
+          {
+            "name": "Census topic cache",
+            "status": "OK",
+            "message": "census topic cache is waiting to be populated"
+          },
+          {
+            "name": "Data topic cache",
+            "status": "OK",
+            "message": "data topic cache is waiting to be populated"
+          },
+          {
+            "name": "Navigation cache",
+            "status": "OK",
+            "message": "navigation cache is ok"
           }
         ]
       }
@@ -47,6 +47,14 @@ Feature: Previous Releases
     Then the HTTP status code should be "308"
     And the response header "Location" should be "/my-new-bulletin/editions"
 
+  Scenario: GET /previousreleases with a migration link keeps the page query parameter
+    Given there is a Search API that gives a successful response and returns 1 results
+    And get page data request to zebedee for "/economy/latest" returns a page with migration link "/my-new-bulletin"
+    And the search controller is running
+    When I GET "/economy/previousreleases?page=2&sort=title"
+    Then the HTTP status code should be "308"
+    And the response header "Location" should be "/my-new-bulletin/editions?page=2"
+
   Scenario: GET /previousreleases and breadcrumb request errors
     Given there is a Search API that gives a successful response and returns 3 results
     And get page data request to zebedee for "/economy/latest" returns a page of type "bulletin" with status 200
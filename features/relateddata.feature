@@ -49,6 +49,13 @@ Feature: Related Data
     Then the HTTP status code should be "308"
     And the response header "Location" should be "/new-weekly-earnings/related-data"
 
+  Scenario: GET /relateddata of an edition with a migration link
+    Given get page data request to zebedee for "/employmentandlabourmarket/bulletin1/2024" returns a page with migration link "/new-weekly-earnings/2024"
+    And the search controller is running
+    When I GET "/employmentandlabourmarket/bulletin1/2024/relateddata?page=3"
+    Then the HTTP status code should be "308"
+    And the response header "Location" should be "/new-weekly-earnings/2024/related-data?page=3"
+
   Scenario: GET /relateddata and breadcrumb request errors
     Given there is a Search API that gives a successful response and returns 3 results
     And get page data request to zebedee for "/employmentandlabourmarket/peopleinwork/bulletin1" returns a page of type "bulletin" with status 200
@@ -89,3 +89,9 @@ Feature: Search
       }
       """
     And the page should be accessible
+
+  Scenario: GET /search with legacy query parameters
+    Given the search controller is running
+    When I GET "/search?query=test+query&size=20&sortBy=release_date"
+    Then the HTTP status code should be "301"
+    And the response header "Location" should be "/search?limit=20&q=test+query&sort=release_date"
@@ -58,6 +58,7 @@ func NewSearchControllerComponent() (c *Component, err error) {
 
 	c.Config.EnableAggregationPages = true
 	c.Config.EnableTopicAggregationPages = true
+	c.Config.EnableCacheReadinessGate = false
 
 	log.Info(ctx, "configuration for component test", log.Data{"config": c.Config})
 
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// maxTopicChangeNotificationSize is the maximum size in bytes of a request body sent to the cache invalidation webhook
+const maxTopicChangeNotificationSize = 64 * 1024
+
+// TopicChangeNotification is the body of a request to the cache invalidation webhook
+type TopicChangeNotification struct {
+	TopicIDs []string `json:"topic_ids"`
+}
+
+// CacheInvalidation handles notifications of changed topics sent to the cache invalidation webhook by refreshing the
+// cached data of each changed topic. Requests are authorised with the webhook secret as a bearer token
+func CacheInvalidation(secret string, invalidator cache.TopicInvalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		token := []byte(req.Header.Get("Authorization"))
+		if secret == "" || subtle.ConstantTimeCompare(token, []byte(Bearer+secret)) != 1 {
+			log.Warn(ctx, "unauthorised cache invalidation request")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var notification TopicChangeNotification
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxTopicChangeNotificationSize)).Decode(&notification); err != nil {
+			log.Warn(ctx, "invalid cache invalidation request body", log.FormatErrors([]error{err}))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(notification.TopicIDs) == 0 || slices.Contains(notification.TopicIDs, "") {
+			log.Warn(ctx, "cache invalidation request contains no topic ids or an empty topic id")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := invalidator.InvalidateTopics(ctx, notification.TopicIDs); err != nil {
+			log.Error(ctx, "cache invalidation request failed", err, log.Data{"topic_ids": notification.TopicIDs})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testWebhookSecret = "test-secret"
+
+// testTopicInvalidator records the topics it is asked to invalidate and how many times it is called
+type testTopicInvalidator struct {
+	topicIDs []string
+	calls    int
+	err      error
+}
+
+func (i *testTopicInvalidator) InvalidateTopics(ctx context.Context, topicIDs []string) error {
+	i.topicIDs = append(i.topicIDs, topicIDs...)
+	i.calls++
+	return i.err
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(authorization, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/cache/invalidate", strings.NewReader(body))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return req
+	}
+
+	Convey("Given the cache invalidation handler", t, func() {
+		invalidator := &testTopicInvalidator{}
+		handler := CacheInvalidation(testWebhookSecret, invalidator)
+		w := httptest.NewRecorder()
+
+		Convey("When a notification of changed topics is received with the webhook secret", func() {
+			handler.ServeHTTP(w, newRequest(Bearer+testWebhookSecret, `{"topic_ids": ["1234", "5678"]}`))
+
+			Convey("Then the topics are invalidated together", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(invalidator.topicIDs, ShouldResemble, []string{"1234", "5678"})
+				So(invalidator.calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a notification is received without the webhook secret", func() {
+			handler.ServeHTTP(w, newRequest(Bearer+"wrong-secret", `{"topic_ids": ["1234"]}`))
+
+			Convey("Then the request is unauthorised", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+				So(invalidator.topicIDs, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a notification is received with an invalid body", func() {
+			handler.ServeHTTP(w, newRequest(Bearer+testWebhookSecret, `{"topic_ids": [`))
+
+			Convey("Then the request is rejected as bad", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(invalidator.topicIDs, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a notification is received without any topic ids", func() {
+			handler.ServeHTTP(w, newRequest(Bearer+testWebhookSecret, `{"topic_ids": []}`))
+
+			Convey("Then the request is rejected as bad", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When invalidating a topic fails", func() {
+			invalidator.err = errors.New("topic api unavailable")
+			handler.ServeHTTP(w, newRequest(Bearer+testWebhookSecret, `{"topic_ids": ["1234"]}`))
+
+			Convey("Then an internal server error is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+
+	Convey("Given the cache invalidation handler without a webhook secret", t, func() {
+		invalidator := &testTopicInvalidator{}
+		handler := CacheInvalidation("", invalidator)
+		w := httptest.NewRecorder()
+
+		Convey("When a notification is received with an empty bearer token", func() {
+			handler.ServeHTTP(w, newRequest(Bearer, `{"topic_ids": ["1234"]}`))
+
+			Convey("Then the request is unauthorised", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
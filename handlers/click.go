@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Click records the click on a search result followed through its signed click link, and redirects to the result
+func (sh *SearchHandler) Click() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		event, err := sh.Analytics.ParseClick(req.URL.Query())
+		if err != nil {
+			log.Warn(req.Context(), "invalid search result click link", log.FormatErrors([]error{err}))
+			setStatusCode(w, req, apperrors.ErrInvalidClickLink)
+			return
+		}
+
+		sh.Analytics.Record(event)
+		http.Redirect(w, req, event.URI, http.StatusFound)
+	}
+}
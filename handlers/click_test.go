@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// recordingSink is a sink which keeps the events sent to it
+type recordingSink struct {
+	events []analytics.Event
+}
+
+func (s *recordingSink) Send(_ context.Context, events []analytics.Event) error {
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestUnitClick(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a search handler which tracks clicks", t, func() {
+		sink := &recordingSink{}
+		recorder := analytics.NewRecorder(sink, analytics.Config{BatchSize: 10, SampleRate: 1, ClickSecret: "secret"})
+		recorder.Start(context.Background())
+		sh := &SearchHandler{Analytics: recorder}
+
+		Convey("When a signed click link is followed", func() {
+			link := recorder.ClickURL(analytics.Event{URI: "/economy/inflation", Query: "cpi", Rank: 3, Page: 1, Template: "search", Lang: "en"})
+			w := httptest.NewRecorder()
+			sh.Click()(w, httptest.NewRequest(http.MethodGet, link, http.NoBody))
+			So(recorder.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then the user is redirected to the search result and the click is recorded", func() {
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldEqual, "/economy/inflation")
+				So(sink.events, ShouldHaveLength, 1)
+				So(sink.events[0].Type, ShouldEqual, analytics.EventClick)
+				So(sink.events[0].Rank, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When a click link which has not been signed is followed", func() {
+			w := httptest.NewRecorder()
+			sh.Click()(w, httptest.NewRequest(http.MethodGet, analytics.ClickPath+"?uri=https://example.com", http.NoBody))
+			So(recorder.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then a 400 is returned without redirecting or recording a click", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Location"), ShouldBeEmpty)
+				So(sink.events, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestUnitAnalyticsStage(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a request whose search results have been mapped", t, func() {
+		sink := &recordingSink{}
+		recorder := analytics.NewRecorder(sink, analytics.Config{BatchSize: 10, SampleRate: 1, ClickSecret: "secret"})
+		recorder.Start(context.Background())
+
+		rc := &RequestContext{
+			Analytics:     recorder,
+			Lang:          "en",
+			AggCfg:        AggregationConfig{TemplateName: "search"},
+			SearchCount:   25,
+			SearchLatency: 120 * time.Millisecond,
+			QueryParams: data.SearchURLParams{
+				Query:       "Inflation  jane@example.com",
+				Filter:      data.Filter{Query: []string{"bulletin", "article"}},
+				Sort:        data.Sort{Query: "relevance"},
+				CurrentPage: 2,
+				Offset:      10,
+			},
+		}
+		rc.Page.Data.Response.Items = []model.ContentItem{{URI: "/economy/a"}, {URI: "/economy/b"}}
+
+		Convey("When the analytics stage runs", func() {
+			So(analyticsStage(rc), ShouldBeNil)
+			So(recorder.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then the search is recorded with its scrubbed query, filters and results", func() {
+				So(sink.events, ShouldHaveLength, 1)
+				So(sink.events[0], ShouldResemble, analytics.Event{
+					Type:        analytics.EventSearch,
+					Time:        sink.events[0].Time,
+					Template:    "search",
+					Query:       "inflation [email]",
+					Filters:     map[string]string{"content_type": "bulletin,article", "sort": "relevance"},
+					ResultCount: 25,
+					Page:        2,
+					Lang:        "en",
+					LatencyMS:   120,
+				})
+			})
+
+			Convey("Then each search result links through the click tracking with its rank", func() {
+				items := rc.Page.Data.Response.Items
+				So(items[0].ClickURI, ShouldStartWith, analytics.ClickPath+"?")
+				So(items[0].ClickURI, ShouldContainSubstring, "rank=11")
+				So(items[1].ClickURI, ShouldContainSubstring, "rank=12")
+			})
+		})
+	})
+
+	Convey("Given a request without search analytics", t, func() {
+		rc := &RequestContext{}
+		rc.Page.Data.Response.Items = []model.ContentItem{{URI: "/economy/a"}}
+
+		Convey("When the analytics stage runs", func() {
+			So(analyticsStage(rc), ShouldBeNil)
+
+			Convey("Then the search results link straight to their pages", func() {
+				So(rc.Page.Data.Response.Items[0].ClickURI, ShouldBeEmpty)
+			})
+		})
+	})
+}
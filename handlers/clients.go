@@ -8,6 +8,7 @@ import (
 	searchModels "github.com/ONSdigital/dp-search-api/models"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
 	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
 
 	topicModels "github.com/ONSdigital/dp-topic-api/models"
 	topicSDK "github.com/ONSdigital/dp-topic-api/sdk"
@@ -37,6 +38,7 @@ type RenderClient interface {
 
 // SearchClient is an interface with methods required for a search client
 type SearchClient interface {
+	GetReleaseCalendarEntries(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error)
 	GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error)
 	PostSearchURIs(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error)
 }
@@ -46,6 +48,7 @@ type ZebedeeClient interface {
 	GetHomepageContent(ctx context.Context, userAuthToken, collectionID, lang, path string) (m zebedee.HomepageContent, err error)
 	GetPageData(ctx context.Context, userAuthToken, collectionID, lang, path string) (m zebedee.PageData, err error)
 	GetBreadcrumb(ctx context.Context, userAccessToken, collectionID, lang, uri string) (bc []zebedee.Breadcrumb, err error)
+	GetCollection(ctx context.Context, userAccessToken, collectionID string) (m zebedee.Collection, err error)
 }
 
 // TopicClient is an interface with methods required for a zebedee client
@@ -14,6 +14,7 @@ import (
 	searchModels "github.com/ONSdigital/dp-search-api/models"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
 	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
 	topicModels "github.com/ONSdigital/dp-topic-api/models"
 	topicSDK "github.com/ONSdigital/dp-topic-api/sdk"
 	topicError "github.com/ONSdigital/dp-topic-api/sdk/errors"
@@ -144,6 +145,9 @@ var _ SearchClient = &SearchClientMock{}
 //
 //		// make and configure a mocked SearchClient
 //		mockedSearchClient := &SearchClientMock{
+//			GetReleaseCalendarEntriesFunc: func(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+//				panic("mock out the GetReleaseCalendarEntries method")
+//			},
 //			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
 //				panic("mock out the GetSearch method")
 //			},
@@ -157,6 +161,9 @@ var _ SearchClient = &SearchClientMock{}
 //
 //	}
 type SearchClientMock struct {
+	// GetReleaseCalendarEntriesFunc mocks the GetReleaseCalendarEntries method.
+	GetReleaseCalendarEntriesFunc func(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error)
+
 	// GetSearchFunc mocks the GetSearch method.
 	GetSearchFunc func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error)
 
@@ -165,6 +172,13 @@ type SearchClientMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetReleaseCalendarEntries holds details about calls to the GetReleaseCalendarEntries method.
+		GetReleaseCalendarEntries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Options is the options argument value.
+			Options searchSDK.Options
+		}
 		// GetSearch holds details about calls to the GetSearch method.
 		GetSearch []struct {
 			// Ctx is the ctx argument value.
@@ -182,8 +196,45 @@ type SearchClientMock struct {
 			UrisRequest searchAPI.URIsRequest
 		}
 	}
-	lockGetSearch      sync.RWMutex
-	lockPostSearchURIs sync.RWMutex
+	lockGetReleaseCalendarEntries sync.RWMutex
+	lockGetSearch                 sync.RWMutex
+	lockPostSearchURIs            sync.RWMutex
+}
+
+// GetReleaseCalendarEntries calls GetReleaseCalendarEntriesFunc.
+func (mock *SearchClientMock) GetReleaseCalendarEntries(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+	if mock.GetReleaseCalendarEntriesFunc == nil {
+		panic("SearchClientMock.GetReleaseCalendarEntriesFunc: method is nil but SearchClient.GetReleaseCalendarEntries was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Options searchSDK.Options
+	}{
+		Ctx:     ctx,
+		Options: options,
+	}
+	mock.lockGetReleaseCalendarEntries.Lock()
+	mock.calls.GetReleaseCalendarEntries = append(mock.calls.GetReleaseCalendarEntries, callInfo)
+	mock.lockGetReleaseCalendarEntries.Unlock()
+	return mock.GetReleaseCalendarEntriesFunc(ctx, options)
+}
+
+// GetReleaseCalendarEntriesCalls gets all the calls that were made to GetReleaseCalendarEntries.
+// Check the length with:
+//
+//	len(mockedSearchClient.GetReleaseCalendarEntriesCalls())
+func (mock *SearchClientMock) GetReleaseCalendarEntriesCalls() []struct {
+	Ctx     context.Context
+	Options searchSDK.Options
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Options searchSDK.Options
+	}
+	mock.lockGetReleaseCalendarEntries.RLock()
+	calls = mock.calls.GetReleaseCalendarEntries
+	mock.lockGetReleaseCalendarEntries.RUnlock()
+	return calls
 }
 
 // GetSearch calls GetSearchFunc.
@@ -275,6 +326,9 @@ var _ ZebedeeClient = &ZebedeeClientMock{}
 //			GetBreadcrumbFunc: func(ctx context.Context, userAccessToken string, collectionID string, lang string, uri string) ([]zebedeeCli.Breadcrumb, error) {
 //				panic("mock out the GetBreadcrumb method")
 //			},
+//			GetCollectionFunc: func(ctx context.Context, userAccessToken string, collectionID string) (zebedeeCli.Collection, error) {
+//				panic("mock out the GetCollection method")
+//			},
 //			GetHomepageContentFunc: func(ctx context.Context, userAuthToken string, collectionID string, lang string, path string) (zebedeeCli.HomepageContent, error) {
 //				panic("mock out the GetHomepageContent method")
 //			},
@@ -291,6 +345,9 @@ type ZebedeeClientMock struct {
 	// GetBreadcrumbFunc mocks the GetBreadcrumb method.
 	GetBreadcrumbFunc func(ctx context.Context, userAccessToken string, collectionID string, lang string, uri string) ([]zebedeeCli.Breadcrumb, error)
 
+	// GetCollectionFunc mocks the GetCollection method.
+	GetCollectionFunc func(ctx context.Context, userAccessToken string, collectionID string) (zebedeeCli.Collection, error)
+
 	// GetHomepageContentFunc mocks the GetHomepageContent method.
 	GetHomepageContentFunc func(ctx context.Context, userAuthToken string, collectionID string, lang string, path string) (zebedeeCli.HomepageContent, error)
 
@@ -312,6 +369,15 @@ type ZebedeeClientMock struct {
 			// URI is the uri argument value.
 			URI string
 		}
+		// GetCollection holds details about calls to the GetCollection method.
+		GetCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserAccessToken is the userAccessToken argument value.
+			UserAccessToken string
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+		}
 		// GetHomepageContent holds details about calls to the GetHomepageContent method.
 		GetHomepageContent []struct {
 			// Ctx is the ctx argument value.
@@ -340,6 +406,7 @@ type ZebedeeClientMock struct {
 		}
 	}
 	lockGetBreadcrumb      sync.RWMutex
+	lockGetCollection      sync.RWMutex
 	lockGetHomepageContent sync.RWMutex
 	lockGetPageData        sync.RWMutex
 }
@@ -392,6 +459,46 @@ func (mock *ZebedeeClientMock) GetBreadcrumbCalls() []struct {
 	return calls
 }
 
+// GetCollection calls GetCollectionFunc.
+func (mock *ZebedeeClientMock) GetCollection(ctx context.Context, userAccessToken string, collectionID string) (zebedeeCli.Collection, error) {
+	if mock.GetCollectionFunc == nil {
+		panic("ZebedeeClientMock.GetCollectionFunc: method is nil but ZebedeeClient.GetCollection was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		UserAccessToken string
+		CollectionID    string
+	}{
+		Ctx:             ctx,
+		UserAccessToken: userAccessToken,
+		CollectionID:    collectionID,
+	}
+	mock.lockGetCollection.Lock()
+	mock.calls.GetCollection = append(mock.calls.GetCollection, callInfo)
+	mock.lockGetCollection.Unlock()
+	return mock.GetCollectionFunc(ctx, userAccessToken, collectionID)
+}
+
+// GetCollectionCalls gets all the calls that were made to GetCollection.
+// Check the length with:
+//
+//	len(mockedZebedeeClient.GetCollectionCalls())
+func (mock *ZebedeeClientMock) GetCollectionCalls() []struct {
+	Ctx             context.Context
+	UserAccessToken string
+	CollectionID    string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		UserAccessToken string
+		CollectionID    string
+	}
+	mock.lockGetCollection.RLock()
+	calls = mock.calls.GetCollection
+	mock.lockGetCollection.RUnlock()
+	return calls
+}
+
 // GetHomepageContent calls GetHomepageContentFunc.
 func (mock *ZebedeeClientMock) GetHomepageContent(ctx context.Context, userAuthToken string, collectionID string, lang string, path string) (zebedeeCli.HomepageContent, error) {
 	if mock.GetHomepageContentFunc == nil {
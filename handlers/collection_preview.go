@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// addCollectionPreview adds the details of the collection being previewed to the page and marks the results which
+// are part of the collection. If enabled, the results are also compared with the published results to mark the
+// results which are new in the collection
+func addCollectionPreview(ctx context.Context, cfg *config.Config, zc ZebedeeClient, searchC SearchClient, options searchSDK.Options, accessToken, collectionID string, m *model.SearchPage) {
+	if !cfg.IsPublishing || collectionID == "" {
+		return
+	}
+
+	logData := log.Data{"collection_id": collectionID}
+
+	preview := &model.CollectionPreview{
+		ID:   collectionID,
+		Name: collectionID,
+	}
+
+	collection, err := zc.GetCollection(ctx, accessToken, collectionID)
+	if err != nil {
+		log.Warn(ctx, "failed to get collection for preview, results will not be marked", log.FormatErrors([]error{err}), logData)
+	} else {
+		if collection.Name != "" {
+			preview.Name = collection.Name
+		}
+		preview.ItemsInCollection = markCollectionItems(collection, m.Data.Response.Items)
+	}
+
+	if cfg.EnableCollectionPreviewDiff {
+		preview.ShowNewInCollection = true
+		preview.NewInCollection, err = markNewInCollection(ctx, searchC, options, m.Data.Response.Items)
+		if err != nil {
+			log.Warn(ctx, "failed to compare results with published results", log.FormatErrors([]error{err}), logData)
+			preview.ShowNewInCollection = false
+		}
+	}
+
+	m.Data.CollectionPreview = preview
+}
+
+// markCollectionItems marks the items which are part of the collection and returns the number of items marked
+func markCollectionItems(collection zebedeeCli.Collection, items []model.ContentItem) int {
+	collectionURIs := make(map[string]struct{})
+	for _, collectionItems := range [][]zebedeeCli.CollectionItem{collection.Inprogress, collection.Complete, collection.Reviewed} {
+		for _, item := range collectionItems {
+			collectionURIs[normaliseCollectionURI(item.URI)] = struct{}{}
+		}
+	}
+
+	count := 0
+	for i := range items {
+		if _, ok := collectionURIs[normaliseCollectionURI(items[i].URI)]; ok {
+			items[i].InCollection = true
+			count++
+		}
+	}
+
+	return count
+}
+
+// markNewInCollection requests the items from the search API without the collection, so that only published content
+// is returned, and marks the items which are not published. The number of items marked is returned
+func markNewInCollection(ctx context.Context, searchC SearchClient, options searchSDK.Options, items []model.ContentItem) (int, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	uris := make([]string, 0, len(items))
+	for i := range items {
+		uris = append(uris, items[i].URI)
+	}
+
+	publishedOptions := searchSDK.Options{
+		Query:   url.Values{},
+		Headers: options.Headers.Clone(),
+	}
+	if publishedOptions.Headers == nil {
+		publishedOptions.Headers = http.Header{}
+	}
+	publishedOptions.Headers.Del(searchSDK.CollectionID)
+
+	publishedResp, err := searchC.PostSearchURIs(ctx, publishedOptions, searchAPI.URIsRequest{
+		URIs:  uris,
+		Limit: len(uris),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	publishedURIs := make(map[string]struct{})
+	if publishedResp != nil {
+		for i := range publishedResp.Items {
+			publishedURIs[normaliseCollectionURI(publishedResp.Items[i].URI)] = struct{}{}
+		}
+	}
+
+	count := 0
+	for i := range items {
+		if _, ok := publishedURIs[normaliseCollectionURI(items[i].URI)]; !ok {
+			items[i].NewInCollection = true
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// normaliseCollectionURI removes the data file and trailing slash from the uri of a collection item so that it can be
+// compared with the uri of a search result
+func normaliseCollectionURI(uri string) string {
+	uri = strings.TrimSuffix(uri, "/data.json")
+	return strings.TrimSuffix(uri, "/")
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	zebedeeC "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitAddCollectionPreview(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newPage := func() *model.SearchPage {
+		return &model.SearchPage{
+			Data: model.Search{
+				Response: model.Response{
+					Items: []model.ContentItem{
+						{URI: "/economy/inflation/bulletins/consumerprices/latest"},
+						{URI: "/economy/gdp/articles/gdpexplained"},
+						{URI: "/people/census/datasets/population"},
+					},
+				},
+			},
+		}
+	}
+
+	collection := zebedeeC.Collection{
+		ID:   "collection-1",
+		Name: "Inflation release",
+		Inprogress: []zebedeeC.CollectionItem{
+			{URI: "/economy/inflation/bulletins/consumerprices/latest/data.json"},
+		},
+		Reviewed: []zebedeeC.CollectionItem{
+			{URI: "/economy/gdp/articles/gdpexplained/"},
+		},
+	}
+
+	options := searchSDK.Options{
+		Headers: http.Header{
+			searchSDK.CollectionID: {"collection-1"},
+		},
+	}
+
+	Convey("Given publishing mode and a collection which contains some of the results", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.IsPublishing = true
+		cfg.EnableCollectionPreviewDiff = false
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetCollectionFunc: func(ctx context.Context, userAccessToken, collectionID string) (zebedeeC.Collection, error) {
+				return collection, nil
+			},
+		}
+		mockedSearchClient := &SearchClientMock{}
+
+		Convey("When the collection preview is added to the page", func() {
+			m := newPage()
+			addCollectionPreview(ctx, cfg, mockedZebedeeClient, mockedSearchClient, options, "token", "collection-1", m)
+
+			Convey("Then the banner contains the collection name and number of items in the collection", func() {
+				So(m.Data.CollectionPreview, ShouldResemble, &model.CollectionPreview{
+					ID:                "collection-1",
+					Name:              "Inflation release",
+					ItemsInCollection: 2,
+				})
+			})
+
+			Convey("And the results in the collection are marked", func() {
+				So(m.Data.Response.Items[0].InCollection, ShouldBeTrue)
+				So(m.Data.Response.Items[1].InCollection, ShouldBeTrue)
+				So(m.Data.Response.Items[2].InCollection, ShouldBeFalse)
+			})
+
+			Convey("And the published results are not requested", func() {
+				So(mockedSearchClient.PostSearchURIsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the comparison with the published results is enabled", func() {
+			cfg.EnableCollectionPreviewDiff = true
+			mockedSearchClient.PostSearchURIsFunc = func(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+				return &searchModels.SearchResponse{
+					Items: []searchModels.Item{
+						{URI: "/economy/gdp/articles/gdpexplained"},
+						{URI: "/people/census/datasets/population"},
+					},
+				}, nil
+			}
+
+			m := newPage()
+			addCollectionPreview(ctx, cfg, mockedZebedeeClient, mockedSearchClient, options, "token", "collection-1", m)
+
+			Convey("Then the published results are requested without the collection", func() {
+				So(mockedSearchClient.PostSearchURIsCalls(), ShouldHaveLength, 1)
+				call := mockedSearchClient.PostSearchURIsCalls()[0]
+				So(call.Options.Headers.Get(searchSDK.CollectionID), ShouldBeEmpty)
+				So(call.UrisRequest.URIs, ShouldHaveLength, 3)
+				So(options.Headers.Get(searchSDK.CollectionID), ShouldEqual, "collection-1")
+			})
+
+			Convey("And the results which are not published are marked as new in the collection", func() {
+				So(m.Data.CollectionPreview.ShowNewInCollection, ShouldBeTrue)
+				So(m.Data.CollectionPreview.NewInCollection, ShouldEqual, 1)
+				So(m.Data.Response.Items[0].NewInCollection, ShouldBeTrue)
+				So(m.Data.Response.Items[1].NewInCollection, ShouldBeFalse)
+				So(m.Data.Response.Items[2].NewInCollection, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the published results cannot be requested", func() {
+			cfg.EnableCollectionPreviewDiff = true
+			mockedSearchClient.PostSearchURIsFunc = func(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+				return nil, searchError.StatusError{Code: http.StatusInternalServerError, Err: errors.New("internal server error")}
+			}
+
+			m := newPage()
+			addCollectionPreview(ctx, cfg, mockedZebedeeClient, mockedSearchClient, options, "token", "collection-1", m)
+
+			Convey("Then the banner is still shown without the new in collection count", func() {
+				So(m.Data.CollectionPreview.Name, ShouldEqual, "Inflation release")
+				So(m.Data.CollectionPreview.ShowNewInCollection, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given publishing mode and a collection which cannot be retrieved", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.IsPublishing = true
+		cfg.EnableCollectionPreviewDiff = false
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetCollectionFunc: func(ctx context.Context, userAccessToken, collectionID string) (zebedeeC.Collection, error) {
+				return zebedeeC.Collection{}, errors.New("collection not found")
+			},
+		}
+
+		Convey("When the collection preview is added to the page", func() {
+			m := newPage()
+			addCollectionPreview(ctx, cfg, mockedZebedeeClient, &SearchClientMock{}, options, "token", "collection-1", m)
+
+			Convey("Then the banner shows the collection id and no results are marked", func() {
+				So(m.Data.CollectionPreview.Name, ShouldEqual, "collection-1")
+				So(m.Data.CollectionPreview.ItemsInCollection, ShouldEqual, 0)
+				for _, item := range m.Data.Response.Items {
+					So(item.InCollection, ShouldBeFalse)
+				}
+			})
+		})
+	})
+
+	Convey("Given web mode", t, func() {
+		mockedZebedeeClient := &ZebedeeClientMock{}
+
+		Convey("When the collection preview is added to the page", func() {
+			m := newPage()
+			addCollectionPreview(ctx, &config.Config{IsPublishing: false}, mockedZebedeeClient, &SearchClientMock{}, options, "token", "collection-1", m)
+
+			Convey("Then no banner is shown", func() {
+				So(m.Data.CollectionPreview, ShouldBeNil)
+				So(mockedZebedeeClient.GetCollectionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
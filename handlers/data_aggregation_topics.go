@@ -6,15 +6,12 @@ import (
 	"net/url"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
-	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
-	searchModels "github.com/ONSdigital/dp-search-api/models"
-	"github.com/ONSdigital/dp-topic-api/models"
 )
 
 // DataAggregationWithTopics for data aggregation routes with topic/subtopics
@@ -22,17 +19,16 @@ func (sh *SearchHandler) DataAggregationWithTopics(cfg *config.Config, template
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
 		aggregationConfig := NewAggregationWithTopicsConfig(template)
 
-		handleReadRequest(w, req, cfg, sh.ZebedeeClient, sh.Renderer, sh.SearchClient, accessToken, collectionID, lang, sh.CacheList, aggregationConfig)
+		sh.handleReadRequest(w, req, cfg, accessToken, collectionID, lang, aggregationConfig)
 	})
 }
 
 func NewAggregationWithTopicsConfig(template string) AggregationConfig {
-	createPageModel := func(cfg *config.Config, req *http.Request, base core.Page, queryParams data.SearchURLParams, categories []data.Category, topics []data.Topic, searchResp *searchModels.SearchResponse, lang string, homepageResp zebedeeCli.HomepageContent, errorMessage string, navigationCache *models.Navigation,
-		template string, topic cache.Topic, validationErrs []core.ErrorItem, _ zebedeeCli.PageData, _ []zebedeeCli.Breadcrumb) model.SearchPage {
-		return mapper.CreateDataAggregationPage(cfg, req, base, queryParams, categories, topics, searchResp, lang, homepageResp, errorMessage, navigationCache, template, topic, validationErrs)
+	createPageModel := func(rc *RequestContext) model.SearchPage {
+		return mapper.CreateDataAggregationPage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.QueryParams, rc.Categories, rc.TopicCategories, rc.SearchResp, rc.Lang, rc.HomepageResp, "", rc.NavigationCache, template, rc.SelectedTopic, rc.ValidationErrs)
 	}
 	validateParams := func(ctx context.Context, cfg *config.Config, urlQuery url.Values, _ string, _ *cache.Topic) (data.SearchURLParams, []core.ErrorItem) {
-		return data.ReviewDataAggregationQueryWithParams(ctx, cfg, urlQuery)
+		return data.ReviewDataAggregationQueryWithParams(ctx, cfg, urlQuery, template)
 	}
 
 	getSearchAndCategoriesCountQueries := func(validatedQueryParams data.SearchURLParams, _ *cache.Topic, template, _ string) (searchQuery, categoriesCountQuery url.Values) {
@@ -48,5 +44,6 @@ func NewAggregationWithTopicsConfig(template string) AggregationConfig {
 		ValidateParams:                     validateParams,
 		GetSearchAndCategoriesCountQueries: getSearchAndCategoriesCountQueries,
 		CreatePageModel:                    createPageModel,
+		EnableICalendar:                    true,
 	}
 }
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"sync"
+
+	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// compareQueryParam is the query parameter of the editions chosen to be compared on /previousreleases
+const compareQueryParam = "compare"
+
+// EditionComparisonTemplate is the template of the side by side comparison of two editions
+const EditionComparisonTemplate = "edition-comparison"
+
+// handleEditionComparison renders the two editions chosen on /previousreleases side by side
+func handleEditionComparison(w http.ResponseWriter, req *http.Request, cfg *config.Config, zc ZebedeeClient, rend RenderClient, accessToken, collectionID, lang string, cacheList cache.List, urlPath string, pageData zebedeeCli.PageData, editionURIs []string) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	metrics.SetTemplate(ctx, EditionComparisonTemplate)
+
+	if err := validateComparedEditions(urlPath, editionURIs); err != nil {
+		log.Info(ctx, "invalid editions chosen to compare", log.Data{
+			"url_path": urlPath,
+			"editions": editionURIs,
+		})
+		setStatusCode(w, req, err)
+		return
+	}
+
+	editions := make([]zebedeeCli.PageData, len(editionURIs))
+	editionErrs := make([]error, len(editionURIs))
+
+	var homepageResp zebedeeCli.HomepageContent
+	var navigationCache *models.Navigation
+	var bc []zebedeeCli.Breadcrumb
+
+	wg := sync.WaitGroup{}
+	wg.Add(3 + len(editionURIs))
+
+	// Parallel fetching
+	for i := range editionURIs {
+		go func(i int) {
+			defer wg.Done()
+			// the editions must be the same kind of page which has previous releases
+			editions[i], editionErrs[i] = checkAllowedPageTypes(ctx, w, zc, accessToken, collectionID, lang, path.Clean(editionURIs[i]), knownRelatedListTypes)
+		}(i)
+	}
+	go func() {
+		defer wg.Done()
+		navigationCache, _ = getNavigationCache(ctx, cacheList, cfg.NavigationTimeout, lang)
+	}()
+	go func() {
+		defer wg.Done()
+		homepageResp, _ = getHomepageContent(ctx, zc, cfg.ZebedeeTimeout, accessToken, collectionID, lang)
+	}()
+	go func() {
+		defer wg.Done()
+		bc, _ = getBreadcrumb(ctx, zc, cfg.ZebedeeTimeout, accessToken, collectionID, lang, urlPath+"/latest")
+	}()
+	wg.Wait()
+
+	for _, err := range editionErrs {
+		if err != nil {
+			log.Error(ctx, "failed to get edition to compare", err, log.Data{"editions": editionURIs})
+			setStatusCode(w, req, err)
+			return
+		}
+	}
+
+	m := mapper.CreateEditionComparisonPage(cfg, req, rend.NewBasePageModel(), lang, homepageResp, navigationCache, pageData, editions, bc)
+	rend.BuildPage(w, m, EditionComparisonTemplate)
+}
+
+// validateComparedEditions checks that two different editions of the release at urlPath have been chosen
+func validateComparedEditions(urlPath string, editionURIs []string) error {
+	if len(editionURIs) != 2 {
+		return apperrors.ErrInvalidEditionComparison
+	}
+
+	earlier, later := path.Clean(editionURIs[0]), path.Clean(editionURIs[1])
+	if earlier == later {
+		return apperrors.ErrInvalidEditionComparison
+	}
+
+	for _, editionURI := range []string{earlier, later} {
+		if !path.IsAbs(editionURI) || path.Dir(editionURI) != path.Clean(urlPath) {
+			return apperrors.ErrInvalidEditionComparison
+		}
+	}
+
+	return nil
+}
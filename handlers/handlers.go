@@ -6,19 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"path"
 	"slices"
 	"strings"
-	"sync"
 	"time"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
 	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
 	"github.com/ONSdigital/dp-frontend-search-controller/apperrors"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/clock"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/redirect"
 	searchAPI "github.com/ONSdigital/dp-search-api/api"
 	searchModels "github.com/ONSdigital/dp-search-api/models"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
@@ -36,6 +37,12 @@ const (
 	RelatedPagesTemplate = "related-list-pages"
 )
 
+// routes which are redirected to the migration link of their page, see config.MigrationLinkRedirects
+const (
+	previousReleasesRoute = "previousreleases"
+	relatedDataRoute      = "relateddata"
+)
+
 // list of content types that have /relateddata and /previousreleases
 var knownRelatedListTypes = []string{
 	"bulletin",
@@ -46,6 +53,23 @@ var knownRelatedListTypes = []string{
 // list of query params allowed on /previousreleases
 var allowedPreviousReleasesQueryParams = []string{data.Page}
 
+// list of query params allowed on /relateddata
+var allowedRelatedDataQueryParams = []string{data.Page, "sort", "filter"}
+
+// list of query params allowed on /previousreleases when filtering the editions or exporting them
+var allowedPreviousReleasesFilterQueryParams = []string{
+	data.Page,
+	data.DayAfter,
+	data.MonthAfter,
+	data.YearAfter,
+	data.DayBefore,
+	data.MonthBefore,
+	data.YearBefore,
+	data.Edition,
+	data.GroupBy,
+	"ics",
+}
+
 // SearchHandler represents the handlers for search functionality
 type SearchHandler struct {
 	Renderer                    RenderClient
@@ -55,6 +79,12 @@ type SearchHandler struct {
 	EnableAggregationPages      bool
 	EnableTopicAggregationPages bool
 	CacheList                   cache.List
+	Clock                       clock.Clock
+	Redirector                  *redirect.Redirector
+	// Analytics records the searches made and results clicked, which are not recorded if it is nil
+	Analytics *analytics.Recorder
+	// ReleaseYearCounts keeps the counts of the previous releases in each year, nothing is kept if it is nil
+	ReleaseYearCounts *ReleaseYearCountsCache
 }
 
 // NewSearchHandler creates a new instance of SearchHandler
@@ -67,186 +97,25 @@ func NewSearchHandler(rc RenderClient, sc SearchClient, tc TopicClient, zc Zebed
 		EnableAggregationPages:      cfg.EnableAggregationPages,
 		EnableTopicAggregationPages: cfg.EnableTopicAggregationPages,
 		CacheList:                   cl,
+		Clock:                       clock.System{},
+		Redirector:                  redirect.New(cfg.MigrationLinkRedirects, cfg.MigrationLinkQueryParams),
+		ReleaseYearCounts:           NewReleaseYearCountsCache(cfg.PreviousReleasesYearCountsCacheTTL),
 	}
 }
 
+// AggregationConfig describes a page type, which is served by passing the request through the stages of its pipeline
 type AggregationConfig struct {
 	TemplateName                       string
 	URLQueryParams                     url.Values
 	UseTopicsPath                      bool
-	UseURIsRequest                     bool
 	NLPWeightingEnabled                bool
 	ValidateParams                     func(context.Context, *config.Config, url.Values, string, *cache.Topic) (data.SearchURLParams, []core.ErrorItem)
-	CreatePageModel                    func(*config.Config, *http.Request, core.Page, data.SearchURLParams, []data.Category, []data.Topic, *searchModels.SearchResponse, string, zebedeeCli.HomepageContent, string, *models.Navigation, string, cache.Topic, []core.ErrorItem, zebedeeCli.PageData, []zebedeeCli.Breadcrumb) model.SearchPage
+	CreatePageModel                    func(rc *RequestContext) model.SearchPage
 	GetSearchAndCategoriesCountQueries func(data.SearchURLParams, *cache.Topic, string, string) (url.Values, url.Values)
-}
-
-//nolint:gocyclo // TODO: refactor to reduce cyclomatic complexity
-func handleReadRequest(w http.ResponseWriter, req *http.Request, cfg *config.Config, zc ZebedeeClient, rend RenderClient, searchC SearchClient, accessToken, collectionID, lang string, cacheList cache.List, aggCfg AggregationConfig) {
-	ctx, cancel := context.WithCancel(req.Context())
-	defer cancel()
-
-	urlPath := path.Dir(req.URL.Path)
-	latestContentURL := path.Dir(req.URL.Path) + "/latest"
-	// Extract topic if required
-	var selectedTopic cache.Topic
-
-	selectedTopic, err := selectTopic(ctx, req, cacheList, aggCfg)
-	if err != nil {
-		setStatusCode(w, req, err)
-		return
-	}
-
-	clearTopics := prepareQueryParams(req, &aggCfg, selectedTopic)
-	pageData, err := validatePageType(ctx, w, zc, aggCfg, accessToken, collectionID, lang, urlPath)
-	if err != nil {
-		setStatusCode(w, req, err)
-		return
-	}
-	counter := 2
-	wg := sync.WaitGroup{}
-
-	var homepageResp zebedeeCli.HomepageContent
-	var navigationCache *models.Navigation
-	var searchResp = &searchModels.SearchResponse{}
-	var categories []data.Category
-	var topicCategories []data.Topic
-	var respErr, countErr error
-	var searchCount int
-	var bc []zebedeeCli.Breadcrumb
-
-	wg.Add(counter)
-
-	// Parallel fetching
-	go func() {
-		defer wg.Done()
-		// get cached navigation data
-		navigationCache = getNavigationCache(ctx, w, req, cacheList, lang)
-	}()
-	go func() {
-		defer wg.Done()
-		// get homepage content
-		homepageResp = getHomepageContent(ctx, zc, accessToken, collectionID, lang)
-	}()
-
-	if aggCfg.TemplateName == RelatedPagesTemplate {
-		wg.Add(1)
-		if aggCfg.UseURIsRequest {
-			go func() {
-				defer wg.Done()
-				bc = getBreadcrumb(ctx, zc, accessToken, collectionID, lang, urlPath)
-			}()
-		} else {
-			go func() {
-				defer wg.Done()
-				bc = getBreadcrumb(ctx, zc, accessToken, collectionID, lang, latestContentURL)
-			}()
-		}
-	}
-	wg.Wait()
-
-	validatedQueryParams, validationErrs := aggCfg.ValidateParams(ctx, cfg, aggCfg.URLQueryParams, urlPath, &selectedTopic)
-	if len(validationErrs) > 0 {
-		m := aggCfg.CreatePageModel(cfg, req, rend.NewBasePageModel(), validatedQueryParams, []data.Category{}, []data.Topic{}, &searchModels.SearchResponse{}, lang, zebedeeCli.HomepageContent{}, "", navigationCache, aggCfg.TemplateName, selectedTopic, validationErrs, pageData, []zebedeeCli.Breadcrumb{})
-		buildDataAggregationPage(w, m, rend, aggCfg.TemplateName)
-		return
-	}
-
-	if aggCfg.NLPWeightingEnabled {
-		validatedQueryParams.NLPWeightingEnabled = aggCfg.NLPWeightingEnabled
-		log.Info(ctx, "NLP Weighting for query", log.Data{
-			"nlp_weighting": aggCfg.NLPWeightingEnabled,
-		})
-	}
-
-	if _, rssParam := aggCfg.URLQueryParams["rss"]; rssParam {
-		var err error
-		req.Header.Set("Accept", "application/rss+xml")
-		if err = createRSSFeed(ctx, w, req, collectionID, accessToken, searchC, validatedQueryParams, aggCfg.TemplateName); err != nil {
-			log.Error(ctx, "failed to create rss feed", err)
-			setStatusCode(w, req, err)
-		}
-		return
-	}
-
-	searchQuery, categoriesCountQuery := aggCfg.GetSearchAndCategoriesCountQueries(validatedQueryParams, &selectedTopic, aggCfg.TemplateName, pageData.Type)
-
-	var options searchSDK.Options
-
-	options.Query = searchQuery
-	options.Headers = http.Header{
-		searchSDK.CollectionID: {collectionID},
-	}
-
-	setAuthTokenHeader(options.Headers, accessToken)
-	if aggCfg.TemplateName == RelatedPagesTemplate {
-		if aggCfg.UseURIsRequest {
-			URIList := make([]string, 0, len(pageData.RelatedData))
-			for _, related := range pageData.RelatedData {
-				URIList = append(URIList, related.URI)
-			}
-
-			URIsRequest := searchAPI.URIsRequest{
-				URIs:   URIList,
-				Limit:  validatedQueryParams.Limit,
-				Offset: validatedQueryParams.Offset,
-				Sort:   validatedQueryParams.Sort.Query,
-			}
-
-			searchResp, respErr, searchCount = postSearchURIs(ctx, searchC, options, cancel, URIsRequest)
-			if respErr != nil {
-				log.Error(ctx, "getting search response with uris from client failed", respErr)
-			}
-		} else {
-			searchResp, respErr = searchC.GetSearch(ctx, options)
-			if respErr != nil {
-				log.Error(ctx, "getting search response from client failed", respErr)
-			}
-			searchCount = searchResp.Count
-		}
-	} else {
-		searchResp, respErr = searchC.GetSearch(ctx, options)
-		if respErr != nil {
-			log.Error(ctx, "getting search response from client failed", respErr)
-		}
-
-		if searchResp != nil {
-			searchCount = searchResp.Count
-		}
-
-		// TO-DO: Need to make a second request until API can handle aggregation on datatypes (e.g. bulletins, article) to return counts
-		categories, topicCategories, countErr = getCategoriesTypesCount(ctx, accessToken, collectionID, categoriesCountQuery, searchC, &selectedTopic)
-		if countErr != nil {
-			log.Error(ctx, "getting categories, types and its counts failed", countErr)
-		}
-	}
-	if respErr != nil || countErr != nil {
-		setStatusCode(w, req, respErr)
-		return
-	}
-	if clearTopics {
-		/* By default, we set all topics as active,
-		 * but we don't want the checkboxes to be ticked
-		 * this ensures they're sent to the topic API, but
-		 * hides that from the frontend.
-		 */
-		validatedQueryParams.TopicFilter = ""
-	}
-
-	err = validateCurrentPage(ctx, cfg, validatedQueryParams, searchCount)
-	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
-		m := aggCfg.CreatePageModel(cfg, req, rend.NewBasePageModel(), validatedQueryParams, []data.Category{}, []data.Topic{}, &searchModels.SearchResponse{}, lang, zebedeeCli.HomepageContent{}, "", navigationCache, aggCfg.TemplateName, cache.Topic{}, validationErrs, pageData, bc)
-		buildDataAggregationPage(w, m, rend, aggCfg.TemplateName)
-		return
-	}
-
-	m := aggCfg.CreatePageModel(cfg, req, rend.NewBasePageModel(), validatedQueryParams, categories, topicCategories, searchResp, lang, homepageResp, "", navigationCache, aggCfg.TemplateName, selectedTopic, validationErrs, pageData, bc)
-	buildDataAggregationPage(w, m, rend, aggCfg.TemplateName)
+	// Stages adds, skips or replaces stages of the default pipeline for the page type
+	Stages func(Pipeline) Pipeline
+	// EnableICalendar allows the search results to be exported as an iCalendar feed using the ics query parameter
+	EnableICalendar bool
 }
 
 func getSelectedTopic(ctx context.Context, req *http.Request, cacheList cache.List) (cache.Topic, error) {
@@ -410,6 +279,10 @@ func setStatusCode(w http.ResponseWriter, req *http.Request, err error) {
 		status = http.StatusNotFound
 	}
 
+	if errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+
 	log.Info(req.Context(), "setting-response-status", log.Data{
 		"status": status,
 	})
@@ -495,26 +368,12 @@ func createRSSFeed(ctx context.Context, w http.ResponseWriter, r *http.Request,
 }
 
 func getPageTitle(template string) (pageTitle, pageTag string) {
-	switch template {
-	case "all-adhocs":
-		return "User requested data", "UserRequestedData"
-	case "home-datalist":
-		return "Published data", "DataList"
-	case "home-publications":
-		return "Publications", "HomePublications"
-	case "all-methodologies":
-		return "All methodology", "AllMethodology"
-	case "published-requests":
-		return "Freedom of Information (FOI) requests", "FOIRequests"
-	case "home-list":
-		return "Information pages", "HomeList"
-	case "home-methodology":
-		return "Methodology", "HomeMethodology"
-	case "time-series-tool":
-		return "Time series explorer", "TimeSeriesExplorer"
-	}
-
-	return "", ""
+	if template == RelatedPagesTemplate {
+		return "Previous releases", "PreviousReleases"
+	}
+
+	aggregationPage, _ := data.GetAggregationPage(template)
+	return aggregationPage.Title, aggregationPage.LocaliseKeyName
 }
 
 // ValidateTopicHierarchy validate the segments i.e. check that they all exist in the cache, check that the hierarchy is correct and return the last item as the selectedTopic
@@ -590,14 +449,17 @@ func checkAllowedPageTypes(ctx context.Context, w http.ResponseWriter, zc Zebede
 	return pageData, nil
 }
 
-// getNavigationCache returns cached navigation data
-func getNavigationCache(ctx context.Context, w http.ResponseWriter, req *http.Request, cacheList cache.List, lang string) *models.Navigation {
-	navigationCache, err := cacheList.Navigation.GetNavigationData(ctx, lang)
+// getNavigationCache returns cached navigation data. The page can still be shown without it, so nil is returned along
+// with any error
+func getNavigationCache(ctx context.Context, cacheList cache.List, timeout time.Duration, lang string) (*models.Navigation, error) {
+	navigationCache, err := withTimeout(ctx, timeout, func(ctx context.Context) (*models.Navigation, error) {
+		return cacheList.Navigation.GetNavigationData(ctx, lang)
+	})
 	if err != nil {
-		log.Error(ctx, "failed to get navigation cache for aggregation", err)
-		setStatusCode(w, req, err)
+		log.Warn(ctx, "failed to get navigation cache, rendering without navigation", log.FormatErrors([]error{err}))
+		return nil, err
 	}
-	return navigationCache
+	return navigationCache, nil
 }
 
 // postSearchURIs posts a list of URIs to search API and gets a search response
@@ -615,10 +477,6 @@ func postSearchURIs(ctx context.Context, searchC SearchClient, options searchSDK
 }
 
 func selectTopic(ctx context.Context, req *http.Request, cacheList cache.List, aggCfg AggregationConfig) (cache.Topic, error) {
-	if aggCfg.TemplateName == RelatedPagesTemplate {
-		return cache.Topic{}, nil
-	}
-
 	if aggCfg.UseTopicsPath {
 		return getSelectedTopic(ctx, req, cacheList)
 	}
@@ -643,35 +501,32 @@ func prepareQueryParams(req *http.Request, aggCfg *AggregationConfig, selectedTo
 	return false
 }
 
-func validatePageType(ctx context.Context, w http.ResponseWriter, zc ZebedeeClient, aggCfg AggregationConfig, accessToken, collectionID, lang, urlPath string) (zebedeeCli.PageData, error) {
-	if aggCfg.TemplateName != RelatedPagesTemplate {
-		return zebedeeCli.PageData{}, nil
-	}
-
-	validatePath := urlPath
-	if !aggCfg.UseURIsRequest {
-		validatePath = urlPath + "/latest"
-	}
-
-	return checkAllowedPageTypes(ctx, w, zc, accessToken, collectionID, lang, validatePath, knownRelatedListTypes)
+// getRelatedListPage gets the page from zebedee, which must be a type of page with previous releases or related data
+func getRelatedListPage(ctx context.Context, w http.ResponseWriter, zc ZebedeeClient, accessToken, collectionID, lang, pagePath string) (zebedeeCli.PageData, error) {
+	return checkAllowedPageTypes(ctx, w, zc, accessToken, collectionID, lang, pagePath, knownRelatedListTypes)
 }
 
 // getBreadcrumb performs a get request to zebedee for breadcrumb data
-func getBreadcrumb(ctx context.Context, zc ZebedeeClient, accessToken, collectionID, lang, pageURL string) []zebedeeCli.Breadcrumb {
-	bc, err := zc.GetBreadcrumb(ctx, accessToken, collectionID, lang, pageURL)
+func getBreadcrumb(ctx context.Context, zc ZebedeeClient, timeout time.Duration, accessToken, collectionID, lang, pageURL string) ([]zebedeeCli.Breadcrumb, error) {
+	bc, err := withTimeout(ctx, timeout, func(ctx context.Context) ([]zebedeeCli.Breadcrumb, error) {
+		return zc.GetBreadcrumb(ctx, accessToken, collectionID, lang, pageURL)
+	})
 	if err != nil {
 		log.Warn(ctx, "getting breadcrumb response from client failed", log.FormatErrors([]error{err}))
-		bc = []zebedeeCli.Breadcrumb{}
+		return []zebedeeCli.Breadcrumb{}, err
 	}
-	return bc
+	return bc, nil
 }
 
-// getHomepageContent performs a get request to zebedee for breadcrumb data
-func getHomepageContent(ctx context.Context, zc ZebedeeClient, accessToken, collectionID, lang string) zebedeeCli.HomepageContent {
-	hp, err := zc.GetHomepageContent(ctx, accessToken, collectionID, lang, homepagePath)
+// getHomepageContent performs a get request to zebedee for homepage data, e.g. the emergency banner. The page can
+// still be shown without it, so an empty homepage is returned along with any error
+func getHomepageContent(ctx context.Context, zc ZebedeeClient, timeout time.Duration, accessToken, collectionID, lang string) (zebedeeCli.HomepageContent, error) {
+	hp, err := withTimeout(ctx, timeout, func(ctx context.Context) (zebedeeCli.HomepageContent, error) {
+		return zc.GetHomepageContent(ctx, accessToken, collectionID, lang, homepagePath)
+	})
 	if err != nil {
 		log.Warn(ctx, "getting homepage response from client failed", log.FormatErrors([]error{err}))
-		hp = zebedeeCli.HomepageContent{}
+		return zebedeeCli.HomepageContent{}, err
 	}
-	return hp
+	return hp, nil
 }
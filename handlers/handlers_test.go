@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dis-design-system-go/v2/helper"
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
@@ -19,10 +24,14 @@ import (
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/mocks"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/redact"
+	"github.com/ONSdigital/dp-frontend-search-controller/resilience"
 	searchModels "github.com/ONSdigital/dp-search-api/models"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
 	apiError "github.com/ONSdigital/dp-search-api/sdk/errors"
 	topicModels "github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -182,7 +191,7 @@ func TestUnitReadSuccess(t *testing.T) {
 
 		Convey("When read is called", func() {
 			searchConfig := NewSearchConfig(false)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, searchConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -197,7 +206,7 @@ func TestUnitReadSuccess(t *testing.T) {
 
 		Convey("When read is called with NLP switched on", func() {
 			searchConfig := NewSearchConfig(true)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, searchConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -259,7 +268,7 @@ func TestUnitReadFailure(t *testing.T) {
 
 		Convey("When read is called", func() {
 			searchConfig := NewSearchConfig(false)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, searchConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -305,13 +314,13 @@ func TestUnitReadFailure(t *testing.T) {
 
 		Convey("When read is called", func() {
 			searchConfig := NewSearchConfig(false)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, searchConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
 
 			Convey("Then a 500 internal server error status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
 
 				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 0)
-				So(mockedSearchClient.GetSearchCalls(), ShouldHaveLength, 2)
+				So(mockedSearchClient.GetSearchCalls(), ShouldHaveLength, 1)
 				So(mockedZebedeeClient.GetHomepageContentCalls(), ShouldHaveLength, 1)
 			})
 		})
@@ -348,7 +357,7 @@ func TestUnitReadFailure(t *testing.T) {
 
 		Convey("When read is called", func() {
 			searchConfig := NewSearchConfig(false)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, searchConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -362,6 +371,206 @@ func TestUnitReadFailure(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given the circuit breaker of the search api is open", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search?q=housing", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, pageModel interface{}, templateName string) {},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return nil, &resilience.UnavailableError{Dependency: "Search API"}
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return mockHomepageContent, nil
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When read is called", func() {
+			searchConfig := NewSearchConfig(false)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
+
+			Convey("Then the search unavailable page is rendered with a 503 service unavailable status", func() {
+				So(w.Code, ShouldEqual, http.StatusServiceUnavailable)
+				So(w.Header().Get("Retry-After"), ShouldEqual, "30")
+
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 1)
+				So(mockedRendererClient.BuildPageCalls()[0].TemplateName, ShouldEqual, SearchUnavailableTemplate)
+				So(mockedRendererClient.BuildPageCalls()[0].PageModel.(model.SearchPage).Data.Query, ShouldEqual, "housing")
+				So(mockedSearchClient.GetSearchCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestUnitReadDegraded(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	mockSearchResponse, err := mapper.GetMockSearchResponse()
+	if err != nil {
+		t.Errorf("failed to retrieve mock search response for unit tests, failing early: %v", err)
+	}
+
+	Convey("Given the homepage content and the counts of the categories cannot be got", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search?q=housing", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		var pageModel model.SearchPage
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, m interface{}, templateName string) {
+				pageModel = m.(model.SearchPage)
+			},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		searchCalls := 0
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				searchCalls++
+				if searchCalls > 1 {
+					return nil, apiError.StatusError{Code: 500}
+				}
+				return mockSearchResponse, nil
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, errors.New("zebedee unavailable")
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When read is called", func() {
+			searchConfig := NewSearchConfig(false)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
+
+			Convey("Then the search results are rendered without the filter counts and the emergency banner", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 1)
+				So(mockedSearchClient.GetSearchCalls(), ShouldHaveLength, 2)
+				So(pageModel.Data.Response.Count, ShouldEqual, mockSearchResponse.Count)
+				So(pageModel.Data.Degraded, ShouldResemble, &model.Degraded{EmergencyBanner: true, FilterCounts: true})
+			})
+		})
+	})
+
+	Convey("Given the navigation cannot be got", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search?q=housing", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		var pageModel model.SearchPage
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, m interface{}, templateName string) {
+				pageModel = m.(model.SearchPage)
+			},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return mockSearchResponse, nil
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+		}
+
+		// the navigation is only cached in welsh
+		mockCacheList, err := cache.GetMockCacheList(ctx, "cy")
+		So(err, ShouldBeNil)
+
+		Convey("When read is called", func() {
+			searchConfig := NewSearchConfig(false)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
+
+			Convey("Then the search results are rendered without the navigation and without an error status", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 1)
+				So(pageModel.Data.Response.Count, ShouldEqual, mockSearchResponse.Count)
+				So(pageModel.Data.Degraded, ShouldResemble, &model.Degraded{Navigation: true})
+			})
+		})
+	})
+
+	Convey("Given the search api does not respond in time", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search?q=housing", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.SearchTimeout = 10 * time.Millisecond
+
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, pageModel interface{}, templateName string) {},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				<-release
+				return mockSearchResponse, nil
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When read is called", func() {
+			searchConfig := NewSearchConfig(false)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, searchConfig)
+
+			Convey("Then a 504 gateway timeout status should be returned", func() {
+				So(w.Code, ShouldEqual, http.StatusGatewayTimeout)
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
 }
 
 func TestUnitReadDataAggregationSuccess(t *testing.T) {
@@ -409,7 +618,7 @@ func TestUnitReadDataAggregationSuccess(t *testing.T) {
 		So(err, ShouldBeNil)
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationConfig("home-publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -480,7 +689,7 @@ func TestUnitReadDataAggregationFailure(t *testing.T) {
 
 		Convey("When readDataAggregation is called", func() {
 			aggregationConfig := NewAggregationConfig("home-publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -525,7 +734,7 @@ func TestUnitReadDataAggregationFailure(t *testing.T) {
 
 		Convey("When readDataAggregation is called", func() {
 			aggregationConfig := NewAggregationConfig("all-adhocs")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -592,7 +801,7 @@ func TestUnitReadDataAggregationWithTopicsSuccess(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -658,7 +867,7 @@ func TestUnitReadDataAggregationWithTopicsSuccess(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -730,7 +939,7 @@ func TestUnitReadDataAggregationWithTopicsSuccess(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -802,7 +1011,7 @@ func TestUnitReadDataAggregationWithTopicsFailure(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 404 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -851,7 +1060,7 @@ func TestUnitReadDataAggregationWithTopicsFailure(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 404 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -908,7 +1117,7 @@ func TestUnitReadDataAggregationWithTopicsFailure(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 404 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -1340,7 +1549,7 @@ func TestUnitReadDataAggregationWithTopicsRSSSuccess(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -1356,6 +1565,62 @@ func TestUnitReadDataAggregationWithTopicsRSSSuccess(t *testing.T) {
 	})
 }
 
+func TestUnitReadDataAggregationWithTopicsICSSuccess(t *testing.T) {
+	t.Parallel()
+
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	ctx := context.Background()
+
+	mockSearchResponse, err := mapper.GetMockSearchResponse()
+	if err != nil {
+		t.Errorf("failed to retrieve mock search response for unit tests, failing early: %v", err)
+	}
+
+	Convey("Given a valid request for the calendar of a subtopic filtered page and a set of mocked services", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/economy/governmentpublicsectorandtaxes/publications?ics", http.NoBody)
+		req = mux.SetURLVars(req, map[string]string{"topicsPath": "economy/governmentpublicsectorandtaxes"})
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, pageModel interface{}, templateName string) {},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return mockSearchResponse, nil
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When readDataAggregationWithTopics is called", func() {
+			aggregationConfig := NewAggregationWithTopicsConfig("home-publications")
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
+
+			Convey("Then the search results are returned as a calendar", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/calendar; charset=utf-8")
+				So(w.Body.String(), ShouldStartWith, "BEGIN:VCALENDAR")
+				So(strings.Count(w.Body.String(), "BEGIN:VEVENT"), ShouldEqual, len(mockSearchResponse.Items))
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
 func TestUnitReadDataAggregationWithTopicsRSSFailure(t *testing.T) {
 	t.Parallel()
 
@@ -1409,7 +1674,7 @@ func TestUnitReadDataAggregationWithTopicsRSSFailure(t *testing.T) {
 
 		Convey("When readDataAggregationWithTopics is called", func() {
 			aggregationConfig := NewAggregationWithTopicsConfig("publications")
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, aggregationConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, aggregationConfig)
 
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -1574,3 +1839,73 @@ func TestSanitiseQueryParams(t *testing.T) {
 		})
 	})
 }
+
+func TestUnitReadLogRedaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	mockSearchResponse, err := mapper.GetMockSearchResponse()
+	if err != nil {
+		t.Errorf("failed to retrieve mock search response for unit tests, failing early: %v", err)
+	}
+
+	Convey("Given the logs are redacted and a search request with an access token and a personal query", t, func() {
+		var logs bytes.Buffer
+		redactor := redact.New(redact.Config{QueryParams: []string{"q"}, Secrets: []string{"service-secret"}})
+		log.SetDestination(redact.NewWriter(redactor, &logs), nil)
+		defer log.SetDestination(os.Stdout, nil)
+
+		req := httptest.NewRequest("GET", "/search?q=jane+doe+sw1a+1aa&filter=bulletin", http.NoBody)
+		req.Header.Set("X-Florence-Token", "florence-secret")
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, pageModel interface{}, templateName string) {},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		// the count of the search results fails with an error describing the request made to the search api
+		var calls atomic.Int32
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				if calls.Add(1) == 1 {
+					return mockSearchResponse, nil
+				}
+				return nil, apiError.StatusError{
+					Code: http.StatusInternalServerError,
+					Err: errors.New("failed to call http://localhost:23900/search?q=jane+doe+sw1a+1aa&access_token=florence-secret " +
+						"with Authorization: Bearer service-secret"),
+				}
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When Search is called and its failures are logged", func() {
+			sh := NewSearchHandler(mockedRendererClient, mockedSearchClient, &TopicClientMock{}, mockedZebedeeClient, cfg, *mockCacheList)
+			w := doTestRequest("/search", req, sh.Search(cfg), nil)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			log.SetDestination(os.Stdout, nil)
+
+			Convey("Then no log line contains the access token, the service auth token or the query", func() {
+				So(logs.String(), ShouldContainSubstring, "getting search query count from client failed")
+				So(logs.String(), ShouldContainSubstring, redact.Mask)
+				So(logs.String(), ShouldNotContainSubstring, "florence-secret")
+				So(logs.String(), ShouldNotContainSubstring, "service-secret")
+				So(logs.String(), ShouldNotContainSubstring, "sw1a")
+			})
+		})
+	})
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // only used to derive stable event uids
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	// icsPageSize is the number of search results requested at a time when exporting them, the most the search api returns
+	icsPageSize       = 100
+	icsDateTimeFormat = "20060102T150405Z"
+	icsLineLength     = 75
+	icsUIDDomain      = "@ons.gov.uk"
+	icsURIPrefix      = "https://www.ons.gov.uk"
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// getICalendarResults gets the search results to export, a page at a time with the search given, until every result up
+// to the maximum has been got. Each page is got within the timeout
+func getICalendarResults(ctx context.Context, searchC SearchClient, options searchSDK.Options, search func(context.Context, SearchClient, searchSDK.Options) (*searchModels.SearchResponse, searchError.Error), timeout time.Duration, maxResults int) (*searchModels.SearchResponse, error) {
+	exportResp := &searchModels.SearchResponse{}
+
+	for offset := 0; offset < maxResults; offset += icsPageSize {
+		pageOptions := options
+		pageOptions.Query = maps.Clone(options.Query)
+		if pageOptions.Query == nil {
+			pageOptions.Query = url.Values{}
+		}
+		pageOptions.Query.Set("limit", strconv.Itoa(min(icsPageSize, maxResults-offset)))
+		pageOptions.Query.Set("offset", strconv.Itoa(offset))
+
+		searchResp, err := withTimeout(ctx, timeout, func(ctx context.Context) (*searchModels.SearchResponse, error) {
+			searchResp, err := search(ctx, searchC, pageOptions)
+			if err != nil {
+				return nil, err
+			}
+			return searchResp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if searchResp == nil {
+			break
+		}
+
+		exportResp.Count = searchResp.Count
+		exportResp.Items = append(exportResp.Items, searchResp.Items...)
+
+		if len(searchResp.Items) == 0 || offset+len(searchResp.Items) >= searchResp.Count {
+			break
+		}
+	}
+
+	return exportResp, nil
+}
+
+// createICalendarFeed writes the search results as an iCalendar (RFC 5545) feed with an event for each release
+func createICalendarFeed(ctx context.Context, w http.ResponseWriter, searchResp *searchModels.SearchResponse, template string, now time.Time) error {
+	pageTitle, pageTag := getPageTitle(template)
+	if pageTag == "" {
+		pageTag = "calendar"
+	}
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Office for National Statistics//"+pageTitle+"//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+icsEscaper.Replace(pageTitle+" - Office for National Statistics"))
+
+	if searchResp != nil {
+		for i := range searchResp.Items {
+			item := &searchResp.Items[i]
+
+			releaseDate, err := time.Parse(time.RFC3339, item.ReleaseDate)
+			if err != nil {
+				log.Warn(ctx, "skipping item with invalid release date in icalendar feed", log.Data{
+					"uri":          item.URI,
+					"release_date": item.ReleaseDate,
+				})
+				continue
+			}
+
+			writeICSEvent(&b, item, releaseDate, now)
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", strings.ToLower(pageTag)+".ics"))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte(b.String())); err != nil {
+		return fmt.Errorf("error writing icalendar to response: %s", err)
+	}
+
+	return nil
+}
+
+// writeICSEvent writes a VEVENT for the search result
+func writeICSEvent(b *strings.Builder, item *searchModels.Item, releaseDate, now time.Time) {
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, "UID:"+getICSUID(item.URI))
+	writeICSLine(b, "DTSTAMP:"+now.UTC().Format(icsDateTimeFormat))
+	writeICSLine(b, "DTSTART:"+releaseDate.UTC().Format(icsDateTimeFormat))
+	writeICSLine(b, "SUMMARY:"+icsEscaper.Replace(item.Title))
+	if item.Summary != "" {
+		writeICSLine(b, "DESCRIPTION:"+icsEscaper.Replace(item.Summary))
+	}
+	writeICSLine(b, "URL:"+icsURIPrefix+item.URI)
+	writeICSLine(b, "STATUS:"+getICSStatus(item))
+	writeICSLine(b, "END:VEVENT")
+}
+
+// getICSUID returns a uid for the event which is the same each time the feed is requested so calendars update rather than duplicate it
+func getICSUID(uri string) string {
+	hash := sha1.Sum([]byte(uri)) //nolint:gosec // not used for security
+	return hex.EncodeToString(hash[:]) + icsUIDDomain
+}
+
+// getICSStatus maps the state of the release to the status of the event, content other than releases has already been published
+func getICSStatus(item *searchModels.Item) string {
+	switch {
+	case item.Cancelled:
+		return "CANCELLED"
+	case item.DataType != "release", item.Published, item.Finalised:
+		return "CONFIRMED"
+	default:
+		return "TENTATIVE"
+	}
+}
+
+// writeICSLine writes the content line, folding it so that no line is longer than 75 octets
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		// avoid splitting a multi-byte character
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space which counts towards their length
+		limit = icsLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
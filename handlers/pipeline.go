@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/redirect"
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Names of the stages of the read request pipeline
+const (
+	StageResolveTopic       = "resolve-topic"
+	StageResolvePage        = "resolve-page"
+	StageMigrationRedirect  = "migration-redirect"
+	StageEditionComparison  = "edition-comparison"
+	StageFetchContent       = "fetch-content"
+	StageValidateParams     = "validate-params"
+	StageRSS                = "rss"
+	StageQuery              = "query"
+	StageReleaseCutoff      = "release-cutoff"
+	StageICalendar          = "icalendar"
+	StageFetch              = "fetch"
+	StageCount              = "count"
+	StageReleaseYears       = "release-years"
+	StageMap                = "map"
+	StageReleaseSchedule    = "release-schedule"
+	StageBrokenRelatedLinks = "broken-related-links"
+	StageCollectionPreview  = "collection-preview"
+	StageAnalytics          = "analytics"
+	StageRender             = "render"
+)
+
+// SearchUnavailableTemplate is the template of the page shown while the search api cannot be called
+const SearchUnavailableTemplate = "search-unavailable"
+
+// errResponseWritten is returned by a stage which has written the response, e.g. a feed or a page of validation errors,
+// to stop the pipeline without writing an error status
+var errResponseWritten = errors.New("response written")
+
+// RequestContext is the state of a read request, which is built up as the request passes through the stages of the
+// pipeline of its page type
+type RequestContext struct {
+	Ctx          context.Context
+	cancel       context.CancelFunc
+	W            http.ResponseWriter
+	Req          *http.Request
+	Cfg          *config.Config
+	Zebedee      ZebedeeClient
+	Renderer     RenderClient
+	Search       SearchClient
+	Analytics    *analytics.Recorder
+	Redirector   *redirect.Redirector
+	CacheList    cache.List
+	AccessToken  string
+	CollectionID string
+	Lang         string
+	Now          time.Time
+	AggCfg       AggregationConfig
+	// URLPath is the path of the page the list belongs to, e.g. the release of /previousreleases
+	URLPath string
+	// ReleaseYearCounts keeps the counts of the previous releases in each year, nothing is kept if it is nil
+	ReleaseYearCounts *ReleaseYearCountsCache
+
+	SelectedTopic        cache.Topic
+	ClearTopics          bool
+	PageData             zebedeeCli.PageData
+	HomepageResp         zebedeeCli.HomepageContent
+	NavigationCache      *models.Navigation
+	Breadcrumbs          []zebedeeCli.Breadcrumb
+	QueryParams          data.SearchURLParams
+	ValidationErrs       []core.ErrorItem
+	SearchOptions        searchSDK.Options
+	CategoriesCountQuery url.Values
+	SearchResp           *searchModels.SearchResponse
+	SearchCount          int
+	SearchLatency        time.Duration
+	Categories           []data.Category
+	TopicCategories      []data.Topic
+	MissingRelatedURIs   []string
+	YearCounts           []data.YearCount
+	// Degraded are the parts of the page left out because a dependency failed, which do not fail the request
+	Degraded model.Degraded
+	Page     model.SearchPage
+}
+
+// Stage is a step of the read request pipeline. Returning an error stops the pipeline and writes the status of the
+// error, unless the stage has written the response itself
+type Stage struct {
+	Name string
+	Run  func(rc *RequestContext) error
+}
+
+// Pipeline is the ordered stages a read request passes through
+type Pipeline []Stage
+
+// defaultPipeline returns the stages of a search or data aggregation page
+func defaultPipeline() Pipeline {
+	return Pipeline{
+		{Name: StageResolveTopic, Run: resolveTopicStage},
+		{Name: StageFetchContent, Run: fetchContentStage},
+		{Name: StageValidateParams, Run: validateParamsStage},
+		{Name: StageRSS, Run: rssStage},
+		{Name: StageQuery, Run: queryStage},
+		{Name: StageReleaseCutoff, Run: releaseCutoffStage},
+		{Name: StageICalendar, Run: icalendarStage},
+		{Name: StageFetch, Run: fetchStage},
+		{Name: StageCount, Run: countStage},
+		{Name: StageMap, Run: mapStage},
+		{Name: StageReleaseSchedule, Run: releaseScheduleStage},
+		{Name: StageCollectionPreview, Run: collectionPreviewStage},
+		{Name: StageAnalytics, Run: analyticsStage},
+		{Name: StageRender, Run: renderStage},
+	}
+}
+
+// Skip returns the pipeline without the named stages
+func (p Pipeline) Skip(names ...string) Pipeline {
+	return slices.DeleteFunc(slices.Clone(p), func(stage Stage) bool {
+		return slices.Contains(names, stage.Name)
+	})
+}
+
+// Replace returns the pipeline with the named stage run by run instead
+func (p Pipeline) Replace(name string, run func(rc *RequestContext) error) Pipeline {
+	replaced := slices.Clone(p)
+	for i := range replaced {
+		if replaced[i].Name == name {
+			replaced[i].Run = run
+		}
+	}
+	return replaced
+}
+
+// InsertBefore returns the pipeline with the stage added before the named stage, or at the end if it is not found
+func (p Pipeline) InsertBefore(name string, stage Stage) Pipeline {
+	i := slices.IndexFunc(p, func(s Stage) bool { return s.Name == name })
+	if i < 0 {
+		return append(slices.Clone(p), stage)
+	}
+	return slices.Insert(slices.Clone(p), i, stage)
+}
+
+// InsertAfter returns the pipeline with the stage added after the named stage, or at the end if it is not found
+func (p Pipeline) InsertAfter(name string, stage Stage) Pipeline {
+	i := slices.IndexFunc(p, func(s Stage) bool { return s.Name == name })
+	if i < 0 {
+		return append(slices.Clone(p), stage)
+	}
+	return slices.Insert(slices.Clone(p), i+1, stage)
+}
+
+// Names returns the names of the stages in order
+func (p Pipeline) Names() []string {
+	names := make([]string, 0, len(p))
+	for i := range p {
+		names = append(names, p[i].Name)
+	}
+	return names
+}
+
+// Run passes the request through each stage in turn until one of them fails or writes the response. Each stage is
+// traced in a child span of the request, which is given the attributes of the search once the pipeline stops
+func (p Pipeline) Run(rc *RequestContext) {
+	defer rc.setSpanAttributes()
+
+	for i := range p {
+		if err := p[i].runTraced(rc); err != nil {
+			if !errors.Is(err, errResponseWritten) {
+				setStatusCode(rc.W, rc.Req, err)
+			}
+			return
+		}
+	}
+}
+
+// runTraced runs the stage in its own span, which the calls made by the stage are children of
+func (s Stage) runTraced(rc *RequestContext) (err error) {
+	requestCtx := rc.Ctx
+	ctx, span := tracing.Start(requestCtx, "stage "+s.Name, tracing.AttrTemplate.String(rc.AggCfg.TemplateName))
+	rc.Ctx = ctx
+	defer func() {
+		rc.Ctx = requestCtx
+		if errors.Is(err, errResponseWritten) {
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+
+	return s.Run(rc)
+}
+
+// setSpanAttributes adds the template, filters, result count and any validation errors of the search to the span of
+// the request
+func (rc *RequestContext) setSpanAttributes() {
+	span := trace.SpanFromContext(rc.Ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(
+		tracing.AttrTemplate.String(rc.AggCfg.TemplateName),
+		tracing.AttrResultCount.Int(rc.SearchCount),
+		tracing.AttrFilters.StringSlice(spanFilters(rc.QueryParams)),
+	)
+
+	if len(rc.ValidationErrs) > 0 {
+		ids := make([]string, 0, len(rc.ValidationErrs))
+		for i := range rc.ValidationErrs {
+			ids = append(ids, rc.ValidationErrs[i].ID)
+		}
+		span.SetAttributes(tracing.AttrValidationErrors.StringSlice(ids))
+	}
+}
+
+// spanFilters returns the filters of the search as sorted key=value pairs
+func spanFilters(params data.SearchURLParams) []string {
+	filters := searchFilters(params)
+	pairs := make([]string, 0, len(filters))
+	for key, value := range filters {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return pairs
+}
+
+// pipeline returns the pipeline of the page type
+func (aggCfg AggregationConfig) pipeline() Pipeline {
+	p := defaultPipeline()
+	if aggCfg.Stages != nil {
+		p = aggCfg.Stages(p)
+	}
+	return p
+}
+
+// handleReadRequest passes a read request through the pipeline of its page type
+func (sh *SearchHandler) handleReadRequest(w http.ResponseWriter, req *http.Request, cfg *config.Config, accessToken, collectionID, lang string, aggCfg AggregationConfig) {
+	rc := sh.newRequestContext(w, req, cfg, accessToken, collectionID, lang, aggCfg)
+	defer rc.cancel()
+	metrics.SetTemplate(rc.Ctx, aggCfg.TemplateName)
+
+	aggCfg.pipeline().Run(rc)
+}
+
+// newRequestContext creates the state of a read request before it enters the pipeline
+func (sh *SearchHandler) newRequestContext(w http.ResponseWriter, req *http.Request, cfg *config.Config, accessToken, collectionID, lang string, aggCfg AggregationConfig) *RequestContext {
+	ctx, cancel := context.WithCancel(req.Context())
+
+	if aggCfg.URLQueryParams == nil {
+		aggCfg.URLQueryParams = req.URL.Query()
+	}
+
+	return &RequestContext{
+		Ctx:               ctx,
+		cancel:            cancel,
+		W:                 w,
+		Req:               req,
+		Cfg:               cfg,
+		Zebedee:           sh.ZebedeeClient,
+		Renderer:          sh.Renderer,
+		Search:            sh.SearchClient,
+		Analytics:         sh.Analytics,
+		Redirector:        sh.Redirector,
+		ReleaseYearCounts: sh.ReleaseYearCounts,
+		CacheList:         sh.CacheList,
+		AccessToken:       accessToken,
+		CollectionID:      collectionID,
+		Lang:              lang,
+		Now:               sh.Clock.Now(),
+		AggCfg:            aggCfg,
+		URLPath:           path.Dir(req.URL.Path),
+		SearchResp:        &searchModels.SearchResponse{},
+	}
+}
+
+// renderErrorPage renders the page of the validation errors, without any search results, and stops the pipeline
+func (rc *RequestContext) renderErrorPage(topic cache.Topic, bc []zebedeeCli.Breadcrumb) error {
+	for i := range rc.ValidationErrs {
+		id := rc.ValidationErrs[i].ID
+		if id == "" {
+			id = metrics.UnknownID
+		}
+		metrics.ValidationErrors.WithLabelValues(id).Inc()
+	}
+
+	errorRC := *rc
+	errorRC.Categories = []data.Category{}
+	errorRC.TopicCategories = []data.Topic{}
+	errorRC.SearchResp = &searchModels.SearchResponse{}
+	errorRC.HomepageResp = zebedeeCli.HomepageContent{}
+	errorRC.SelectedTopic = topic
+	errorRC.Breadcrumbs = bc
+
+	m := rc.AggCfg.CreatePageModel(&errorRC)
+	buildDataAggregationPage(rc.W, m, rc.Renderer, rc.AggCfg.TemplateName)
+
+	return errResponseWritten
+}
+
+// renderUnavailablePage renders the page telling the user that search is temporarily unavailable, with a 503 status
+// asking them to retry once the circuit breaker of the search api may have closed, and stops the pipeline
+func (rc *RequestContext) renderUnavailablePage() error {
+	m := mapper.CreateSearchUnavailablePage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.Lang, rc.HomepageResp, rc.NavigationCache)
+
+	rc.W.Header().Set("Retry-After", strconv.Itoa(int(rc.Cfg.CircuitBreakerOpenTimeout.Seconds())))
+	rc.W.WriteHeader(http.StatusServiceUnavailable)
+	rc.Renderer.BuildPage(rc.W, m, SearchUnavailableTemplate)
+
+	return errResponseWritten
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func testStage(name string, ran *[]string, err error) Stage {
+	return Stage{
+		Name: name,
+		Run: func(rc *RequestContext) error {
+			*ran = append(*ran, name)
+			return err
+		},
+	}
+}
+
+func TestUnitPipeline(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the default pipeline", t, func() {
+		p := defaultPipeline()
+
+		Convey("Then its stages are in order", func() {
+			So(p.Names(), ShouldResemble, []string{
+				StageResolveTopic, StageFetchContent, StageValidateParams, StageRSS, StageQuery,
+				StageReleaseCutoff, StageICalendar, StageFetch, StageCount, StageMap, StageReleaseSchedule, StageCollectionPreview, StageAnalytics,
+				StageRender,
+			})
+		})
+
+		Convey("When stages are skipped", func() {
+			skipped := p.Skip(StageResolveTopic, StageCount)
+
+			Convey("Then they are removed without changing the default pipeline", func() {
+				So(skipped.Names(), ShouldNotContain, StageResolveTopic)
+				So(skipped.Names(), ShouldNotContain, StageCount)
+				So(skipped, ShouldHaveLength, len(p)-2)
+				So(p.Names(), ShouldContain, StageCount)
+			})
+		})
+
+		Convey("When a stage is inserted before another", func() {
+			inserted := p.InsertBefore(StageFetchContent, Stage{Name: StageResolvePage})
+
+			Convey("Then it is added before the named stage", func() {
+				So(inserted.Names()[:3], ShouldResemble, []string{StageResolveTopic, StageResolvePage, StageFetchContent})
+				So(p, ShouldHaveLength, len(inserted)-1)
+			})
+		})
+
+		Convey("When a stage is inserted after another", func() {
+			inserted := p.InsertAfter(StageCollectionPreview, Stage{Name: StageBrokenRelatedLinks})
+
+			Convey("Then it is added after the named stage", func() {
+				So(inserted.Names()[len(inserted)-4:], ShouldResemble, []string{StageCollectionPreview, StageBrokenRelatedLinks, StageAnalytics, StageRender})
+			})
+		})
+
+		Convey("When a stage is inserted next to an unknown stage", func() {
+			inserted := p.InsertAfter("unknown", Stage{Name: StageBrokenRelatedLinks})
+
+			Convey("Then it is added at the end", func() {
+				So(inserted.Names()[len(inserted)-1], ShouldEqual, StageBrokenRelatedLinks)
+			})
+		})
+	})
+
+	Convey("Given a pipeline", t, func() {
+		var ran []string
+		w := httptest.NewRecorder()
+		rc := &RequestContext{Ctx: context.Background(), W: w, Req: httptest.NewRequest(http.MethodGet, "/search", http.NoBody)}
+
+		p := Pipeline{
+			testStage(StageQuery, &ran, nil),
+			testStage(StageFetch, &ran, nil),
+			testStage(StageRender, &ran, nil),
+		}
+
+		Convey("When it is run", func() {
+			p.Run(rc)
+
+			Convey("Then every stage is run in order", func() {
+				So(ran, ShouldResemble, []string{StageQuery, StageFetch, StageRender})
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When a stage is replaced and it is run", func() {
+			p.Replace(StageFetch, func(rc *RequestContext) error {
+				ran = append(ran, "replaced")
+				return nil
+			}).Run(rc)
+
+			Convey("Then the replacement is run instead", func() {
+				So(ran, ShouldResemble, []string{StageQuery, "replaced", StageRender})
+			})
+		})
+
+		Convey("When a stage fails", func() {
+			p.Replace(StageFetch, testStage(StageFetch, &ran, apperrors.ErrTopicPathNotFound).Run).Run(rc)
+
+			Convey("Then the pipeline stops and the status of the error is written", func() {
+				So(ran, ShouldResemble, []string{StageQuery, StageFetch})
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a stage fails with an unknown error", func() {
+			p.Replace(StageFetch, testStage(StageFetch, &ran, errors.New("internal error")).Run).Run(rc)
+
+			Convey("Then the pipeline stops and an internal server error is written", func() {
+				So(ran, ShouldResemble, []string{StageQuery, StageFetch})
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When a stage writes the response", func() {
+			p.Replace(StageFetch, func(rc *RequestContext) error {
+				ran = append(ran, StageFetch)
+				rc.W.WriteHeader(http.StatusAccepted)
+				return errResponseWritten
+			}).Run(rc)
+
+			Convey("Then the pipeline stops without writing an error status", func() {
+				So(ran, ShouldResemble, []string{StageQuery, StageFetch})
+				So(w.Code, ShouldEqual, http.StatusAccepted)
+			})
+		})
+	})
+}
+
+func TestUnitPipelineTracing(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a pipeline run in a traced request", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		otel.SetTracerProvider(provider)
+
+		ctx, requestSpan := provider.Tracer("test").Start(context.Background(), "request")
+		rc := &RequestContext{
+			Ctx:            ctx,
+			W:              httptest.NewRecorder(),
+			Req:            httptest.NewRequest(http.MethodGet, "/search", http.NoBody),
+			AggCfg:         AggregationConfig{TemplateName: "search"},
+			SearchCount:    25,
+			QueryParams:    data.SearchURLParams{Filter: data.Filter{Query: []string{"bulletin"}}, Sort: data.Sort{Query: "relevance"}},
+			ValidationErrs: []core.ErrorItem{{ID: "input-text-error"}},
+		}
+
+		p := Pipeline{
+			{Name: StageValidateParams, Run: func(*RequestContext) error { return nil }},
+			{Name: StageFetch, Run: func(*RequestContext) error { return errors.New("search api failed") }},
+		}
+
+		Convey("When it is run", func() {
+			p.Run(rc)
+			requestSpan.End()
+
+			spans := map[string]sdktrace.ReadOnlySpan{}
+			for _, span := range recorder.Ended() {
+				if span.SpanContext().TraceID() == requestSpan.SpanContext().TraceID() {
+					spans[span.Name()] = span
+				}
+			}
+
+			Convey("Then each stage run is traced in a child span of the request", func() {
+				So(spans, ShouldContainKey, "stage "+StageValidateParams)
+				So(spans, ShouldContainKey, "stage "+StageFetch)
+				So(spans["stage "+StageFetch].Parent().SpanID(), ShouldEqual, requestSpan.SpanContext().SpanID())
+				So(rc.Ctx, ShouldEqual, ctx)
+			})
+
+			Convey("Then the span of the failed stage records its error", func() {
+				So(spans["stage "+StageFetch].Status().Code, ShouldEqual, codes.Error)
+				So(spans["stage "+StageValidateParams].Status().Code, ShouldEqual, codes.Unset)
+			})
+
+			Convey("Then the span of the request has the attributes of the search", func() {
+				attrs := spans["request"].Attributes()
+				So(attrs, ShouldContain, tracing.AttrTemplate.String("search"))
+				So(attrs, ShouldContain, tracing.AttrResultCount.Int(25))
+				So(attrs, ShouldContain, tracing.AttrFilters.StringSlice([]string{"content_type=bulletin", "sort=relevance"}))
+				So(attrs, ShouldContain, tracing.AttrValidationErrors.StringSlice([]string{"input-text-error"}))
+			})
+		})
+	})
+}
@@ -4,66 +4,199 @@ import (
 	"context"
 	"net/http"
 	"net/url"
-	"path"
+	"sync"
+	"time"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
-	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
-	searchModels "github.com/ONSdigital/dp-search-api/models"
-	"github.com/ONSdigital/dp-topic-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	// releaseYearsPageSize is the number of previous releases requested at a time when counting the years of the releases
+	releaseYearsPageSize = 100
+	// releaseYearsMaxResults is the maximum number of previous releases which are counted
+	releaseYearsMaxResults = 1000
 )
 
 // PreviousReleases handler
 func (sh *SearchHandler) PreviousReleases(cfg *config.Config) http.HandlerFunc {
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
 		previousReleasesConfig := NewPreviousReleasesConfig(*req)
-		ctx := context.Background()
-		urlPath := path.Dir(req.URL.Path)
-
-		pageData, err := validatePageType(ctx, w, sh.ZebedeeClient, previousReleasesConfig, accessToken, collectionID, lang, urlPath)
-		if err != nil {
-			setStatusCode(w, req, err)
-			return
-		}
-
-		if pageData.Description.MigrationLink != "" {
-			migrationLinkURI := pageData.Description.MigrationLink + "/editions"
-			http.Redirect(w, req, migrationLinkURI, http.StatusPermanentRedirect)
-			return
-		}
 
-		handleReadRequest(w, req, cfg, sh.ZebedeeClient, sh.Renderer, sh.SearchClient, accessToken, collectionID, lang, sh.CacheList, previousReleasesConfig)
+		sh.handleReadRequest(w, req, cfg, accessToken, collectionID, lang, previousReleasesConfig)
 	})
 }
 
 func NewPreviousReleasesConfig(req http.Request) AggregationConfig {
-	createPageModel := func(cfg *config.Config, req *http.Request, base core.Page, queryParams data.SearchURLParams, categories []data.Category, topics []data.Topic, searchResp *searchModels.SearchResponse, lang string, homepageResp zebedee.HomepageContent, errorMessage string, navigationCache *models.Navigation,
-		template string, topic cache.Topic, validationErrs []core.ErrorItem, pageData zebedee.PageData, bc []zebedee.Breadcrumb) model.SearchPage {
-		return mapper.CreatePreviousReleasesPage(cfg, req, base, queryParams, searchResp, lang, homepageResp, "", navigationCache, template, cache.Topic{}, validationErrs, pageData, bc)
+	createPageModel := func(rc *RequestContext) model.SearchPage {
+		return mapper.CreatePreviousReleasesPage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.QueryParams, rc.SearchResp, rc.YearCounts, rc.Lang, rc.HomepageResp, "", rc.NavigationCache, rc.AggCfg.TemplateName, cache.Topic{}, rc.ValidationErrs, rc.PageData, rc.Breadcrumbs)
 	}
 	validateParams := func(ctx context.Context, cfg *config.Config, urlQuery url.Values, urlPath string, _ *cache.Topic) (data.SearchURLParams, []core.ErrorItem) {
 		return data.ReviewPreviousReleasesQueryWithParams(ctx, cfg, urlQuery, urlPath)
 	}
 	getSearchAndCategoriesCountQueries := func(validatedQueryParams data.SearchURLParams, _ *cache.Topic, _, pageDataType string) (searchQuery, categoriesCountQuery url.Values) {
-		searchQuery = data.SetParentTypeOnSearchAPIQuery(validatedQueryParams, pageDataType)
+		searchQuery = data.GetPreviousReleasesQuery(validatedQueryParams, pageDataType)
 
 		return searchQuery, categoriesCountQuery
 	}
 
 	urlQuery := req.URL.Query()
-	sanitisedParams := sanitiseQueryParams(allowedPreviousReleasesQueryParams, urlQuery)
+	sanitisedParams := sanitiseQueryParams(allowedPreviousReleasesFilterQueryParams, urlQuery)
 
 	return AggregationConfig{
-		TemplateName:                       "related-list-pages",
+		TemplateName:                       RelatedPagesTemplate,
 		UseTopicsPath:                      false,
 		URLQueryParams:                     sanitisedParams,
 		ValidateParams:                     validateParams,
 		GetSearchAndCategoriesCountQueries: getSearchAndCategoriesCountQueries,
 		CreatePageModel:                    createPageModel,
+		Stages: func(p Pipeline) Pipeline {
+			return p.Skip(StageResolveTopic, StageCount).
+				InsertBefore(StageFetchContent, Stage{Name: StageResolvePage, Run: resolvePageStage(latestReleasePath)}).
+				InsertAfter(StageResolvePage, Stage{Name: StageMigrationRedirect, Run: migrationRedirectStage(previousReleasesRoute)}).
+				InsertAfter(StageMigrationRedirect, Stage{Name: StageEditionComparison, Run: editionComparisonStage}).
+				Replace(StageFetchContent, fetchContentWithBreadcrumbsStage(latestReleasePath)).
+				InsertAfter(StageFetch, Stage{Name: StageReleaseYears, Run: releaseYearCountsStage})
+		},
+		EnableICalendar: true,
 	}
 }
+
+// editionComparisonStage compares the editions given by the compare query parameter instead of listing the previous releases
+func editionComparisonStage(rc *RequestContext) error {
+	editionURIs, compare := rc.Req.URL.Query()[compareQueryParam]
+	if !compare {
+		return nil
+	}
+
+	handleEditionComparison(rc.W, rc.Req, rc.Cfg, rc.Zebedee, rc.Renderer, rc.AccessToken, rc.CollectionID, rc.Lang, rc.CacheList, rc.URLPath, rc.PageData, editionURIs)
+
+	return errResponseWritten
+}
+
+// releaseYearCountsStage counts the previous releases in each year so that they can be filtered by year. The counts are
+// of every edition of the release, whatever the edition filter, so they are kept for each release other than in
+// collection previews where the releases may be changing. They are left out of the page if they cannot be counted
+// within the search count timeout
+func releaseYearCountsStage(rc *RequestContext) error {
+	cacheable := rc.CollectionID == ""
+
+	if cacheable {
+		if yearCounts, ok := rc.ReleaseYearCounts.Get(rc.URLPath, rc.Now); ok {
+			rc.YearCounts = yearCounts
+			return nil
+		}
+	}
+
+	yearCounts, err := withTimeout(rc.Ctx, rc.Cfg.SearchCountTimeout, func(ctx context.Context) ([]data.YearCount, error) {
+		return getReleaseYearCounts(ctx, rc.Cfg, rc.Now, rc.Search, rc.SearchOptions)
+	})
+	if err != nil {
+		log.Warn(rc.Ctx, "failed to count the years of previous releases", log.FormatErrors([]error{err}), log.Data{"url_path": rc.URLPath})
+		return nil
+	}
+
+	rc.YearCounts = yearCounts
+	if cacheable {
+		rc.ReleaseYearCounts.Set(rc.URLPath, rc.YearCounts, rc.Now)
+	}
+
+	return nil
+}
+
+// ReleaseYearCountsCache keeps the counts of the previous releases in each year for a time, as counting them takes a
+// search for each page of previous releases
+type ReleaseYearCountsCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]releaseYearCountsEntry
+}
+
+type releaseYearCountsEntry struct {
+	yearCounts []data.YearCount
+	expires    time.Time
+}
+
+// NewReleaseYearCountsCache creates a cache which keeps the counts for the time to live, they are not kept if it is 0
+func NewReleaseYearCountsCache(ttl time.Duration) *ReleaseYearCountsCache {
+	return &ReleaseYearCountsCache{
+		ttl:     ttl,
+		entries: make(map[string]releaseYearCountsEntry),
+	}
+}
+
+// Get returns the counts kept for the key, if they have not expired
+func (c *ReleaseYearCountsCache) Get(key string, now time.Time) ([]data.YearCount, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.yearCounts, true
+}
+
+// Set keeps the counts for the key, removing the counts which have expired
+func (c *ReleaseYearCountsCache) Set(key string, yearCounts []data.YearCount, now time.Time) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = releaseYearCountsEntry{yearCounts: yearCounts, expires: now.Add(c.ttl)}
+}
+
+// getReleaseYearCounts counts the previous releases in each year, ignoring the date range chosen so that every year can
+// be chosen, but not the release cutoff so that releases scheduled in the future are not counted in web mode
+func getReleaseYearCounts(ctx context.Context, cfg *config.Config, now time.Time, searchC SearchClient, options searchSDK.Options) ([]data.YearCount, error) {
+	counts := map[int]int{}
+
+	for offset := 0; offset < releaseYearsMaxResults; offset += releaseYearsPageSize {
+		yearsOptions := options
+		yearsOptions.Query = data.GetReleaseYearsQuery(options.Query, releaseYearsPageSize, offset)
+		applyReleaseCutoff(cfg, now, yearsOptions.Query)
+
+		searchResp, err := searchC.GetSearch(ctx, yearsOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range searchResp.Items {
+			releaseDate, err := time.Parse(time.RFC3339, searchResp.Items[i].ReleaseDate)
+			if err != nil {
+				continue
+			}
+			counts[releaseDate.Year()]++
+		}
+
+		if len(searchResp.Items) < releaseYearsPageSize || offset+releaseYearsPageSize >= searchResp.Count {
+			break
+		}
+	}
+
+	yearCounts := make([]data.YearCount, 0, len(counts))
+	for year, count := range counts {
+		yearCounts = append(yearCounts, data.YearCount{Year: year, Count: count})
+	}
+
+	return yearCounts, nil
+}
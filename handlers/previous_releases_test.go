@@ -2,13 +2,22 @@ package handlers
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
 	zebedeeC "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	apiError "github.com/ONSdigital/dp-search-api/sdk/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -42,6 +51,308 @@ func TestUnitReadPreviousReleasesWithMigrationLink(t *testing.T) {
 
 				So(w.Code, ShouldEqual, http.StatusPermanentRedirect)
 				So(location, ShouldEqual, expectedLocation)
+				So(mockedZebedeeClient.GetPageDataCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestUnitReadPreviousReleasesEditionComparison(t *testing.T) {
+	Convey("Given a search handler and zebedee client with editions of a bulletin", t, func() {
+		ctx := context.Background()
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.PageData, error) {
+				pageType := "bulletin"
+				if path == "/foo/bar/dataset" {
+					pageType = "dataset"
+				}
+				return zebedeeC.PageData{
+					Type: pageType,
+					URI:  path,
+					Description: zebedeeC.Description{
+						Title:   "My test bulletin",
+						Summary: "Summary of " + path,
+					},
+				}, nil
+			},
+			GetHomepageContentFunc: func(ctx context.Context, userAccessToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+			GetBreadcrumbFunc: func(ctx context.Context, userAccessToken, collectionID, lang, uri string) ([]zebedeeC.Breadcrumb, error) {
+				return []zebedeeC.Breadcrumb{}, nil
+			},
+		}
+
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, pageModel interface{}, templateName string) {},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		mockSearchHandler := NewSearchHandler(mockedRendererClient, &SearchClientMock{}, &TopicClientMock{}, mockedZebedeeClient, cfg, *mockCacheList)
+
+		Convey("When /previousreleases is called with two editions to compare", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/previousreleases?compare=/foo/bar/2024-03-01&compare=/foo/bar/2024-02-01", http.NoBody)
+			w := doTestRequest("/{uri:.*}/previousreleases", req, mockSearchHandler.PreviousReleases(cfg), nil)
+
+			Convey("Then the editions are compared side by side", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 1)
+				So(mockedRendererClient.BuildPageCalls()[0].TemplateName, ShouldEqual, EditionComparisonTemplate)
+
+				page, ok := mockedRendererClient.BuildPageCalls()[0].PageModel.(model.SearchPage)
+				So(ok, ShouldBeTrue)
+				So(page.Data.EditionComparison.Editions, ShouldHaveLength, 2)
+				So(mockedZebedeeClient.GetPageDataCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When /previousreleases is called with one edition to compare", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/previousreleases?compare=/foo/bar/2024-03-01", http.NoBody)
+			w := doTestRequest("/{uri:.*}/previousreleases", req, mockSearchHandler.PreviousReleases(cfg), nil)
+
+			Convey("Then a 400 bad request should be returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When /previousreleases is called with an edition of another release", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/previousreleases?compare=/foo/bar/2024-03-01&compare=/other/release/2024-02-01", http.NoBody)
+			w := doTestRequest("/{uri:.*}/previousreleases", req, mockSearchHandler.PreviousReleases(cfg), nil)
+
+			Convey("Then a 400 bad request should be returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When /previousreleases is called with a page which is not a known related list type", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/previousreleases?compare=/foo/bar/2024-03-01&compare=/foo/bar/dataset", http.NoBody)
+			w := doTestRequest("/{uri:.*}/previousreleases", req, mockSearchHandler.PreviousReleases(cfg), nil)
+
+			Convey("Then a 404 not found should be returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedRendererClient.BuildPageCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestUnitGetReleaseYearCounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	Convey("Given previous releases across two pages of search results", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.IsPublishing = true
+
+		var offsets []string
+		mockSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				offsets = append(offsets, options.Query.Get("offset"))
+				So(options.Query.Has("fromDate"), ShouldBeFalse)
+				So(options.Query.Has("toDate"), ShouldBeFalse)
+				if options.Query.Get("offset") == "0" {
+					items := make([]searchModels.Item, releaseYearsPageSize)
+					for i := range items {
+						items[i].ReleaseDate = "2024-03-01T07:00:00.000Z"
+					}
+					return &searchModels.SearchResponse{Count: releaseYearsPageSize + 2, Items: items}, nil
+				}
+				return &searchModels.SearchResponse{
+					Count: releaseYearsPageSize + 2,
+					Items: []searchModels.Item{
+						{ReleaseDate: "2023-03-01T07:00:00.000Z"},
+						{ReleaseDate: "not a date"},
+					},
+				}, nil
+			},
+		}
+		options := searchSDK.Options{Query: data.GetReleaseYearsQuery(nil, 10, 0)}
+		options.Query.Set("fromDate", "2024-01-01")
+
+		Convey("When getReleaseYearCounts is called", func() {
+			yearCounts, err := getReleaseYearCounts(ctx, cfg, now, mockSearchClient, options)
+			So(err, ShouldBeNil)
+
+			Convey("Then the releases are counted by year across every page without the date range", func() {
+				So(offsets, ShouldResemble, []string{"0", "100"})
+				So(yearCounts, ShouldHaveLength, 2)
+				So(yearCounts, ShouldContain, data.YearCount{Year: 2024, Count: releaseYearsPageSize})
+				So(yearCounts, ShouldContain, data.YearCount{Year: 2023, Count: 1})
+			})
+		})
+	})
+
+	Convey("Given previous releases in web mode, where the search has a release cutoff", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.IsPublishing = false
+
+		mockSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return &searchModels.SearchResponse{Count: 1, Items: []searchModels.Item{{ReleaseDate: "2024-03-01T07:00:00.000Z"}}}, nil
+			},
+		}
+		options := searchSDK.Options{Query: url.Values{"fromDate": {"2023-01-01"}, "toDate": {"2023-12-31"}}}
+
+		Convey("When getReleaseYearCounts is called", func() {
+			_, err := getReleaseYearCounts(ctx, cfg, now, mockSearchClient, options)
+			So(err, ShouldBeNil)
+
+			Convey("Then the date range chosen is dropped but releases scheduled after today are still left out", func() {
+				So(mockSearchClient.GetSearchCalls(), ShouldHaveLength, 1)
+				yearsQuery := mockSearchClient.GetSearchCalls()[0].Options.Query
+				So(yearsQuery.Has("fromDate"), ShouldBeFalse)
+				So(yearsQuery.Get("toDate"), ShouldEqual, "2024-03-01")
+			})
+		})
+	})
+
+	Convey("Given the search API returns an error", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return nil, apiError.StatusError{Code: 500}
+			},
+		}
+
+		Convey("When getReleaseYearCounts is called", func() {
+			yearCounts, err := getReleaseYearCounts(ctx, cfg, now, mockSearchClient, searchSDK.Options{})
+
+			Convey("Then the error is returned without year counts", func() {
+				So(err, ShouldNotBeNil)
+				So(yearCounts, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUnitReleaseYearCountsStage(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	Convey("Given the previous releases of a release and a cache of their counts", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				return &searchModels.SearchResponse{Count: 1, Items: []searchModels.Item{{ReleaseDate: "2024-03-01T07:00:00.000Z"}}}, nil
+			},
+		}
+		newRequestContext := func(collectionID string, now time.Time) *RequestContext {
+			return &RequestContext{
+				Ctx:               context.Background(),
+				Cfg:               cfg,
+				Search:            mockSearchClient,
+				CollectionID:      collectionID,
+				Now:               now,
+				URLPath:           "/economy/inflation/bulletins/cpi",
+				ReleaseYearCounts: NewReleaseYearCountsCache(10 * time.Minute),
+				SearchOptions:     searchSDK.Options{Query: url.Values{"q": {"march"}}},
+			}
+		}
+
+		Convey("When the years are counted twice for the same release", func() {
+			rc := newRequestContext("", now)
+			So(releaseYearCountsStage(rc), ShouldBeNil)
+
+			cached := newRequestContext("", now.Add(time.Minute))
+			cached.ReleaseYearCounts = rc.ReleaseYearCounts
+			So(releaseYearCountsStage(cached), ShouldBeNil)
+
+			Convey("Then the counts are only searched for once", func() {
+				So(mockSearchClient.GetSearchCalls(), ShouldHaveLength, 1)
+				So(cached.YearCounts, ShouldResemble, []data.YearCount{{Year: 2024, Count: 1}})
+			})
+		})
+
+		Convey("When the years are counted for the same release with another edition filter", func() {
+			rc := newRequestContext("", now)
+			So(releaseYearCountsStage(rc), ShouldBeNil)
+
+			filtered := newRequestContext("", now.Add(time.Minute))
+			filtered.ReleaseYearCounts = rc.ReleaseYearCounts
+			filtered.SearchOptions = searchSDK.Options{Query: url.Values{"q": {"april"}}}
+			So(releaseYearCountsStage(filtered), ShouldBeNil)
+
+			Convey("Then the counts of every edition are searched for once, without the edition filter", func() {
+				So(mockSearchClient.GetSearchCalls(), ShouldHaveLength, 1)
+				So(mockSearchClient.GetSearchCalls()[0].Options.Query.Has("q"), ShouldBeFalse)
+				So(filtered.YearCounts, ShouldResemble, []data.YearCount{{Year: 2024, Count: 1}})
+			})
+		})
+
+		Convey("When the years are counted again once the counts have expired", func() {
+			rc := newRequestContext("", now)
+			So(releaseYearCountsStage(rc), ShouldBeNil)
+
+			expired := newRequestContext("", now.Add(10*time.Minute))
+			expired.ReleaseYearCounts = rc.ReleaseYearCounts
+			So(releaseYearCountsStage(expired), ShouldBeNil)
+
+			Convey("Then the counts are searched for again", func() {
+				So(mockSearchClient.GetSearchCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the years are counted twice in a collection preview", func() {
+			rc := newRequestContext("collection", now)
+			So(releaseYearCountsStage(rc), ShouldBeNil)
+
+			preview := newRequestContext("collection", now)
+			preview.ReleaseYearCounts = rc.ReleaseYearCounts
+			So(releaseYearCountsStage(preview), ShouldBeNil)
+
+			Convey("Then the counts are not kept", func() {
+				So(mockSearchClient.GetSearchCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given the search api does not count the previous releases within the search count timeout", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.SearchCountTimeout = 10 * time.Millisecond
+
+		mockSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, apiError.Error) {
+				<-ctx.Done()
+				return nil, apiError.StatusError{Code: http.StatusGatewayTimeout}
+			},
+		}
+		rc := &RequestContext{
+			Ctx:               context.Background(),
+			Cfg:               cfg,
+			Search:            mockSearchClient,
+			Now:               now,
+			URLPath:           "/economy/inflation/bulletins/cpi",
+			ReleaseYearCounts: NewReleaseYearCountsCache(10 * time.Minute),
+			SearchOptions:     searchSDK.Options{Query: url.Values{}},
+		}
+
+		Convey("When the years are counted", func() {
+			So(releaseYearCountsStage(rc), ShouldBeNil)
+
+			Convey("Then the page is shown without the counts and they are not kept", func() {
+				So(rc.YearCounts, ShouldBeNil)
+				_, ok := rc.ReleaseYearCounts.Get(rc.URLPath, now)
+				So(ok, ShouldBeFalse)
 			})
 		})
 	})
@@ -2,51 +2,102 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
-	"path"
+	"slices"
 	"strings"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
-	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
 	searchModels "github.com/ONSdigital/dp-search-api/models"
-	"github.com/ONSdigital/dp-topic-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
+// relatedDataReportPath is the path, relative to a release, of the report of its related data which could not be found
+const relatedDataReportPath = "/relateddata/report"
+
+// RelatedDataReport is the report of the related data of a release which is not returned by the search api
+type RelatedDataReport struct {
+	ParentURI        string   `json:"parent_uri"`
+	Title            string   `json:"title"`
+	RelatedDataCount int      `json:"related_data_count"`
+	MissingCount     int      `json:"missing_count"`
+	MissingURIs      []string `json:"missing_uris"`
+}
+
 // RelatedData handler
 func (sh *SearchHandler) RelatedData(cfg *config.Config) http.HandlerFunc {
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
 		relatedDataConfig := NewRelatedDataConfig(*req)
-		ctx := context.Background()
-		urlPath := path.Dir(req.URL.Path)
-
-		if strings.HasSuffix(urlPath, "/latest") {
-			pageData, err := validatePageType(ctx, w, sh.ZebedeeClient, relatedDataConfig, accessToken, collectionID, lang, urlPath)
-			if err != nil {
-				setStatusCode(w, req, err)
-				return
-			}
 
-			if pageData.Description.MigrationLink != "" {
-				http.Redirect(w, req, pageData.Description.MigrationLink+"/related-data", http.StatusPermanentRedirect)
-				return
+		sh.handleReadRequest(w, req, cfg, accessToken, collectionID, lang, relatedDataConfig)
+	})
+}
+
+// RelatedDataReport handler reports the related data of a release which is not returned by the search api, so that
+// content editors can fix dead links
+func (sh *SearchHandler) RelatedDataReport() http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		urlPath := strings.TrimSuffix(req.URL.Path, relatedDataReportPath)
+
+		pageData, err := checkAllowedPageTypes(ctx, w, sh.ZebedeeClient, accessToken, collectionID, lang, urlPath, knownRelatedListTypes)
+		if err != nil {
+			setStatusCode(w, req, err)
+			return
+		}
+
+		uris := make([]string, 0, len(pageData.RelatedData))
+		for _, related := range pageData.RelatedData {
+			uris = append(uris, related.URI)
+		}
+
+		var options searchSDK.Options
+		options.Headers = http.Header{
+			searchSDK.CollectionID: {collectionID},
+		}
+		setAuthTokenHeader(options.Headers, accessToken)
+
+		searchResp, searchErr, _ := postSearchURIs(ctx, sh.SearchClient, options, cancel, searchAPI.URIsRequest{URIs: uris, Limit: len(uris)})
+		if searchErr != nil {
+			setStatusCode(w, req, searchErr)
+			return
+		}
+
+		report := RelatedDataReport{
+			ParentURI:        urlPath,
+			Title:            pageData.Description.Title,
+			RelatedDataCount: len(uris),
+			MissingURIs:      []string{},
+		}
+		if searchResp != nil {
+			if missingURIs := getMissingRelatedDataURIs(uris, searchResp.Items); missingURIs != nil {
+				report.MissingURIs = missingURIs
 			}
 		}
+		report.MissingCount = len(report.MissingURIs)
 
-		handleReadRequest(w, req, cfg, sh.ZebedeeClient, sh.Renderer, sh.SearchClient, accessToken, collectionID, lang, sh.CacheList, relatedDataConfig)
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(report); err != nil {
+			log.Error(ctx, "failed to write related data report", err, log.Data{"parent_page": urlPath})
+		}
 	})
 }
 
 func NewRelatedDataConfig(req http.Request) AggregationConfig {
-	createPageModel := func(cfg *config.Config, req *http.Request, base core.Page, queryParams data.SearchURLParams, categories []data.Category, topics []data.Topic, searchResp *searchModels.SearchResponse, lang string, homepageResp zebedeeCli.HomepageContent, errorMessage string, navigationCache *models.Navigation,
-		template string, topic cache.Topic, validationErrs []core.ErrorItem, pageData zebedeeCli.PageData, bc []zebedeeCli.Breadcrumb) model.SearchPage {
-		return mapper.CreateRelatedDataPage(cfg, req, base, queryParams, searchResp, lang, homepageResp, "", navigationCache, template, cache.Topic{}, validationErrs, pageData, bc)
+	createPageModel := func(rc *RequestContext) model.SearchPage {
+		return mapper.CreateRelatedDataPage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.QueryParams, rc.Categories, rc.SearchResp, rc.Lang, rc.HomepageResp, "", rc.NavigationCache, rc.AggCfg.TemplateName, cache.Topic{}, rc.ValidationErrs, rc.PageData, rc.Breadcrumbs)
 	}
 	validateParams := func(ctx context.Context, cfg *config.Config, urlQuery url.Values, urlPath string, _ *cache.Topic) (data.SearchURLParams, []core.ErrorItem) {
 		return data.ReviewRelatedDataQueryWithParams(ctx, cfg, urlQuery, urlPath)
@@ -58,15 +109,113 @@ func NewRelatedDataConfig(req http.Request) AggregationConfig {
 	}
 
 	urlQuery := req.URL.Query()
-	sanitisedParams := sanitiseQueryParams(allowedPreviousReleasesQueryParams, urlQuery)
+	sanitisedParams := sanitiseQueryParams(allowedRelatedDataQueryParams, urlQuery)
 
 	return AggregationConfig{
-		TemplateName:                       "related-list-pages",
+		TemplateName:                       RelatedPagesTemplate,
 		UseTopicsPath:                      false,
-		UseURIsRequest:                     true,
 		URLQueryParams:                     sanitisedParams,
 		ValidateParams:                     validateParams,
 		GetSearchAndCategoriesCountQueries: getSearchAndCategoriesCountQueries,
 		CreatePageModel:                    createPageModel,
+		Stages: func(p Pipeline) Pipeline {
+			return p.Skip(StageResolveTopic, StageCount).
+				InsertBefore(StageFetchContent, Stage{Name: StageResolvePage, Run: resolvePageStage(parentPagePath)}).
+				InsertAfter(StageResolvePage, Stage{Name: StageMigrationRedirect, Run: migrationRedirectStage(relatedDataRoute)}).
+				Replace(StageFetchContent, fetchContentWithBreadcrumbsStage(parentPagePath)).
+				Replace(StageFetch, fetchRelatedDataStage).
+				InsertAfter(StageReleaseSchedule, Stage{Name: StageBrokenRelatedLinks, Run: brokenRelatedLinksStage})
+		},
+	}
+}
+
+// getRelatedData gets all the related data in the chosen order to count the results of each content type, then
+// filters the results by the content types chosen and returns the current page of them. The related data which is
+// not returned by the search api is logged and returned as missing
+func getRelatedData(ctx context.Context, searchC SearchClient, options searchSDK.Options, cancel func(), parentURI string, uris []string, validatedQueryParams data.SearchURLParams) (*searchModels.SearchResponse, []data.Category, []string, searchError.Error, int) {
+	categories := data.GetRelatedDataCategories()
+
+	urisRequest := searchAPI.URIsRequest{
+		URIs:  uris,
+		Limit: len(uris),
+		Sort:  validatedQueryParams.Sort.Query,
+	}
+
+	searchResp, err, _ := postSearchURIs(ctx, searchC, options, cancel, urisRequest)
+	if err != nil || searchResp == nil {
+		return searchResp, categories, nil, err, 0
+	}
+
+	missingURIs := getMissingRelatedDataURIs(uris, searchResp.Items)
+	if len(missingURIs) > 0 {
+		log.Warn(ctx, "related data not returned by search api", log.Data{
+			"parent_page":  parentURI,
+			"missing_uris": missingURIs,
+		})
+	}
+
+	setCountToRelatedDataCategories(searchResp.Items, categories)
+
+	types := data.GetRelatedDataTypes(validatedQueryParams)
+	items := make([]searchModels.Item, 0, len(searchResp.Items))
+	for i := range searchResp.Items {
+		if len(types) == 0 || slices.Contains(types, searchResp.Items[i].DataType) {
+			items = append(items, searchResp.Items[i])
+		}
+	}
+
+	count := len(items)
+	start := min(validatedQueryParams.Offset, count)
+	end := min(validatedQueryParams.Offset+validatedQueryParams.Limit, count)
+
+	relatedDataResp := *searchResp
+	relatedDataResp.Count = count
+	relatedDataResp.Items = items[start:end]
+
+	return &relatedDataResp, categories, missingURIs, nil, count
+}
+
+// setCountToRelatedDataCategories counts the related data of each content type
+func setCountToRelatedDataCategories(items []searchModels.Item, categories []data.Category) {
+	for i := range items {
+		for j := range categories {
+			for k := range categories[j].ContentTypes {
+				if slices.Contains(categories[j].ContentTypes[k].Types, items[i].DataType) {
+					categories[j].Count++
+					categories[j].ContentTypes[k].Count++
+				}
+			}
+		}
+	}
+}
+
+// getMissingRelatedDataURIs returns the related data uris which are not in the items returned by the search api
+func getMissingRelatedDataURIs(uris []string, items []searchModels.Item) []string {
+	returnedURIs := make(map[string]struct{}, len(items))
+	for i := range items {
+		returnedURIs[normaliseCollectionURI(items[i].URI)] = struct{}{}
+	}
+
+	var missingURIs []string
+	for _, uri := range uris {
+		if _, ok := returnedURIs[normaliseCollectionURI(uri)]; !ok {
+			missingURIs = append(missingURIs, uri)
+		}
+	}
+
+	return missingURIs
+}
+
+// addBrokenRelatedLinks adds the related data which could not be found to the page in publishing mode, so that
+// content editors can fix the links
+func addBrokenRelatedLinks(cfg *config.Config, parentURI string, missingURIs []string, m *model.SearchPage) {
+	if !cfg.IsPublishing || len(missingURIs) == 0 {
+		return
+	}
+
+	m.Data.BrokenRelatedLinks = &model.BrokenRelatedLinks{
+		Count:     len(missingURIs),
+		URIs:      missingURIs,
+		ReportURL: parentURI + relatedDataReportPath,
 	}
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -12,8 +13,10 @@ import (
 	zebedeeC "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/mocks"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	searchAPI "github.com/ONSdigital/dp-search-api/api"
 	searchModels "github.com/ONSdigital/dp-search-api/models"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
@@ -83,7 +86,7 @@ func TestUnitReadRelatedData(t *testing.T) {
 
 		Convey("When readRelatedData is called", func() {
 			relatedDataConfig := NewRelatedDataConfig(*req)
-			handleReadRequest(w, req, cfg, mockedZebedeeClient, mockedRendererClient, mockedSearchClient, accessToken, collectionID, englishLang, *mockCacheList, relatedDataConfig)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, relatedDataConfig)
 			Convey("Then a 200 OK status should be returned", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
 
@@ -127,6 +130,268 @@ func TestUnitReadRelatedDataWithMigrationLink(t *testing.T) {
 
 				So(w.Code, ShouldEqual, http.StatusPermanentRedirect)
 				So(location, ShouldEqual, expectedLocation)
+				So(mockedZebedeeClient.GetPageDataCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestUnitReadRelatedDataFilteredByContentType(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	ctx := context.Background()
+
+	mockZebedeePageContent, err := mapper.GetMockZebedeePageDataResponse()
+	if err != nil {
+		t.Errorf("failed to retrieve mock zebedee page data content for unit tests, failing early: %v", err)
+	}
+
+	Convey("Given a request for the related datasets sorted by release date", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/foo/bar/relateddata?filter=datasets&sort=release_date&colour=blue", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		var pageModel model.SearchPage
+		mockedRendererClient := &RenderClientMock{
+			BuildPageFunc: func(w io.Writer, m interface{}, templateName string) {
+				pageModel = m.(model.SearchPage)
+			},
+			NewBasePageModelFunc: func() core.Page {
+				return core.Page{}
+			},
+		}
+
+		mockedSearchClient := &SearchClientMock{
+			PostSearchURIsFunc: func(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+				return &searchModels.SearchResponse{
+					Count: 3,
+					Items: []searchModels.Item{
+						{DataType: "dataset_landing_page", URI: "/dataset1"},
+						{DataType: "static_adhoc", URI: "/adhoc1"},
+						{DataType: "timeseries_dataset", URI: "/dataset2"},
+					},
+				}, nil
+			},
+		}
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetHomepageContentFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.HomepageContent, error) {
+				return zebedeeC.HomepageContent{}, nil
+			},
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.PageData, error) {
+				return mockZebedeePageContent, nil
+			},
+			GetBreadcrumbFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) ([]zebedeeC.Breadcrumb, error) {
+				return []zebedeeC.Breadcrumb{}, nil
+			},
+		}
+
+		mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+		So(err, ShouldBeNil)
+
+		Convey("When readRelatedData is called", func() {
+			relatedDataConfig := NewRelatedDataConfig(*req)
+			NewSearchHandler(mockedRendererClient, mockedSearchClient, nil, mockedZebedeeClient, cfg, *mockCacheList).handleReadRequest(w, req, cfg, accessToken, collectionID, englishLang, relatedDataConfig)
+
+			Convey("Then all the related data is requested in the order chosen", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedSearchClient.PostSearchURIsCalls(), ShouldHaveLength, 1)
+
+				urisRequest := mockedSearchClient.PostSearchURIsCalls()[0].UrisRequest
+				So(urisRequest.URIs, ShouldHaveLength, 2)
+				So(urisRequest.Limit, ShouldEqual, 2)
+				So(urisRequest.Offset, ShouldEqual, 0)
+				So(urisRequest.Sort, ShouldEqual, "release_date")
+			})
+
+			Convey("And the related data of each content type is counted", func() {
+				So(pageModel.Data.Filters, ShouldHaveLength, 1)
+				So(pageModel.Data.Filters[0].NumberOfResults, ShouldEqual, 3)
+				So(pageModel.Data.Filters[0].Types[0].NumberOfResults, ShouldEqual, 2)
+				So(pageModel.Data.Filters[0].Types[0].IsChecked, ShouldBeTrue)
+				So(pageModel.Data.Filters[0].Types[2].NumberOfResults, ShouldEqual, 1)
+			})
+
+			Convey("And only the related datasets are shown", func() {
+				So(relatedDataConfig.URLQueryParams, ShouldNotContainKey, "colour")
+				So(pageModel.Count, ShouldEqual, 2)
+				So(pageModel.Data.Response.Items, ShouldHaveLength, 2)
+				So(pageModel.Data.Response.Items[0].URI, ShouldEqual, "/dataset1")
+				So(pageModel.Data.Response.Items[1].URI, ShouldEqual, "/dataset2")
+				So(pageModel.Data.RelatedDataGroups, ShouldHaveLength, 1)
+				So(pageModel.Data.RelatedDataGroups[0].LocaliseKeyName, ShouldEqual, "Datasets")
+			})
+		})
+	})
+}
+
+func TestUnitGetRelatedData(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given related data of different content types", t, func() {
+		mockedSearchClient := &SearchClientMock{
+			PostSearchURIsFunc: func(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+				return &searchModels.SearchResponse{
+					Count: 4,
+					Items: []searchModels.Item{
+						{DataType: "timeseries", URI: "/timeseries1"},
+						{DataType: "static_adhoc", URI: "/adhoc1"},
+						{DataType: "timeseries", URI: "/timeseries2"},
+						{DataType: "timeseries", URI: "/timeseries3"},
+					},
+				}, nil
+			},
+		}
+		uris := []string{"/timeseries1", "/adhoc1", "/timeseries2", "/timeseries3"}
+
+		Convey("When the second page of the related time series is requested", func() {
+			validatedQueryParams := data.SearchURLParams{
+				Filter: data.Filter{Query: []string{"time_series"}},
+				Limit:  2,
+				Offset: 2,
+			}
+
+			searchResp, categories, missingURIs, err, count := getRelatedData(ctx, mockedSearchClient, searchSDK.Options{}, func() {}, "/foo/bar", append(uris, "/removed/dataset/"), validatedQueryParams)
+
+			Convey("Then the related time series on the second page are returned", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(searchResp.Count, ShouldEqual, 3)
+				So(searchResp.Items, ShouldHaveLength, 1)
+				So(searchResp.Items[0].URI, ShouldEqual, "/timeseries3")
+			})
+
+			Convey("And the related data not returned by the search api is missing", func() {
+				So(missingURIs, ShouldResemble, []string{"/removed/dataset/"})
+			})
+
+			Convey("And the counts of all the related data are returned", func() {
+				So(categories[0].Count, ShouldEqual, 4)
+				So(categories[0].ContentTypes[1].Count, ShouldEqual, 3)
+				So(categories[0].ContentTypes[2].Count, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a release with no related data", t, func() {
+		mockedSearchClient := &SearchClientMock{}
+
+		Convey("When getRelatedData is called", func() {
+			searchResp, categories, missingURIs, err, count := getRelatedData(ctx, mockedSearchClient, searchSDK.Options{}, func() {}, "/foo/bar", []string{}, data.SearchURLParams{Limit: 10})
+
+			Convey("Then the search api is not called and there are no results", func() {
+				So(mockedSearchClient.PostSearchURIsCalls(), ShouldBeEmpty)
+				So(err, ShouldBeNil)
+				So(searchResp, ShouldBeNil)
+				So(missingURIs, ShouldBeEmpty)
+				So(count, ShouldEqual, 0)
+				So(categories[0].Count, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestUnitAddBrokenRelatedLinks(t *testing.T) {
+	Convey("Given related data which could not be found", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		missingURIs := []string{"/removed/dataset"}
+		m := model.SearchPage{}
+
+		Convey("When addBrokenRelatedLinks is called in web mode", func() {
+			cfg.IsPublishing = false
+			addBrokenRelatedLinks(cfg, "/foo/bar", missingURIs, &m)
+
+			Convey("Then the broken links are not added to the page", func() {
+				So(m.Data.BrokenRelatedLinks, ShouldBeNil)
+			})
+		})
+
+		Convey("When addBrokenRelatedLinks is called in publishing mode", func() {
+			cfg.IsPublishing = true
+			addBrokenRelatedLinks(cfg, "/foo/bar", missingURIs, &m)
+
+			Convey("Then the broken links and the report are added to the page", func() {
+				So(m.Data.BrokenRelatedLinks, ShouldResemble, &model.BrokenRelatedLinks{
+					Count:     1,
+					URIs:      missingURIs,
+					ReportURL: "/foo/bar/relateddata/report",
+				})
+			})
+		})
+	})
+}
+
+func TestUnitRelatedDataReport(t *testing.T) {
+	mockZebedeePageContent, err := mapper.GetMockZebedeePageDataResponse()
+	if err != nil {
+		t.Errorf("failed to retrieve mock zebedee page data content for unit tests, failing early: %v", err)
+	}
+
+	Convey("Given a release with related data which is no longer returned by the search api", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.PageData, error) {
+				return mockZebedeePageContent, nil
+			},
+		}
+
+		mockedSearchClient := &SearchClientMock{
+			PostSearchURIsFunc: func(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+				return &searchModels.SearchResponse{
+					Count: 1,
+					Items: []searchModels.Item{{URI: mockZebedeePageContent.RelatedData[0].URI}},
+				}, nil
+			},
+		}
+
+		mockSearchHandler := NewSearchHandler(&RenderClientMock{}, mockedSearchClient, &TopicClientMock{}, mockedZebedeeClient, cfg, cache.List{})
+
+		Convey("When the related data report is requested", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/relateddata/report", http.NoBody)
+			w := doTestRequest("/{uri:.*}/relateddata/report", req, mockSearchHandler.RelatedDataReport(), nil)
+
+			Convey("Then the related data which could not be found is reported", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+				So(mockedZebedeeClient.GetPageDataCalls()[0].Path, ShouldEqual, "/foo/bar")
+
+				var report RelatedDataReport
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+				So(report, ShouldResemble, RelatedDataReport{
+					ParentURI:        "/foo/bar",
+					Title:            mockZebedeePageContent.Description.Title,
+					RelatedDataCount: 2,
+					MissingCount:     1,
+					MissingURIs:      []string{mockZebedeePageContent.RelatedData[1].URI},
+				})
+			})
+		})
+	})
+
+	Convey("Given a page which does not have related data", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		mockedZebedeeClient := &ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedeeC.PageData, error) {
+				return zebedeeC.PageData{Type: "timeseries"}, nil
+			},
+		}
+
+		mockSearchHandler := NewSearchHandler(&RenderClientMock{}, &SearchClientMock{}, &TopicClientMock{}, mockedZebedeeClient, cfg, cache.List{})
+
+		Convey("When the related data report is requested", func() {
+			req := httptest.NewRequest("GET", "/foo/bar/relateddata/report", http.NoBody)
+			w := doTestRequest("/{uri:.*}/relateddata/report", req, mockSearchHandler.RelatedDataReport(), nil)
+
+			Convey("Then a 404 not found status should be returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
 			})
 		})
 	})
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	// ReleaseCalendarTemplate is the template name of the release calendar
+	ReleaseCalendarTemplate = "release-calendar"
+
+	// releaseCalendarPageSize is the number of releases requested at a time when filtering them by topic, the most the
+	// search api returns
+	releaseCalendarPageSize = 100
+)
+
+// ReleaseCalendar handler
+func (sh *SearchHandler) ReleaseCalendar(cfg *config.Config) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		ctx := req.Context()
+
+		dataTopicCache, err := sh.CacheList.DataTopic.GetData(ctx, cache.DataTopicCacheKey)
+		if err != nil {
+			log.Error(ctx, "failed to get data topic cache for release calendar", err)
+			setStatusCode(w, req, err)
+			return
+		}
+
+		releaseCalendarConfig := NewReleaseCalendarConfig(dataTopicCache)
+
+		sh.handleReadRequest(w, req, cfg, accessToken, collectionID, lang, releaseCalendarConfig)
+	})
+}
+
+// NewReleaseCalendarConfig creates a new instance of AggregationConfig for the release calendar which can be filtered by the data topics
+func NewReleaseCalendarConfig(dataTopicCache *cache.Topic) AggregationConfig {
+	createPageModel := func(rc *RequestContext) model.SearchPage {
+		return mapper.CreateReleaseCalendarPage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.QueryParams, data.GetReleaseCalendarTopics(dataTopicCache), rc.SearchResp, rc.Lang, rc.HomepageResp, "", rc.NavigationCache, ReleaseCalendarTemplate, rc.ValidationErrs)
+	}
+	validateParams := func(ctx context.Context, cfg *config.Config, urlQuery url.Values, _ string, _ *cache.Topic) (data.SearchURLParams, []core.ErrorItem) {
+		return data.ReviewReleaseCalendarQuery(ctx, cfg, urlQuery, dataTopicCache)
+	}
+	getSearchAndCategoriesCountQueries := func(validatedQueryParams data.SearchURLParams, _ *cache.Topic, _, _ string) (searchQuery, categoriesCountQuery url.Values) {
+		return data.GetReleaseCalendarQuery(validatedQueryParams), nil
+	}
+
+	return AggregationConfig{
+		TemplateName:                       ReleaseCalendarTemplate,
+		UseTopicsPath:                      false,
+		ValidateParams:                     validateParams,
+		GetSearchAndCategoriesCountQueries: getSearchAndCategoriesCountQueries,
+		CreatePageModel:                    createPageModel,
+		Stages: func(p Pipeline) Pipeline {
+			return p.Replace(StageFetch, fetchReleaseCalendarStage(dataTopicCache)).
+				Replace(StageICalendar, icalendarReleaseCalendarStage(dataTopicCache)).
+				Skip(StageCount, StageReleaseCutoff)
+		},
+		EnableICalendar: true,
+	}
+}
+
+// fetchReleaseCalendarStage returns the stage which gets the releases of the page. The release calendar endpoint of the
+// search api cannot filter by topic, so when topics are chosen the releases up to the configured maximum are got and
+// only those of the topics are shown
+func fetchReleaseCalendarStage(dataTopicCache *cache.Topic) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		topicIDs := data.GetReleaseCalendarTopicIDs(rc.QueryParams.TopicFilter, dataTopicCache)
+		if topicIDs == nil {
+			return fetchWithStage(getReleaseCalendarEntries)(rc)
+		}
+		return fetchWithStage(getReleaseCalendarEntriesOfTopics(topicIDs, rc.Cfg.ReleaseCalendarTopicMaxResults))(rc)
+	}
+}
+
+// icalendarReleaseCalendarStage returns the stage which exports the releases, only those of the topics chosen if any
+func icalendarReleaseCalendarStage(dataTopicCache *cache.Topic) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		topicIDs := data.GetReleaseCalendarTopicIDs(rc.QueryParams.TopicFilter, dataTopicCache)
+		if topicIDs == nil {
+			return icalendarWithStage(getReleaseCalendarEntries)(rc)
+		}
+		return icalendarWithStage(func(ctx context.Context, searchC SearchClient, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+			searchResp, err := getReleaseCalendarEntries(ctx, searchC, options)
+			if err != nil {
+				return nil, err
+			}
+			searchResp.Items = filterReleasesByTopic(searchResp.Items, topicIDs)
+			return searchResp, nil
+		})(rc)
+	}
+}
+
+// getReleaseCalendarEntriesOfTopics returns the search which gets the releases up to the maximum a page at a time and
+// returns the page requested of those whose canonical topic is one of the topics, counting only them
+func getReleaseCalendarEntriesOfTopics(topicIDs map[string]bool, maxResults int) func(context.Context, SearchClient, searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	return func(ctx context.Context, searchC SearchClient, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+		limit, _ := strconv.Atoi(options.Query.Get("limit"))
+		offset, _ := strconv.Atoi(options.Query.Get("offset"))
+
+		topicsResp := &searchModels.SearchResponse{Items: []searchModels.Item{}}
+		for pageOffset := 0; pageOffset < maxResults; pageOffset += releaseCalendarPageSize {
+			pageOptions := options
+			pageOptions.Query = maps.Clone(options.Query)
+			if pageOptions.Query == nil {
+				pageOptions.Query = url.Values{}
+			}
+			pageOptions.Query.Set("limit", strconv.Itoa(min(releaseCalendarPageSize, maxResults-pageOffset)))
+			pageOptions.Query.Set("offset", strconv.Itoa(pageOffset))
+
+			searchResp, err := getReleaseCalendarEntries(ctx, searchC, pageOptions)
+			if err != nil {
+				return nil, err
+			}
+
+			topicsResp.Took += searchResp.Took
+			topicsResp.Items = append(topicsResp.Items, filterReleasesByTopic(searchResp.Items, topicIDs)...)
+
+			if len(searchResp.Items) == 0 || pageOffset+len(searchResp.Items) >= searchResp.Count {
+				break
+			}
+		}
+
+		topicsResp.Count = len(topicsResp.Items)
+		topicsResp.Items = topicsResp.Items[min(offset, topicsResp.Count):min(offset+limit, topicsResp.Count)]
+
+		return topicsResp, nil
+	}
+}
+
+// filterReleasesByTopic returns the releases whose canonical topic is one of the topics
+func filterReleasesByTopic(items []searchModels.Item, topicIDs map[string]bool) []searchModels.Item {
+	filtered := make([]searchModels.Item, 0, len(items))
+	for i := range items {
+		if topicIDs[items[i].CanonicalTopic] {
+			filtered = append(filtered, items[i])
+		}
+	}
+	return filtered
+}
+
+// getReleaseCalendarEntries gets the releases from the release calendar endpoint of the search api as a search response
+func getReleaseCalendarEntries(ctx context.Context, searchC SearchClient, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	releaseResp, err := searchC.GetReleaseCalendarEntries(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReleaseCalendarEntries(releaseResp), nil
+}
+
+// mapReleaseCalendarEntries maps the releases to search results so they can be shown by the aggregation templates
+func mapReleaseCalendarEntries(releaseResp *searchTransformer.SearchReleaseResponse) *searchModels.SearchResponse {
+	searchResp := &searchModels.SearchResponse{}
+	if releaseResp == nil {
+		return searchResp
+	}
+
+	searchResp.Count = releaseResp.Breakdown.Total
+	searchResp.Took = releaseResp.Took
+	searchResp.Items = make([]searchModels.Item, 0, len(releaseResp.Releases))
+
+	for i := range releaseResp.Releases {
+		release := &releaseResp.Releases[i]
+
+		dateChanges := make([]searchModels.ReleaseDateChange, 0, len(release.DateChanges))
+		for _, dateChange := range release.DateChanges {
+			dateChanges = append(dateChanges, searchModels.ReleaseDateChange{
+				ChangeNotice: dateChange.ChangeNotice,
+				Date:         dateChange.Date,
+			})
+		}
+
+		searchResp.Items = append(searchResp.Items, searchModels.Item{
+			URI:             release.URI,
+			DataType:        "release",
+			Title:           release.Description.Title,
+			Summary:         release.Description.Summary,
+			ReleaseDate:     release.Description.ReleaseDate,
+			Cancelled:       release.Description.Cancelled,
+			Finalised:       release.Description.Finalised,
+			Published:       release.Description.Published,
+			ProvisionalDate: release.Description.ProvisionalDate,
+			DateChanges:     dateChanges,
+			Keywords:        release.Description.Keywords,
+			Language:        release.Description.Language,
+			CanonicalTopic:  release.Description.CanonicalTopic,
+		})
+	}
+
+	return searchResp
+}
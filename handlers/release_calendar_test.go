@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitGetReleaseCalendarEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given the release calendar endpoint returns releases", t, func() {
+		mockedSearchClient := &SearchClientMock{
+			GetReleaseCalendarEntriesFunc: func(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+				return &searchTransformer.SearchReleaseResponse{
+					Breakdown: searchTransformer.Breakdown{Total: 25},
+					Releases: []searchTransformer.Release{
+						{
+							URI: "/releases/consumerpriceinflation",
+							DateChanges: []searchTransformer.ReleaseDateChange{
+								{ChangeNotice: "delayed", Date: "2023-01-10T07:00:00.000Z"},
+							},
+							Description: searchTransformer.ReleaseDescription{
+								Title:       "Consumer price inflation",
+								Summary:     "Price indices",
+								ReleaseDate: "2023-01-17T07:00:00.000Z",
+								Finalised:   true,
+							},
+						},
+					},
+				}, nil
+			},
+		}
+
+		Convey("When getReleaseCalendarEntries is called", func() {
+			searchResp, err := getReleaseCalendarEntries(ctx, mockedSearchClient, searchSDK.Options{})
+
+			Convey("Then the releases are returned as search results", func() {
+				So(err, ShouldBeNil)
+				So(searchResp.Count, ShouldEqual, 25)
+				So(searchResp.Items, ShouldResemble, []searchModels.Item{
+					{
+						URI:         "/releases/consumerpriceinflation",
+						DataType:    "release",
+						Title:       "Consumer price inflation",
+						Summary:     "Price indices",
+						ReleaseDate: "2023-01-17T07:00:00.000Z",
+						Finalised:   true,
+						DateChanges: []searchModels.ReleaseDateChange{
+							{ChangeNotice: "delayed", Date: "2023-01-10T07:00:00.000Z"},
+						},
+					},
+				})
+			})
+		})
+	})
+}
+
+func TestUnitGetReleaseCalendarEntriesOfTopics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given the release calendar endpoint returns 250 releases, one in five of which are of the economy topic", t, func() {
+		mockedSearchClient := &SearchClientMock{
+			GetReleaseCalendarEntriesFunc: func(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+				limit, _ := strconv.Atoi(options.Query.Get("limit"))
+				offset, _ := strconv.Atoi(options.Query.Get("offset"))
+
+				releaseResp := &searchTransformer.SearchReleaseResponse{Breakdown: searchTransformer.Breakdown{Total: 250}}
+				for i := offset; i < min(offset+limit, 250); i++ {
+					topic := "9999"
+					if i%5 == 0 {
+						topic = "1834"
+					}
+					releaseResp.Releases = append(releaseResp.Releases, searchTransformer.Release{
+						URI:         "/releases/" + strconv.Itoa(i),
+						Description: searchTransformer.ReleaseDescription{CanonicalTopic: topic},
+					})
+				}
+				return releaseResp, nil
+			},
+		}
+
+		Convey("When the second page of releases of the economy topic and the topics beneath it is requested", func() {
+			search := getReleaseCalendarEntriesOfTopics(map[string]bool{"6734": true, "1834": true}, 500)
+			searchResp, err := search(ctx, mockedSearchClient, searchSDK.Options{Query: url.Values{"limit": {"10"}, "offset": {"10"}}})
+
+			Convey("Then every release is searched, a page at a time", func() {
+				So(err, ShouldBeNil)
+				So(mockedSearchClient.GetReleaseCalendarEntriesCalls(), ShouldHaveLength, 3)
+				So(mockedSearchClient.GetReleaseCalendarEntriesCalls()[2].Options.Query.Get("offset"), ShouldEqual, "200")
+			})
+
+			Convey("Then only the releases of the topics are counted and the page requested of them is returned", func() {
+				So(searchResp.Count, ShouldEqual, 50)
+				So(searchResp.Items, ShouldHaveLength, 10)
+				So(searchResp.Items[0].URI, ShouldEqual, "/releases/50")
+				So(searchResp.Items[9].URI, ShouldEqual, "/releases/95")
+			})
+		})
+
+		Convey("When fewer releases than there are may be searched", func() {
+			search := getReleaseCalendarEntriesOfTopics(map[string]bool{"1834": true}, 150)
+			searchResp, err := search(ctx, mockedSearchClient, searchSDK.Options{Query: url.Values{"limit": {"10"}, "offset": {"0"}}})
+
+			Convey("Then no more than the maximum are searched", func() {
+				So(err, ShouldBeNil)
+				So(mockedSearchClient.GetReleaseCalendarEntriesCalls(), ShouldHaveLength, 2)
+				So(mockedSearchClient.GetReleaseCalendarEntriesCalls()[1].Options.Query.Get("limit"), ShouldEqual, "50")
+				So(searchResp.Count, ShouldEqual, 30)
+			})
+		})
+	})
+}
+
+func TestUnitGetICalendarResults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a search with more results than fit on a page", t, func() {
+		const total = 250
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+				limit, _ := strconv.Atoi(options.Query.Get("limit"))
+				offset, _ := strconv.Atoi(options.Query.Get("offset"))
+
+				searchResp := &searchModels.SearchResponse{Count: total}
+				for i := offset; i < min(offset+limit, total); i++ {
+					searchResp.Items = append(searchResp.Items, searchModels.Item{URI: "/releases/" + strconv.Itoa(i)})
+				}
+				return searchResp, nil
+			},
+		}
+		options := searchSDK.Options{Query: url.Values{"q": {"gdp"}, "limit": {"10"}, "offset": {"20"}}}
+
+		Convey("When the results are got for the icalendar feed", func() {
+			searchResp, err := getICalendarResults(ctx, mockedSearchClient, options, getSearch, time.Second, 1000)
+
+			Convey("Then every result is got a page at a time", func() {
+				So(err, ShouldBeNil)
+				So(searchResp.Count, ShouldEqual, total)
+				So(searchResp.Items, ShouldHaveLength, total)
+				So(searchResp.Items[0].URI, ShouldEqual, "/releases/0")
+				So(searchResp.Items[total-1].URI, ShouldEqual, "/releases/249")
+
+				calls := mockedSearchClient.GetSearchCalls()
+				So(calls, ShouldHaveLength, 3)
+				for i, call := range calls {
+					So(call.Options.Query.Get("q"), ShouldEqual, "gdp")
+					So(call.Options.Query.Get("limit"), ShouldEqual, "100")
+					So(call.Options.Query.Get("offset"), ShouldEqual, strconv.Itoa(i*100))
+				}
+			})
+
+			Convey("And the query of the page requested is left unchanged", func() {
+				So(options.Query.Get("limit"), ShouldEqual, "10")
+				So(options.Query.Get("offset"), ShouldEqual, "20")
+			})
+		})
+
+		Convey("When the results are got for the icalendar feed with a lower export maximum", func() {
+			searchResp, err := getICalendarResults(ctx, mockedSearchClient, options, getSearch, time.Second, 150)
+
+			Convey("Then the results are got up to the maximum", func() {
+				So(err, ShouldBeNil)
+				So(searchResp.Items, ShouldHaveLength, 150)
+
+				calls := mockedSearchClient.GetSearchCalls()
+				So(calls, ShouldHaveLength, 2)
+				So(calls[1].Options.Query.Get("limit"), ShouldEqual, "50")
+				So(calls[1].Options.Query.Get("offset"), ShouldEqual, "100")
+			})
+		})
+	})
+
+	Convey("Given the search fails", t, func() {
+		mockedSearchClient := &SearchClientMock{
+			GetSearchFunc: func(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+				return nil, searchError.StatusError{Code: http.StatusInternalServerError, Err: errors.New("internal server error")}
+			},
+		}
+
+		Convey("When the results are got for the icalendar feed", func() {
+			searchResp, err := getICalendarResults(ctx, mockedSearchClient, searchSDK.Options{}, getSearch, time.Second, 1000)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(searchResp, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUnitCreateICalendarFeed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2023, time.January, 3, 12, 0, 0, 0, time.UTC)
+
+	Convey("Given a search response with releases", t, func() {
+		searchResp := &searchModels.SearchResponse{
+			Items: []searchModels.Item{
+				{URI: "/releases/cpi", Title: "Consumer prices, January 2023", Summary: "Prices; rents", ReleaseDate: "2023-01-17T07:00:00.000Z", Finalised: true},
+				{URI: "/releases/gdp", Title: "GDP", ReleaseDate: "2023-01-20T09:30:00.000Z", Cancelled: true},
+				{URI: "/releases/invalid", Title: "Invalid", ReleaseDate: "unknown"},
+			},
+		}
+
+		Convey("When the icalendar feed is created", func() {
+			w := httptest.NewRecorder()
+			err := createICalendarFeed(ctx, w, searchResp, ReleaseCalendarTemplate, now)
+			body := w.Body.String()
+
+			Convey("Then a calendar is written with an event for each release with a valid date", func() {
+				So(err, ShouldBeNil)
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/calendar; charset=utf-8")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="releasecalendar.ics"`)
+				So(body, ShouldStartWith, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+				So(body, ShouldEndWith, "END:VCALENDAR\r\n")
+				So(strings.Count(body, "BEGIN:VEVENT"), ShouldEqual, 2)
+				So(body, ShouldNotContainSubstring, "/releases/invalid")
+			})
+
+			Convey("And each event has the release details", func() {
+				So(body, ShouldContainSubstring, "UID:"+getICSUID("/releases/cpi")+"\r\n")
+				So(body, ShouldContainSubstring, "DTSTAMP:20230103T120000Z\r\n")
+				So(body, ShouldContainSubstring, "DTSTART:20230117T070000Z\r\n")
+				So(body, ShouldContainSubstring, `SUMMARY:Consumer prices\, January 2023`)
+				So(body, ShouldContainSubstring, `DESCRIPTION:Prices\; rents`)
+				So(body, ShouldContainSubstring, "URL:https://www.ons.gov.uk/releases/cpi\r\n")
+				So(body, ShouldContainSubstring, "STATUS:CONFIRMED\r\n")
+				So(body, ShouldContainSubstring, "STATUS:CANCELLED\r\n")
+			})
+		})
+	})
+
+	Convey("Given the same release uri", t, func() {
+		Convey("When the uid is created", func() {
+			Convey("Then it is the same each time", func() {
+				So(getICSUID("/releases/cpi"), ShouldEqual, getICSUID("/releases/cpi"))
+				So(getICSUID("/releases/cpi"), ShouldNotEqual, getICSUID("/releases/gdp"))
+				So(getICSUID("/releases/cpi"), ShouldEndWith, "@ons.gov.uk")
+			})
+		})
+	})
+
+	Convey("Given a content line longer than 75 octets", t, func() {
+		line := "SUMMARY:" + strings.Repeat("é", 60)
+
+		Convey("When the line is written", func() {
+			var b strings.Builder
+			writeICSLine(&b, line)
+
+			Convey("Then it is folded without splitting characters", func() {
+				lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+				So(len(lines), ShouldBeGreaterThan, 1)
+				for _, l := range lines {
+					So(len(l), ShouldBeLessThanOrEqualTo, 75)
+				}
+				So(strings.ReplaceAll(b.String(), "\r\n ", ""), ShouldEqual, line+"\r\n")
+			})
+		})
+	})
+}
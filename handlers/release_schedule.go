@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// isScheduled returns true if the release date is in the future
+func isScheduled(releaseDate *time.Time, now time.Time) bool {
+	return releaseDate != nil && releaseDate.After(now)
+}
+
+// parseReleaseDate parses the release date of a search result, returning nil if it is empty or invalid
+func parseReleaseDate(releaseDate string) *time.Time {
+	if releaseDate == "" {
+		return nil
+	}
+
+	date, err := time.Parse(time.RFC3339, releaseDate)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
+
+// newScheduledRelease returns the scheduled release for the release date, including the time remaining until it is released
+func newScheduledRelease(releaseDate time.Time, now time.Time) *model.ScheduledRelease {
+	remaining := releaseDate.Sub(now)
+
+	return &model.ScheduledRelease{
+		ReleaseDate: releaseDate.UTC().Format(time.RFC3339),
+		Days:        int(remaining / (24 * time.Hour)),
+		Hours:       int(remaining % (24 * time.Hour) / time.Hour),
+		Minutes:     int(remaining % time.Hour / time.Minute),
+	}
+}
+
+// applyReleaseCutoff limits the search query to content released by now in web mode, so that the results, their count
+// and the counts of the filters all leave out content scheduled to be released in the future. The search api filters
+// release dates by day, so the date to search before is set to today unless an earlier date has been chosen
+func applyReleaseCutoff(cfg *config.Config, now time.Time, query url.Values) {
+	if cfg.IsPublishing || query == nil {
+		return
+	}
+
+	today := now.UTC().Format(data.DateFormat)
+	if toDate := query.Get("toDate"); toDate == "" || toDate > today {
+		query.Set("toDate", today)
+	}
+}
+
+// isTopicHidden returns true if the topic is scheduled to be released in the future and should not be shown in web mode
+func isTopicHidden(cfg *config.Config, topic cache.Topic, now time.Time) bool {
+	return !cfg.IsPublishing && isScheduled(topic.ReleaseDate, now)
+}
+
+// applyReleaseSchedule badges the results and topic filters which are scheduled to be released in the future with the
+// date they are scheduled for and the time remaining until then in publishing mode. The topic filters are removed in web
+// mode, where the results have already been left out of the search by its release cutoff
+func applyReleaseSchedule(ctx context.Context, cfg *config.Config, now time.Time, cacheList cache.List, selectedTopic *cache.Topic, m *model.SearchPage) {
+	if cfg.IsPublishing {
+		if selectedTopic != nil && isScheduled(selectedTopic.ReleaseDate, now) {
+			m.Data.TopicScheduledRelease = newScheduledRelease(*selectedTopic.ReleaseDate, now)
+		}
+
+		for i := range m.Data.Response.Items {
+			item := &m.Data.Response.Items[i]
+			if releaseDate := parseReleaseDate(item.Description.ReleaseDate); isScheduled(releaseDate, now) {
+				item.ScheduledRelease = newScheduledRelease(*releaseDate, now)
+			}
+		}
+	}
+
+	if len(m.Data.TopicFilters) == 0 && len(m.Data.CensusFilters) == 0 {
+		return
+	}
+
+	if cacheList.CensusTopic == nil {
+		return
+	}
+
+	censusTopic, err := cacheList.CensusTopic.GetCensusData(ctx)
+	if err != nil {
+		log.Warn(ctx, "failed to get census topic cache to check scheduled topics", log.FormatErrors([]error{err}))
+		return
+	}
+
+	for i := range m.Data.TopicFilters {
+		m.Data.TopicFilters[i].Types = applyTopicFiltersReleaseSchedule(cfg, now, censusTopic, m.Data.TopicFilters[i].Types)
+	}
+	m.Data.CensusFilters = applyTopicFiltersReleaseSchedule(cfg, now, censusTopic, m.Data.CensusFilters)
+}
+
+// applyTopicFiltersReleaseSchedule removes or badges the topic filters for the topics which are scheduled to be released in the future
+func applyTopicFiltersReleaseSchedule(cfg *config.Config, now time.Time, censusTopic *cache.Topic, topicFilters []model.TopicFilter) []model.TopicFilter {
+	if censusTopic == nil || censusTopic.List == nil || topicFilters == nil {
+		return topicFilters
+	}
+
+	filters := make([]model.TopicFilter, 0, len(topicFilters))
+	for i := range topicFilters {
+		filter := topicFilters[i]
+
+		subtopic, exists := censusTopic.List.Get(filter.Query)
+		if !exists || !isScheduled(subtopic.ReleaseDate, now) {
+			filters = append(filters, filter)
+			continue
+		}
+
+		if !cfg.IsPublishing {
+			continue
+		}
+
+		filter.ScheduledRelease = newScheduledRelease(*subtopic.ReleaseDate, now)
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
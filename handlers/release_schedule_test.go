@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/clock"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitApplyReleaseSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// the mock census topic "Age" (5678) is released on 2022-11-09T09:30:00Z
+	clk := clock.Fixed{Time: time.Date(2022, time.November, 7, 7, 15, 0, 0, time.UTC)}
+
+	mockCacheList, err := cache.GetMockCacheList(ctx, englishLang)
+	if err != nil {
+		t.Errorf("failed to get mock cache list")
+	}
+
+	newPage := func() *model.SearchPage {
+		return &model.SearchPage{
+			Data: model.Search{
+				Response: model.Response{
+					Count: 3,
+					Items: []model.ContentItem{
+						{URI: "/released", Description: model.Description{ReleaseDate: "2022-11-01T07:00:00.000Z"}},
+						{URI: "/scheduled", Description: model.Description{ReleaseDate: "2022-11-08T09:30:00.000Z"}},
+						{URI: "/no-release-date"},
+					},
+				},
+				CensusFilters: []model.TopicFilter{
+					{Query: "1234", LocaliseKeyName: "International Migration"},
+					{Query: "5678", LocaliseKeyName: "Age"},
+				},
+				TopicFilters: []model.TopicFilter{
+					{
+						Query: cache.CensusTopicID,
+						Types: []model.TopicFilter{
+							{Query: "1234", LocaliseKeyName: "International Migration"},
+							{Query: "5678", LocaliseKeyName: "Age"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Convey("Given web mode", t, func() {
+		cfg := &config.Config{IsPublishing: false}
+
+		Convey("When the release schedule is applied to the page", func() {
+			m := newPage()
+			applyReleaseSchedule(ctx, cfg, clk.Now(), *mockCacheList, nil, m)
+
+			Convey("Then the results and their count are left as they were searched", func() {
+				So(m.Data.Response.Items, ShouldHaveLength, 3)
+				So(m.Data.Response.Count, ShouldEqual, 3)
+				So(m.Data.Response.Items[1].ScheduledRelease, ShouldBeNil)
+			})
+
+			Convey("And the topic filters scheduled in the future are removed", func() {
+				So(m.Data.CensusFilters, ShouldHaveLength, 1)
+				So(m.Data.CensusFilters[0].Query, ShouldEqual, "1234")
+				So(m.Data.TopicFilters[0].Types, ShouldHaveLength, 1)
+				So(m.Data.TopicFilters[0].Types[0].Query, ShouldEqual, "1234")
+			})
+		})
+
+		Convey("When the selected topic is scheduled in the future", func() {
+			topic := cache.Topic{ID: "5678", ReleaseDate: timePtr(time.Date(2022, time.November, 9, 9, 30, 0, 0, time.UTC))}
+
+			Convey("Then the topic is hidden", func() {
+				So(isTopicHidden(cfg, topic, clk.Now()), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the selected topic has been released", func() {
+			topic := cache.Topic{ID: "1234", ReleaseDate: timePtr(time.Date(2022, time.October, 10, 8, 30, 0, 0, time.UTC))}
+
+			Convey("Then the topic is not hidden", func() {
+				So(isTopicHidden(cfg, topic, clk.Now()), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given publishing mode", t, func() {
+		cfg := &config.Config{IsPublishing: true}
+
+		Convey("When the release schedule is applied to the page", func() {
+			m := newPage()
+			topic := &cache.Topic{ID: "5678", ReleaseDate: timePtr(time.Date(2022, time.November, 9, 9, 30, 0, 0, time.UTC))}
+			applyReleaseSchedule(ctx, cfg, clk.Now(), *mockCacheList, topic, m)
+
+			Convey("Then the results scheduled in the future are badged with a countdown", func() {
+				So(m.Data.Response.Items, ShouldHaveLength, 3)
+				So(m.Data.Response.Count, ShouldEqual, 3)
+				So(m.Data.Response.Items[0].ScheduledRelease, ShouldBeNil)
+				So(m.Data.Response.Items[1].ScheduledRelease, ShouldResemble, &model.ScheduledRelease{
+					ReleaseDate: "2022-11-08T09:30:00Z",
+					Days:        1,
+					Hours:       2,
+					Minutes:     15,
+				})
+				So(m.Data.Response.Items[2].ScheduledRelease, ShouldBeNil)
+			})
+
+			Convey("And the topic filters scheduled in the future are badged", func() {
+				So(m.Data.CensusFilters, ShouldHaveLength, 2)
+				So(m.Data.CensusFilters[0].ScheduledRelease, ShouldBeNil)
+				So(m.Data.CensusFilters[1].ScheduledRelease, ShouldNotBeNil)
+				So(m.Data.CensusFilters[1].ScheduledRelease.Days, ShouldEqual, 2)
+				So(m.Data.TopicFilters[0].Types[1].ScheduledRelease, ShouldNotBeNil)
+			})
+
+			Convey("And the selected topic is badged", func() {
+				So(m.Data.TopicScheduledRelease, ShouldResemble, &model.ScheduledRelease{
+					ReleaseDate: "2022-11-09T09:30:00Z",
+					Days:        2,
+					Hours:       2,
+					Minutes:     15,
+				})
+			})
+		})
+
+		Convey("When the selected topic is scheduled in the future", func() {
+			topic := cache.Topic{ID: "5678", ReleaseDate: timePtr(time.Date(2022, time.November, 9, 9, 30, 0, 0, time.UTC))}
+
+			Convey("Then the topic is not hidden", func() {
+				So(isTopicHidden(cfg, topic, clk.Now()), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestUnitApplyReleaseCutoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.November, 7, 7, 15, 0, 0, time.UTC)
+
+	Convey("Given web mode", t, func() {
+		cfg := &config.Config{IsPublishing: false}
+
+		Convey("When the cutoff is applied to a search without a date to search before", func() {
+			query := url.Values{"q": {"census"}, "toDate": {""}}
+			applyReleaseCutoff(cfg, now, query)
+
+			Convey("Then the search is limited to content released by today", func() {
+				So(query.Get("toDate"), ShouldEqual, "2022-11-07")
+			})
+		})
+
+		Convey("When the cutoff is applied to a search before a date in the future", func() {
+			query := url.Values{"toDate": {"2023-01-01"}}
+			applyReleaseCutoff(cfg, now, query)
+
+			Convey("Then the search is limited to content released by today", func() {
+				So(query.Get("toDate"), ShouldEqual, "2022-11-07")
+			})
+		})
+
+		Convey("When the cutoff is applied to a search before a date in the past", func() {
+			query := url.Values{"toDate": {"2022-01-01"}}
+			applyReleaseCutoff(cfg, now, query)
+
+			Convey("Then the date chosen is kept", func() {
+				So(query.Get("toDate"), ShouldEqual, "2022-01-01")
+			})
+		})
+	})
+
+	Convey("Given publishing mode", t, func() {
+		cfg := &config.Config{IsPublishing: true}
+
+		Convey("When the cutoff is applied to a search", func() {
+			query := url.Values{"toDate": {""}}
+			applyReleaseCutoff(cfg, now, query)
+
+			Convey("Then content scheduled in the future is still searched", func() {
+				So(query.Get("toDate"), ShouldEqual, "")
+			})
+		})
+	})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -6,29 +6,25 @@ import (
 	"net/url"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
-	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
-	searchModels "github.com/ONSdigital/dp-search-api/models"
-	"github.com/ONSdigital/dp-topic-api/models"
 )
 
 // Search handler
 func (sh *SearchHandler) Search(cfg *config.Config) http.HandlerFunc {
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
 		searchConfig := NewSearchConfig(cfg.EnableNLPSearch)
-		handleReadRequest(w, req, cfg, sh.ZebedeeClient, sh.Renderer, sh.SearchClient, accessToken, collectionID, lang, sh.CacheList, searchConfig)
+		sh.handleReadRequest(w, req, cfg, accessToken, collectionID, lang, searchConfig)
 	})
 }
 
 func NewSearchConfig(nlpWeightingEnabled bool) AggregationConfig {
-	createPageModel := func(cfg *config.Config, req *http.Request, base core.Page, queryParams data.SearchURLParams, categories []data.Category, topics []data.Topic, searchResp *searchModels.SearchResponse, lang string, homepageResp zebedeeCli.HomepageContent, errorMessage string, navigationCache *models.Navigation,
-		template string, topic cache.Topic, validationErrs []core.ErrorItem, pageData zebedeeCli.PageData, _ []zebedeeCli.Breadcrumb) model.SearchPage {
-		return mapper.CreateSearchPage(cfg, req, base, queryParams, categories, topics, searchResp, lang, homepageResp, errorMessage, navigationCache, validationErrs)
+	createPageModel := func(rc *RequestContext) model.SearchPage {
+		return mapper.CreateSearchPage(rc.Cfg, rc.Req, rc.Renderer.NewBasePageModel(), rc.QueryParams, rc.Categories, rc.TopicCategories, rc.SearchResp, rc.Lang, rc.HomepageResp, "", rc.NavigationCache, rc.ValidationErrs)
 	}
 	validateParams := func(ctx context.Context, cfg *config.Config, urlQuery url.Values, _ string, censusTopicCache *cache.Topic) (data.SearchURLParams, []core.ErrorItem) {
 		return data.ReviewQuery(ctx, cfg, urlQuery, censusTopicCache)
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	zebedeeCli "github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
+	"github.com/ONSdigital/dp-frontend-search-controller/apperrors"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	"github.com/ONSdigital/dp-frontend-search-controller/resilience"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resolveTopicStage selects the topic of the page, from its path or the census topic, and adds it to the query
+func resolveTopicStage(rc *RequestContext) error {
+	selectedTopic, err := selectTopic(rc.Ctx, rc.Req, rc.CacheList, rc.AggCfg)
+	if err != nil {
+		return err
+	}
+
+	if rc.AggCfg.UseTopicsPath && isTopicHidden(rc.Cfg, selectedTopic, rc.Now) {
+		log.Info(rc.Ctx, "topic is scheduled to be released in the future", log.Data{"topic_id": selectedTopic.ID})
+		return apperrors.ErrTopicPathNotFound
+	}
+
+	rc.SelectedTopic = selectedTopic
+	rc.ClearTopics = prepareQueryParams(rc.Req, &rc.AggCfg, selectedTopic)
+
+	return nil
+}
+
+// resolvePageStage returns a stage which gets the page the list belongs to from zebedee, which must have previous
+// releases or related data
+func resolvePageStage(pagePath func(rc *RequestContext) string) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		pageData, err := getRelatedListPage(rc.Ctx, rc.W, rc.Zebedee, rc.AccessToken, rc.CollectionID, rc.Lang, pagePath(rc))
+		if err != nil {
+			return err
+		}
+
+		rc.PageData = pageData
+		return nil
+	}
+}
+
+// migrationRedirectStage returns a stage which redirects the request for the route to the migration link of the page
+// the list belongs to, if it has one
+func migrationRedirectStage(route string) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		if rc.Redirector != nil && rc.Redirector.Redirect(rc.W, rc.Req, route, rc.PageData.Description.MigrationLink) {
+			return errResponseWritten
+		}
+		return nil
+	}
+}
+
+// latestReleasePath is the path of the latest release of the page the list belongs to
+func latestReleasePath(rc *RequestContext) string {
+	return rc.URLPath + "/latest"
+}
+
+// parentPagePath is the path of the page the list belongs to
+func parentPagePath(rc *RequestContext) string {
+	return rc.URLPath
+}
+
+// fetchContentStage gets the navigation and the homepage content shown around the page in parallel. The page is
+// rendered without the homepage content if it cannot be got in time
+func fetchContentStage(rc *RequestContext) error {
+	fetchContent(rc, nil)
+	return nil
+}
+
+// fetchContentWithBreadcrumbsStage returns a stage which gets the breadcrumbs of the page the list belongs to as well
+// as the navigation and the homepage content. The page is rendered without the breadcrumbs if they cannot be got in time
+func fetchContentWithBreadcrumbsStage(pagePath func(rc *RequestContext) string) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		fetchContent(rc, pagePath)
+		return nil
+	}
+}
+
+func fetchContent(rc *RequestContext, breadcrumbPath func(rc *RequestContext) string) {
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	// Parallel fetching
+	go func() {
+		defer wg.Done()
+		// get cached navigation data
+		var err error
+		rc.NavigationCache, err = getNavigationCache(rc.Ctx, rc.CacheList, rc.Cfg.NavigationTimeout, rc.Lang)
+		rc.Degraded.Navigation = err != nil
+	}()
+	go func() {
+		defer wg.Done()
+		// get homepage content
+		var err error
+		rc.HomepageResp, err = getHomepageContent(rc.Ctx, rc.Zebedee, rc.Cfg.ZebedeeTimeout, rc.AccessToken, rc.CollectionID, rc.Lang)
+		rc.Degraded.EmergencyBanner = err != nil
+	}()
+
+	if breadcrumbPath != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			rc.Breadcrumbs, err = getBreadcrumb(rc.Ctx, rc.Zebedee, rc.Cfg.ZebedeeTimeout, rc.AccessToken, rc.CollectionID, rc.Lang, breadcrumbPath(rc))
+			rc.Degraded.Breadcrumbs = err != nil
+		}()
+	}
+	wg.Wait()
+}
+
+// validateParamsStage reviews the query parameters of the request and renders the page of the validation errors if any are invalid
+func validateParamsStage(rc *RequestContext) error {
+	rc.QueryParams, rc.ValidationErrs = rc.AggCfg.ValidateParams(rc.Ctx, rc.Cfg, rc.AggCfg.URLQueryParams, rc.URLPath, &rc.SelectedTopic)
+	if len(rc.ValidationErrs) > 0 {
+		return rc.renderErrorPage(rc.SelectedTopic, []zebedeeCli.Breadcrumb{})
+	}
+
+	if rc.AggCfg.NLPWeightingEnabled {
+		rc.QueryParams.NLPWeightingEnabled = rc.AggCfg.NLPWeightingEnabled
+		log.Info(rc.Ctx, "NLP Weighting for query", log.Data{
+			"nlp_weighting": rc.AggCfg.NLPWeightingEnabled,
+		})
+	}
+
+	return nil
+}
+
+// rssStage writes the search results as an RSS feed when the rss query parameter is given
+func rssStage(rc *RequestContext) error {
+	if _, rssParam := rc.AggCfg.URLQueryParams["rss"]; !rssParam {
+		return nil
+	}
+
+	rc.Req.Header.Set("Accept", "application/rss+xml")
+	err := createRSSFeed(rc.Ctx, rc.W, rc.Req, rc.CollectionID, rc.AccessToken, rc.Search, rc.QueryParams, rc.AggCfg.TemplateName)
+	metrics.RSSFeeds.WithLabelValues(rc.AggCfg.TemplateName, metrics.Outcome(err)).Inc()
+	if err != nil {
+		log.Error(rc.Ctx, "failed to create rss feed", err)
+		return err
+	}
+
+	return errResponseWritten
+}
+
+// queryStage creates the requests for the search results and the counts of their categories
+func queryStage(rc *RequestContext) error {
+	searchQuery, categoriesCountQuery := rc.AggCfg.GetSearchAndCategoriesCountQueries(rc.QueryParams, &rc.SelectedTopic, rc.AggCfg.TemplateName, rc.PageData.Type)
+
+	rc.SearchOptions.Query = searchQuery
+	rc.SearchOptions.Headers = http.Header{
+		searchSDK.CollectionID: {rc.CollectionID},
+	}
+	setAuthTokenHeader(rc.SearchOptions.Headers, rc.AccessToken)
+
+	rc.CategoriesCountQuery = categoriesCountQuery
+
+	return nil
+}
+
+// releaseCutoffStage leaves the results scheduled to be released in the future out of the search and its counts in web mode
+func releaseCutoffStage(rc *RequestContext) error {
+	applyReleaseCutoff(rc.Cfg, rc.Now, rc.SearchOptions.Query)
+	applyReleaseCutoff(rc.Cfg, rc.Now, rc.CategoriesCountQuery)
+
+	return nil
+}
+
+// fetchStage gets the search results from the search api. The request fails if they cannot be got in time, showing
+// the search unavailable page if the circuit breaker of the search api is open
+func fetchStage(rc *RequestContext) error {
+	return fetchWithStage(getSearch)(rc)
+}
+
+// getSearch gets the search results from the search endpoint of the search api
+func getSearch(ctx context.Context, searchC SearchClient, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	return searchC.GetSearch(ctx, options)
+}
+
+// fetchWithStage returns a stage which gets the search results with the search given, e.g. from another endpoint of the search api
+func fetchWithStage(search func(context.Context, SearchClient, searchSDK.Options) (*searchModels.SearchResponse, searchError.Error)) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		start := time.Now()
+		searchResp, err := withTimeout(rc.Ctx, rc.Cfg.SearchTimeout, func(ctx context.Context) (*searchModels.SearchResponse, error) {
+			searchResp, err := search(ctx, rc.Search, rc.SearchOptions)
+			if err != nil {
+				return nil, err
+			}
+			return searchResp, nil
+		})
+		if err != nil {
+			log.Error(rc.Ctx, "getting search response from client failed", err)
+			if resilience.IsUnavailable(err) {
+				return rc.renderUnavailablePage()
+			}
+			return err
+		}
+		rc.SearchLatency = time.Since(start)
+
+		if searchResp != nil {
+			rc.SearchResp = searchResp
+			rc.SearchCount = searchResp.Count
+		}
+		rc.recordSearch()
+
+		return nil
+	}
+}
+
+// fetchRelatedDataStage gets the related data of the page from the search api, which also counts its content types
+func fetchRelatedDataStage(rc *RequestContext) error {
+	uris := make([]string, 0, len(rc.PageData.RelatedData))
+	for _, related := range rc.PageData.RelatedData {
+		uris = append(uris, related.URI)
+	}
+
+	type relatedData struct {
+		searchResp  *searchModels.SearchResponse
+		categories  []data.Category
+		missingURIs []string
+		searchCount int
+	}
+
+	start := time.Now()
+	r, err := withTimeout(rc.Ctx, rc.Cfg.SearchTimeout, func(ctx context.Context) (relatedData, error) {
+		searchResp, categories, missingURIs, err, searchCount := getRelatedData(ctx, rc.Search, rc.SearchOptions, rc.cancel, rc.URLPath, uris, rc.QueryParams)
+		if err != nil {
+			return relatedData{}, err
+		}
+		return relatedData{searchResp: searchResp, categories: categories, missingURIs: missingURIs, searchCount: searchCount}, nil
+	})
+	if err != nil {
+		log.Error(rc.Ctx, "getting search response with uris from client failed", err)
+		if resilience.IsUnavailable(err) {
+			return rc.renderUnavailablePage()
+		}
+		return err
+	}
+	rc.SearchLatency = time.Since(start)
+
+	rc.SearchResp = r.searchResp
+	rc.Categories = r.categories
+	rc.MissingRelatedURIs = r.missingURIs
+	rc.SearchCount = r.searchCount
+	rc.recordSearch()
+
+	return nil
+}
+
+// recordSearch records the search made for the page, and whether it found any results
+func (rc *RequestContext) recordSearch() {
+	metrics.Searches.WithLabelValues(rc.AggCfg.TemplateName).Inc()
+	if rc.SearchCount == 0 {
+		metrics.ZeroResultSearches.WithLabelValues(rc.AggCfg.TemplateName).Inc()
+	}
+}
+
+// countStage gets the number of search results of each category and topic to show on the filters. The search results
+// are rendered without the counts if they cannot be got in time
+func countStage(rc *RequestContext) error {
+	type counts struct {
+		categories      []data.Category
+		topicCategories []data.Topic
+	}
+
+	// TO-DO: Need to make a second request until API can handle aggregation on datatypes (e.g. bulletins, article) to return counts
+	c, err := withTimeout(rc.Ctx, rc.Cfg.SearchCountTimeout, func(ctx context.Context) (counts, error) {
+		categories, topicCategories, err := getCategoriesTypesCount(ctx, rc.AccessToken, rc.CollectionID, rc.CategoriesCountQuery, rc.Search, &rc.SelectedTopic)
+		return counts{categories: categories, topicCategories: topicCategories}, err
+	})
+	if err != nil {
+		log.Warn(rc.Ctx, "getting categories, types and its counts failed, rendering without filter counts", log.FormatErrors([]error{err}))
+		trace.SpanFromContext(rc.Ctx).RecordError(err)
+		rc.Categories = data.GetCategories()
+		rc.TopicCategories = []data.Topic{}
+		rc.Degraded.FilterCounts = true
+		return nil
+	}
+
+	rc.Categories = c.categories
+	rc.TopicCategories = c.topicCategories
+
+	return nil
+}
+
+// icalendarStage writes the search results as an iCalendar feed when the ics query parameter is given and the page type allows it
+func icalendarStage(rc *RequestContext) error {
+	return icalendarWithStage(getSearch)(rc)
+}
+
+// icalendarWithStage returns a stage which writes the search results got with the search given as an iCalendar feed.
+// Every result up to the export maximum is written, rather than the page requested
+func icalendarWithStage(search func(context.Context, SearchClient, searchSDK.Options) (*searchModels.SearchResponse, searchError.Error)) func(rc *RequestContext) error {
+	return func(rc *RequestContext) error {
+		if _, icsParam := rc.AggCfg.URLQueryParams["ics"]; !icsParam || !rc.AggCfg.EnableICalendar {
+			return nil
+		}
+
+		searchResp, err := getICalendarResults(rc.Ctx, rc.Search, rc.SearchOptions, search, rc.Cfg.SearchTimeout, rc.Cfg.ICalendarExportMaxResults)
+		if err != nil {
+			log.Error(rc.Ctx, "getting search response for icalendar feed from client failed", err)
+			return err
+		}
+
+		if err := createICalendarFeed(rc.Ctx, rc.W, searchResp, rc.AggCfg.TemplateName, rc.Now); err != nil {
+			log.Error(rc.Ctx, "failed to create icalendar feed", err)
+			return err
+		}
+
+		return errResponseWritten
+	}
+}
+
+// mapStage maps the search results to the page model, or renders the page of the validation errors if the page
+// requested is beyond the search results
+func mapStage(rc *RequestContext) error {
+	if rc.ClearTopics {
+		/* By default, we set all topics as active,
+		 * but we don't want the checkboxes to be ticked
+		 * this ensures they're sent to the topic API, but
+		 * hides that from the frontend.
+		 */
+		rc.QueryParams.TopicFilter = ""
+	}
+
+	if err := validateCurrentPage(rc.Ctx, rc.Cfg, rc.QueryParams, rc.SearchCount); err != nil {
+		rc.ValidationErrs = append(rc.ValidationErrs, core.ErrorItem{
+			Description: core.Localisation{
+				Text: err.Error(),
+			},
+		})
+		return rc.renderErrorPage(cache.Topic{}, rc.Breadcrumbs)
+	}
+
+	rc.Page = rc.AggCfg.CreatePageModel(rc)
+	if rc.Degraded != (model.Degraded{}) {
+		degraded := rc.Degraded
+		rc.Page.Data.Degraded = &degraded
+	}
+
+	return nil
+}
+
+// releaseScheduleStage shows or hides the parts of the page which are scheduled to be released
+func releaseScheduleStage(rc *RequestContext) error {
+	var scheduledTopic *cache.Topic
+	if rc.AggCfg.UseTopicsPath {
+		scheduledTopic = &rc.SelectedTopic
+	}
+
+	applyReleaseSchedule(rc.Ctx, rc.Cfg, rc.Now, rc.CacheList, scheduledTopic, &rc.Page)
+
+	return nil
+}
+
+// brokenRelatedLinksStage reports the related data of the page which is missing from the search results
+func brokenRelatedLinksStage(rc *RequestContext) error {
+	addBrokenRelatedLinks(rc.Cfg, rc.URLPath, rc.MissingRelatedURIs, &rc.Page)
+	return nil
+}
+
+// collectionPreviewStage shows the changes to the search results made by the collection being previewed
+func collectionPreviewStage(rc *RequestContext) error {
+	addCollectionPreview(rc.Ctx, rc.Cfg, rc.Zebedee, rc.Search, rc.SearchOptions, rc.AccessToken, rc.CollectionID, &rc.Page)
+	return nil
+}
+
+// analyticsStage records the search made for the page and links each search result through the click tracking
+func analyticsStage(rc *RequestContext) error {
+	if rc.Analytics == nil {
+		return nil
+	}
+
+	search := analytics.Event{
+		Type:        analytics.EventSearch,
+		Template:    rc.AggCfg.TemplateName,
+		Query:       analytics.NormaliseQuery(rc.QueryParams.Query),
+		Filters:     searchFilters(rc.QueryParams),
+		ResultCount: rc.SearchCount,
+		Page:        rc.QueryParams.CurrentPage,
+		Lang:        rc.Lang,
+		LatencyMS:   rc.SearchLatency.Milliseconds(),
+	}
+	rc.Analytics.Record(search)
+
+	if !rc.Analytics.TracksClicks() {
+		return nil
+	}
+
+	items := rc.Page.Data.Response.Items
+	for i := range items {
+		click := search
+		click.URI = items[i].URI
+		click.Rank = rc.QueryParams.Offset + i + 1
+		items[i].ClickURI = rc.Analytics.ClickURL(click)
+	}
+
+	return nil
+}
+
+// searchFilters returns the filters and sort applied to the search, leaving out those which are not set
+func searchFilters(params data.SearchURLParams) map[string]string {
+	filters := map[string]string{
+		"content_type":    strings.Join(params.Filter.Query, ","),
+		"topics":          params.TopicFilter,
+		"population_type": params.PopulationTypeFilter,
+		"dimensions":      params.DimensionsFilter,
+		"after_date":      params.AfterDate.String(),
+		"before_date":     params.BeforeDate.String(),
+		"release_type":    params.ReleaseType.Query,
+		"sort":            params.Sort.Query,
+	}
+
+	maps.DeleteFunc(filters, func(_, value string) bool { return value == "" })
+	return filters
+}
+
+// renderStage renders the page model with the template of the page type
+func renderStage(rc *RequestContext) error {
+	buildDataAggregationPage(rc.W, rc.Page, rc.Renderer, rc.AggCfg.TemplateName)
+	return nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"time"
+)
+
+// withTimeout calls fetch with a deadline, returning the error of the context once the deadline passes even if fetch
+// has not returned, so that a dependency which ignores the deadline cannot hold up the request. A timeout of zero or
+// less disables the deadline
+func withTimeout[T any](ctx context.Context, timeout time.Duration, fetch func(ctx context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fetch(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+
+	resultC := make(chan result, 1)
+	go func() {
+		value, err := fetch(ctx)
+		resultC <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-resultC:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a dependency which responds in time", t, func() {
+		fetch := func(ctx context.Context) (string, error) {
+			return "response", nil
+		}
+
+		Convey("When it is called with a timeout", func() {
+			value, err := withTimeout(ctx, time.Second, fetch)
+
+			Convey("Then its response is returned", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "response")
+			})
+		})
+	})
+
+	Convey("Given a dependency which fails", t, func() {
+		errFetch := errors.New("dependency failed")
+		fetch := func(ctx context.Context) (string, error) {
+			return "", errFetch
+		}
+
+		Convey("When it is called with a timeout", func() {
+			_, err := withTimeout(ctx, time.Second, fetch)
+
+			Convey("Then its error is returned", func() {
+				So(err, ShouldEqual, errFetch)
+			})
+		})
+	})
+
+	Convey("Given a dependency which ignores the deadline and does not respond in time", t, func() {
+		release := make(chan struct{})
+		defer close(release)
+
+		fetch := func(ctx context.Context) (string, error) {
+			<-release
+			return "late response", nil
+		}
+
+		Convey("When it is called with a timeout", func() {
+			value, err := withTimeout(ctx, 10*time.Millisecond, fetch)
+
+			Convey("Then the deadline exceeded error is returned without waiting for it", func() {
+				So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+				So(value, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given the timeout is disabled", t, func() {
+		fetch := func(ctx context.Context) (string, error) {
+			_, hasDeadline := ctx.Deadline()
+			if hasDeadline {
+				return "", errors.New("unexpected deadline")
+			}
+			return "response", nil
+		}
+
+		Convey("When it is called", func() {
+			value, err := withTimeout(ctx, 0, fetch)
+
+			Convey("Then it is called without a deadline", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "response")
+			})
+		})
+	})
+}
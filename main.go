@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/redact"
 	"github.com/ONSdigital/dp-frontend-search-controller/service"
 	dpotelgo "github.com/ONSdigital/dp-otel-go"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -45,6 +46,14 @@ func run(ctx context.Context) error {
 		log.Error(ctx, "unable to retrieve service configuration", err)
 		return err
 	}
+
+	// Mask access tokens, secrets and user queries in every log line from here on
+	redactor := redact.New(redact.Config{
+		QueryParams: cfg.LogRedactedQueryParams,
+		Secrets:     []string{cfg.ServiceAuthToken, cfg.AnalyticsClickSecret, cfg.CacheInvalidationWebhookSecret},
+	})
+	log.SetDestination(redact.NewWriter(redactor, os.Stdout), redact.NewWriter(redactor, os.Stderr))
+
 	log.Info(ctx, "got service configuration", log.Data{"config": cfg})
 
 	if cfg.OtelEnabled {
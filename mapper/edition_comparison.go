@@ -0,0 +1,145 @@
+package mapper
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
+)
+
+// CreateEditionComparisonPage maps the two editions of a release to a side by side comparison
+func CreateEditionComparisonPage(cfg *config.Config, req *http.Request, basePage core.Page, lang string,
+	homepageResponse zebedee.HomepageContent, navigationContent *topicModel.Navigation,
+	zebedeeResp zebedee.PageData, editions []zebedee.PageData, bc []zebedee.Breadcrumb,
+) model.SearchPage {
+	page := model.SearchPage{
+		Page: basePage,
+	}
+
+	MapCookiePreferences(req, &page.Page.CookiesPreferencesSet, &page.Page.CookiesPolicy)
+
+	page.Metadata.Title = "Compare editions of " + zebedeeResp.Description.Title
+	page.Title.Title = zebedeeResp.Description.Title
+	page.Metadata.Description = zebedeeResp.Description.MetaDescription
+	page.Type = "edition-comparison"
+	page.Language = lang
+	page.BetaBannerEnabled = true
+	page.SearchDisabled = false
+	page.URI = req.URL.RequestURI()
+	page.PatternLibraryAssetsPath = cfg.PatternLibraryAssetsPath
+	page.ServiceMessage = homepageResponse.ServiceMessage
+	page.EmergencyBanner = mapEmergencyBanner(homepageResponse)
+	page.FeatureFlags.IsPublishing = cfg.IsPublishing
+	page.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	if navigationContent != nil {
+		page.NavigationContent = mapNavigationContent(*navigationContent)
+	}
+
+	mapBreadcrumb(&page, bc, zebedeeResp.Description.Title, zebedeeResp.URI)
+
+	page.Data.EditionComparison = mapEditionComparison(editions)
+
+	return page
+}
+
+// mapEditionComparison maps the editions ordered by release date, with the differences between the earlier and later edition
+func mapEditionComparison(editions []zebedee.PageData) *model.EditionComparison {
+	comparison := &model.EditionComparison{
+		Editions: make([]model.ComparedEdition, 0, len(editions)),
+	}
+
+	for i := range editions {
+		comparison.Editions = append(comparison.Editions, model.ComparedEdition{
+			URI:         editions[i].URI,
+			Title:       editions[i].Description.Title,
+			Edition:     editions[i].Description.Edition,
+			Summary:     editions[i].Description.Summary,
+			Keywords:    editions[i].Description.Keywords,
+			ReleaseDate: editions[i].Description.ReleaseDate,
+		})
+	}
+
+	slices.SortStableFunc(comparison.Editions, func(a, b model.ComparedEdition) int {
+		return strings.Compare(a.ReleaseDate, b.ReleaseDate)
+	})
+
+	if len(comparison.Editions) != 2 {
+		return comparison
+	}
+
+	earlier, later := comparison.Editions[0], comparison.Editions[1]
+
+	comparison.SummaryDiff = diffWords(earlier.Summary, later.Summary)
+
+	for _, keyword := range later.Keywords {
+		if !slices.Contains(earlier.Keywords, keyword) {
+			comparison.KeywordsAdded = append(comparison.KeywordsAdded, keyword)
+		}
+	}
+	for _, keyword := range earlier.Keywords {
+		if !slices.Contains(later.Keywords, keyword) {
+			comparison.KeywordsRemoved = append(comparison.KeywordsRemoved, keyword)
+		}
+	}
+
+	return comparison
+}
+
+// diffWords returns the words which are unchanged, removed from the earlier text or added to the later text, using
+// the longest common subsequence of words. Consecutive words of the same kind are joined into a single segment
+func diffWords(earlier, later string) []model.DiffSegment {
+	a, b := strings.Fields(earlier), strings.Fields(later)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	segments := []model.DiffSegment{}
+	appendWord := func(word string, added, removed bool) {
+		if n := len(segments); n > 0 && segments[n-1].Added == added && segments[n-1].Removed == removed {
+			segments[n-1].Text += " " + word
+			return
+		}
+		segments = append(segments, model.DiffSegment{Text: word, Added: added, Removed: removed})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			appendWord(a[i], false, false)
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			appendWord(a[i], false, true)
+			i++
+		default:
+			appendWord(b[j], true, false)
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		appendWord(a[i], false, true)
+	}
+	for ; j < len(b); j++ {
+		appendWord(b[j], true, false)
+	}
+
+	return segments
+}
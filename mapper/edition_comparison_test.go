@@ -0,0 +1,102 @@
+package mapper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	topicModels "github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateEditionComparisonPage(t *testing.T) {
+	Convey("Given two editions of a bulletin with the later edition first", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		req := httptest.NewRequest("", "/foo/bar/previousreleases?compare=/foo/bar/2024-03-01&compare=/foo/bar/2024-02-01", http.NoBody)
+
+		latest := zebedee.PageData{
+			URI:         "/foo/bar/2024-03-01",
+			Description: zebedee.Description{Title: "Labour market overview"},
+		}
+		editions := []zebedee.PageData{
+			{
+				URI: "/foo/bar/2024-03-01",
+				Description: zebedee.Description{
+					Title:       "Labour market overview",
+					Edition:     "March 2024",
+					Summary:     "Employment rose in the latest quarter",
+					Keywords:    []string{"employment", "wages"},
+					ReleaseDate: "2024-03-01T07:00:00.000Z",
+				},
+			},
+			{
+				URI: "/foo/bar/2024-02-01",
+				Description: zebedee.Description{
+					Title:       "Labour market overview",
+					Edition:     "February 2024",
+					Summary:     "Employment fell in the quarter",
+					Keywords:    []string{"employment", "vacancies"},
+					ReleaseDate: "2024-02-01T07:00:00.000Z",
+				},
+			},
+		}
+
+		Convey("When CreateEditionComparisonPage is called", func() {
+			page := CreateEditionComparisonPage(cfg, req, core.Page{}, englishLang, zebedee.HomepageContent{}, &topicModels.Navigation{}, latest, editions, []zebedee.Breadcrumb{})
+
+			Convey("Then the editions are ordered with the earlier edition first", func() {
+				So(page.Type, ShouldEqual, "edition-comparison")
+				So(page.Title.Title, ShouldEqual, "Labour market overview")
+				So(page.Data.EditionComparison.Editions, ShouldHaveLength, 2)
+				So(page.Data.EditionComparison.Editions[0].Edition, ShouldEqual, "February 2024")
+				So(page.Data.EditionComparison.Editions[1].Edition, ShouldEqual, "March 2024")
+				So(page.Data.EditionComparison.Editions[1].URI, ShouldEqual, "/foo/bar/2024-03-01")
+			})
+
+			Convey("And the summary and keyword changes are mapped", func() {
+				So(page.Data.EditionComparison.SummaryDiff, ShouldResemble, []model.DiffSegment{
+					{Text: "Employment"},
+					{Text: "fell", Removed: true},
+					{Text: "rose", Added: true},
+					{Text: "in the"},
+					{Text: "latest", Added: true},
+					{Text: "quarter"},
+				})
+				So(page.Data.EditionComparison.KeywordsAdded, ShouldResemble, []string{"wages"})
+				So(page.Data.EditionComparison.KeywordsRemoved, ShouldResemble, []string{"vacancies"})
+			})
+
+			Convey("And the breadcrumb links to the latest release", func() {
+				So(page.Breadcrumb, ShouldHaveLength, 1)
+				So(page.Breadcrumb[0].URI, ShouldEqual, "/foo/bar/2024-03-01")
+			})
+		})
+	})
+}
+
+func TestDiffWords(t *testing.T) {
+	Convey("Given two identical texts", t, func() {
+		Convey("When diffWords is called", func() {
+			segments := diffWords("no change here", "no  change here")
+
+			Convey("Then a single unchanged segment is returned", func() {
+				So(segments, ShouldResemble, []model.DiffSegment{{Text: "no change here"}})
+			})
+		})
+	})
+
+	Convey("Given an empty earlier text", t, func() {
+		Convey("When diffWords is called", func() {
+			segments := diffWords("", "all new")
+
+			Convey("Then all the words are added", func() {
+				So(segments, ShouldResemble, []model.DiffSegment{{Text: "all new", Added: true}})
+			})
+		})
+	})
+}
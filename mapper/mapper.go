@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/ONSdigital/dis-design-system-go/v2/helper"
@@ -104,7 +105,7 @@ func CreateDataAggregationPage(cfg *config.Config, req *http.Request, basePage c
 
 // CreatePreviousReleasesPage maps type searchC.Response to model.Page
 func CreatePreviousReleasesPage(cfg *config.Config, req *http.Request, basePage core.Page,
-	validatedQueryParams data.SearchURLParams, respC *searchModels.SearchResponse, lang string, homepageResponse zebedee.HomepageContent, errorMessage string,
+	validatedQueryParams data.SearchURLParams, respC *searchModels.SearchResponse, yearCounts []data.YearCount, lang string, homepageResponse zebedee.HomepageContent, errorMessage string,
 	navigationContent *topicModel.Navigation, template string, topic cache.Topic, validationErrs []core.ErrorItem, zebedeeResp zebedee.PageData, bc []zebedee.Breadcrumb,
 ) model.SearchPage {
 	page := model.SearchPage{
@@ -145,6 +146,12 @@ func CreatePreviousReleasesPage(cfg *config.Config, req *http.Request, basePage
 	mapBreadcrumb(&page, bc, zebedeeResp.Description.Title, zebedeeResp.URI)
 
 	mapLatestRelease(&page, zebedeeResp.Description.ReleaseDate)
+
+	mapDateFilters(&page, lang, validatedQueryParams, validationErrs)
+
+	mapPreviousReleases(&page, req, validatedQueryParams, yearCounts)
+
+	page.ICSLink = generateICSLink(req.URL.RawQuery)
 	return page
 }
 
@@ -155,42 +162,24 @@ func generateRSSLink(rawQuery string) string {
 	return "?rss"
 }
 
+func generateICSLink(rawQuery string) string {
+	if rawQuery != "" {
+		return fmt.Sprintf("?ics&%s", rawQuery)
+	}
+	return "?ics"
+}
+
 func mapDataPage(page *model.SearchPage, respC *searchModels.SearchResponse, lang string, req *http.Request, cfg *config.Config, validatedQueryParams data.SearchURLParams, homepageResponse zebedee.HomepageContent, navigationContent *topicModel.Navigation, template string, topic cache.Topic, validationErrs []core.ErrorItem) {
-	switch template {
-	case "all-adhocs":
-		page.Metadata.Title = "User requested data"
-		page.Title.LocaliseKeyName = "UserRequestedData"
-		page.Data.DateFilterEnabled = true
-	case "home-datalist":
-		page.Metadata.Title = "Published data"
-		page.Title.LocaliseKeyName = "DataList"
-		page.Data.SingleContentTypeFilterEnabled = true
-		page.Data.DateFilterEnabled = true
-		page.RSSLink = generateRSSLink(req.URL.RawQuery)
-	case "home-publications":
-		page.Metadata.Title = "Publications"
-		page.Title.LocaliseKeyName = "HomePublications"
+	aggregationPage, _ := data.GetAggregationPage(template)
+
+	page.Metadata.Title = aggregationPage.Title
+	page.Title.LocaliseKeyName = aggregationPage.LocaliseKeyName
+	page.Data.DateFilterEnabled = aggregationPage.Filters.Date
+	page.Data.TopicFilterEnabled = aggregationPage.Filters.Topic
+	page.Data.SingleContentTypeFilterEnabled = aggregationPage.Filters.SingleContentType
+	page.Data.EnableTimeSeriesExport = aggregationPage.Filters.TimeSeriesExport
+	if aggregationPage.RSS {
 		page.RSSLink = generateRSSLink(req.URL.RawQuery)
-	case "all-methodologies":
-		page.Metadata.Title = "All methodology"
-		page.Title.LocaliseKeyName = "AllMethodology"
-		page.Data.TopicFilterEnabled = true
-	case "published-requests":
-		page.Metadata.Title = "Freedom of Information (FOI) requests"
-		page.Title.LocaliseKeyName = "FOIRequests"
-		page.Data.DateFilterEnabled = true
-	case "home-list":
-		page.Metadata.Title = "Information pages"
-		page.Title.LocaliseKeyName = "HomeList"
-	case "home-methodology":
-		page.Metadata.Title = "Methodology"
-		page.Title.LocaliseKeyName = "HomeMethodology"
-	case "time-series-tool":
-		page.Metadata.Title = "Time series explorer"
-		page.Title.LocaliseKeyName = "TimeSeriesExplorer"
-		page.Data.DateFilterEnabled = true
-		page.Data.TopicFilterEnabled = true
-		page.Data.EnableTimeSeriesExport = true
 	}
 
 	page.Data.KeywordFilter = core.CompactSearch{
@@ -204,21 +193,50 @@ func mapDataPage(page *model.SearchPage, respC *searchModels.SearchResponse, lan
 		SearchTerm: validatedQueryParams.Query,
 	}
 
-	var fdErrDescription, tdErrDescription []core.Localisation
 	if len(validationErrs) > 0 {
 		page.Error = core.Error{
 			Title:      page.Metadata.Title,
 			ErrorItems: validationErrs,
 			Language:   lang,
 		}
+	}
 
-		for _, err := range validationErrs {
-			switch err.ID {
-			case validatedQueryParams.AfterDate.GetFieldsetErrID():
-				fdErrDescription = append(fdErrDescription, err.Description)
-			case validatedQueryParams.BeforeDate.GetFieldsetErrID():
-				tdErrDescription = append(tdErrDescription, err.Description)
-			}
+	mapDateFilters(page, lang, validatedQueryParams, validationErrs)
+
+	page.Type = "Data Aggregation Page"
+	if !strings.EqualFold(topic.LocaliseKeyName, "census") {
+		page.Data.Topic = strings.ToLower(topic.LocaliseKeyName)
+	}
+	// topic aggregation pages can be added to a calendar as a publication schedule
+	if topic.ID != "" && topic.ID != cache.CensusTopicID {
+		page.ICSLink = generateICSLink(req.URL.RawQuery)
+	}
+	page.Data.TermLocalKey = "Results"
+	page.Count = respC.Count
+	page.Language = lang
+	page.BetaBannerEnabled = true
+	page.SearchDisabled = false
+	page.URI = req.URL.RequestURI()
+	page.PatternLibraryAssetsPath = cfg.PatternLibraryAssetsPath
+	page.Pagination.CurrentPage = validatedQueryParams.CurrentPage
+	page.ServiceMessage = homepageResponse.ServiceMessage
+	page.EmergencyBanner = mapEmergencyBanner(homepageResponse)
+	page.FeatureFlags.IsPublishing = cfg.IsPublishing
+	page.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	if navigationContent != nil {
+		page.NavigationContent = mapNavigationContent(*navigationContent)
+	}
+}
+
+// mapDateFilters maps the released after and released before fieldsets, with the validation errors of each date
+func mapDateFilters(page *model.SearchPage, lang string, validatedQueryParams data.SearchURLParams, validationErrs []core.ErrorItem) {
+	var fdErrDescription, tdErrDescription []core.Localisation
+	for _, err := range validationErrs {
+		switch err.ID {
+		case validatedQueryParams.AfterDate.GetFieldsetErrID():
+			fdErrDescription = append(fdErrDescription, err.Description)
+		case validatedQueryParams.BeforeDate.GetFieldsetErrID():
+			tdErrDescription = append(tdErrDescription, err.Description)
 		}
 	}
 
@@ -360,26 +378,6 @@ func mapDataPage(page *model.SearchPage, respC *searchModels.SearchResponse, lan
 			},
 		},
 	}
-
-	page.Type = "Data Aggregation Page"
-	if !strings.EqualFold(topic.LocaliseKeyName, "census") {
-		page.Data.Topic = strings.ToLower(topic.LocaliseKeyName)
-	}
-	page.Data.TermLocalKey = "Results"
-	page.Count = respC.Count
-	page.Language = lang
-	page.BetaBannerEnabled = true
-	page.SearchDisabled = false
-	page.URI = req.URL.RequestURI()
-	page.PatternLibraryAssetsPath = cfg.PatternLibraryAssetsPath
-	page.Pagination.CurrentPage = validatedQueryParams.CurrentPage
-	page.ServiceMessage = homepageResponse.ServiceMessage
-	page.EmergencyBanner = mapEmergencyBanner(homepageResponse)
-	page.FeatureFlags.IsPublishing = cfg.IsPublishing
-	page.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
-	if navigationContent != nil {
-		page.NavigationContent = mapNavigationContent(*navigationContent)
-	}
 }
 
 // CreateSearchPage maps type searchC.Response to model.Page
@@ -547,6 +545,10 @@ func mapItemDescription(item *model.ContentItem, itemC *searchModels.Item) {
 		Summary:         itemC.Summary,
 		Title:           itemC.Title,
 		Edition:         itemC.Edition,
+		Cancelled:       itemC.Cancelled,
+		Finalised:       itemC.Finalised,
+		Published:       itemC.Published,
+		ProvisionalDate: itemC.ProvisionalDate,
 	}
 
 	if len(itemC.Keywords) != 0 {
@@ -909,17 +911,18 @@ func mapNavigationContent(navigationContent topicModel.Navigation) []core.Naviga
 }
 
 func filterCategoriesByTemplate(template string, categories []data.Category) []data.Category {
-	if template == "home-datalist" || template == "home-publications" {
-		var filteredCategories []data.Category
-		for _, category := range categories {
-			if (template == "home-datalist" && category.LocaliseKeyName == "Data") ||
-				(template == "home-publications" && category.LocaliseKeyName == "Publication") {
-				filteredCategories = append(filteredCategories, category)
-			}
+	aggregationPage, _ := data.GetAggregationPage(template)
+	if len(aggregationPage.Categories) == 0 {
+		return categories
+	}
+
+	var filteredCategories []data.Category
+	for _, category := range categories {
+		if slices.Contains(aggregationPage.Categories, category.LocaliseKeyName) {
+			filteredCategories = append(filteredCategories, category)
 		}
-		return filteredCategories
 	}
-	return categories
+	return filteredCategories
 }
 
 // mapBreadcrumb maps breadcrumb response from Zebedee to page model
@@ -671,7 +671,7 @@ func TestCreatePreviousReleasesPage(t *testing.T) {
 		So(err, ShouldBeNil)
 
 		Convey("When CreatePreviousReleasesPage is called", func() {
-			sp := CreatePreviousReleasesPage(cfg, req, mdl, validatedQueryParams, respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, nil, respZ, respBc)
+			sp := CreatePreviousReleasesPage(cfg, req, mdl, validatedQueryParams, respC, nil, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, nil, respZ, respBc)
 
 			Convey("Then successfully map search response from search-query client to page model", func() {
 				So(sp.Data.Pagination.CurrentPage, ShouldEqual, 1)
@@ -710,6 +710,8 @@ func TestCreatePreviousReleasesPage(t *testing.T) {
 				So(sp.EmergencyBanner.Description, ShouldEqual, respH.EmergencyBanner.Description)
 				So(sp.EmergencyBanner.URI, ShouldEqual, respH.EmergencyBanner.URI)
 				So(sp.EmergencyBanner.LinkText, ShouldEqual, respH.EmergencyBanner.LinkText)
+
+				So(sp.ICSLink, ShouldEqual, "?ics")
 			})
 		})
 
@@ -724,7 +726,7 @@ func TestCreatePreviousReleasesPage(t *testing.T) {
 				},
 			}
 
-			page := CreatePreviousReleasesPage(cfg, req, mdl, validatedQueryParams, respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, validationErrs, respZ, respBc)
+			page := CreatePreviousReleasesPage(cfg, req, mdl, validatedQueryParams, respC, nil, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, validationErrs, respZ, respBc)
 
 			Convey("Then validation errors are successfully mapped to the page model", func() {
 				So(page.Error.ErrorItems, ShouldResemble, validationErrs)
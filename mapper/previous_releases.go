@@ -0,0 +1,99 @@
+package mapper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+)
+
+// mapPreviousReleases maps the edition and year filters of the previous releases and groups the releases by year if chosen
+func mapPreviousReleases(page *model.SearchPage, req *http.Request, validatedQueryParams data.SearchURLParams, yearCounts []data.YearCount) {
+	previousReleases := &model.PreviousReleases{
+		EditionFilter: validatedQueryParams.EditionFilter,
+		GroupByYear:   validatedQueryParams.GroupByYear,
+	}
+
+	groupByYearQuery := getFilterQuery(req)
+	if validatedQueryParams.GroupByYear {
+		groupByYearQuery.Del(data.GroupBy)
+	} else {
+		groupByYearQuery.Set(data.GroupBy, data.GroupByYear)
+	}
+	previousReleases.GroupByYearURL = "?" + groupByYearQuery.Encode()
+
+	previousReleases.YearFacets = mapYearFacets(req, validatedQueryParams, yearCounts)
+
+	if validatedQueryParams.GroupByYear {
+		previousReleases.ReleasesByYear = groupReleasesByYear(page.Data.Response.Items)
+	}
+
+	page.Data.PreviousReleases = previousReleases
+}
+
+// mapYearFacets maps the years which have releases, most recent first, to links which filter the releases to that year
+func mapYearFacets(req *http.Request, validatedQueryParams data.SearchURLParams, yearCounts []data.YearCount) []model.YearFacet {
+	yearFacets := make([]model.YearFacet, 0, len(yearCounts))
+	for _, yearCount := range yearCounts {
+		year := strconv.Itoa(yearCount.Year)
+
+		query := getFilterQuery(req)
+		query.Del(data.DayAfter)
+		query.Del(data.MonthAfter)
+		query.Set(data.YearAfter, year)
+		query.Set(data.DayBefore, "31")
+		query.Set(data.MonthBefore, "12")
+		query.Set(data.YearBefore, year)
+
+		yearFacets = append(yearFacets, model.YearFacet{
+			Year:  yearCount.Year,
+			Count: yearCount.Count,
+			URL:   "?" + query.Encode(),
+			IsSelected: validatedQueryParams.AfterDate.String() == fmt.Sprintf("%s-01-01", year) &&
+				validatedQueryParams.BeforeDate.String() == fmt.Sprintf("%s-12-31", year),
+		})
+	}
+
+	sort.Slice(yearFacets, func(i, j int) bool {
+		return yearFacets[i].Year > yearFacets[j].Year
+	})
+
+	return yearFacets
+}
+
+// groupReleasesByYear groups the releases by the year they were released in, keeping the order of the releases
+func groupReleasesByYear(items []model.ContentItem) []model.ReleaseYearGroup {
+	groups := []model.ReleaseYearGroup{}
+	for i := range items {
+		year := ""
+		if len(items[i].Description.ReleaseDate) >= 4 {
+			year = items[i].Description.ReleaseDate[:4]
+		}
+
+		if n := len(groups); n > 0 && groups[n-1].Year == year {
+			groups[n-1].Items = append(groups[n-1].Items, items[i])
+			continue
+		}
+
+		groups = append(groups, model.ReleaseYearGroup{
+			Year:  year,
+			Items: []model.ContentItem{items[i]},
+		})
+	}
+
+	return groups
+}
+
+// getFilterQuery returns the query of the request without the parameters which should not be kept when the filters change
+func getFilterQuery(req *http.Request) url.Values {
+	query := req.URL.Query()
+	query.Del(data.Page)
+	query.Del("ics")
+	query.Del("compare")
+
+	return query
+}
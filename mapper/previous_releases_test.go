@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMapPreviousReleases(t *testing.T) {
+	Convey("Given previous releases filtered to 2023 and grouped by year", t, func() {
+		req := httptest.NewRequest("", "/foo/bar/previousreleases?edition=q1&after-year=2023&before-year=2023&before-month=12&before-day=31&group-by=year&page=2", http.NoBody)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		validatedQueryParams, validationErrs := data.ReviewPreviousReleasesQueryWithParams(req.Context(), cfg, req.URL.Query(), "/foo/bar")
+		So(validationErrs, ShouldBeEmpty)
+
+		page := &model.SearchPage{}
+		page.Data.Response.Items = []model.ContentItem{
+			{URI: "/foo/bar/2024-02-01", Description: model.Description{ReleaseDate: "2024-02-01T07:00:00.000Z"}},
+			{URI: "/foo/bar/2023-11-01", Description: model.Description{ReleaseDate: "2023-11-01T07:00:00.000Z"}},
+			{URI: "/foo/bar/2023-08-01", Description: model.Description{ReleaseDate: "2023-08-01T07:00:00.000Z"}},
+		}
+
+		yearCounts := []data.YearCount{{Year: 2023, Count: 4}, {Year: 2024, Count: 1}}
+
+		Convey("When mapPreviousReleases is called", func() {
+			mapPreviousReleases(page, req, validatedQueryParams, yearCounts)
+
+			Convey("Then the year facets are mapped with the most recent year first", func() {
+				facets := page.Data.PreviousReleases.YearFacets
+				So(facets, ShouldHaveLength, 2)
+				So(facets[0].Year, ShouldEqual, 2024)
+				So(facets[0].Count, ShouldEqual, 1)
+				So(facets[0].IsSelected, ShouldBeFalse)
+				So(facets[0].URL, ShouldEqual, "?after-year=2024&before-day=31&before-month=12&before-year=2024&edition=q1&group-by=year")
+				So(facets[1].Year, ShouldEqual, 2023)
+				So(facets[1].IsSelected, ShouldBeTrue)
+			})
+
+			Convey("And the releases are grouped by year", func() {
+				So(page.Data.PreviousReleases.ReleasesByYear, ShouldHaveLength, 2)
+				So(page.Data.PreviousReleases.ReleasesByYear[0].Year, ShouldEqual, "2024")
+				So(page.Data.PreviousReleases.ReleasesByYear[0].Items, ShouldHaveLength, 1)
+				So(page.Data.PreviousReleases.ReleasesByYear[1].Year, ShouldEqual, "2023")
+				So(page.Data.PreviousReleases.ReleasesByYear[1].Items, ShouldHaveLength, 2)
+			})
+
+			Convey("And the edition filter and link to show the releases as a list are mapped", func() {
+				So(page.Data.PreviousReleases.EditionFilter, ShouldEqual, "q1")
+				So(page.Data.PreviousReleases.GroupByYear, ShouldBeTrue)
+				So(page.Data.PreviousReleases.GroupByYearURL, ShouldEqual, "?after-year=2023&before-day=31&before-month=12&before-year=2023&edition=q1")
+			})
+		})
+	})
+}
@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"net/http"
+	"slices"
 
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
@@ -14,7 +15,7 @@ import (
 )
 
 func CreateRelatedDataPage(cfg *config.Config, req *http.Request, basePage core.Page,
-	validatedQueryParams data.SearchURLParams, respC *searchModels.SearchResponse, lang string, homepageResponse zebedee.HomepageContent, errorMessage string,
+	validatedQueryParams data.SearchURLParams, categories []data.Category, respC *searchModels.SearchResponse, lang string, homepageResponse zebedee.HomepageContent, errorMessage string,
 	navigationContent *topicModel.Navigation, template string, topic cache.Topic, validationErrs []core.ErrorItem, zebedeeResp zebedee.PageData, bc []zebedee.Breadcrumb,
 ) model.SearchPage {
 	page := model.SearchPage{
@@ -53,8 +54,65 @@ func CreateRelatedDataPage(cfg *config.Config, req *http.Request, basePage core.
 
 	mapQuery(cfg, &page, validatedQueryParams, respC, *req, errorMessage)
 
-	mapResponse(&page, respC, []data.Category{})
+	page.Data.Sort.Options = make([]model.SortOptions, len(data.RelatedDataSortOptions))
+	for i := range data.RelatedDataSortOptions {
+		page.Data.Sort.Options[i] = model.SortOptions{
+			Query:           data.RelatedDataSortOptions[i].Query,
+			LocaliseKeyName: data.RelatedDataSortOptions[i].LocaliseKeyName,
+		}
+	}
+
+	mapResponse(&page, respC, categories)
+
+	page.Data.ContentTypeFilterEnabled = true
+	mapFilters(&page, categories, validatedQueryParams)
+
+	page.Data.RelatedDataGroups = groupRelatedDataByContentType(page.Data.Response.Items, categories, page.Data.Response.Count)
 
 	mapBreadcrumb(&page, bc, zebedeeResp.Description.Title, zebedeeResp.URI)
 	return page
 }
+
+// groupRelatedDataByContentType groups the related data by the content types it can be filtered by, keeping the order
+// of the related data in each group. Related data of any other content type is grouped last
+func groupRelatedDataByContentType(items []model.ContentItem, categories []data.Category, total int) []model.ContentTypeGroup {
+	groups := []model.ContentTypeGroup{}
+	grouped := make([]bool, len(items))
+
+	other := model.ContentTypeGroup{
+		LocaliseKeyName: data.Other.LocaliseKeyName,
+		Count:           total,
+	}
+
+	for _, category := range categories {
+		other.Count -= category.Count
+		for _, contentType := range category.ContentTypes {
+			group := model.ContentTypeGroup{
+				LocaliseKeyName: contentType.LocaliseKeyName,
+				Count:           contentType.Count,
+			}
+
+			for i := range items {
+				if !grouped[i] && slices.Contains(contentType.Types, items[i].Type.Type) {
+					group.Items = append(group.Items, items[i])
+					grouped[i] = true
+				}
+			}
+
+			if len(group.Items) > 0 {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	for i := range items {
+		if !grouped[i] {
+			other.Items = append(other.Items, items[i])
+		}
+	}
+	if len(other.Items) > 0 {
+		groups = append(groups, other)
+	}
+
+	return groups
+}
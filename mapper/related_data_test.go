@@ -12,6 +12,7 @@ import (
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
 	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/mocks"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
 	topicModels "github.com/ONSdigital/dp-topic-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -43,7 +44,21 @@ func TestCreateRelatedDataPage(t *testing.T) {
 		So(err, ShouldBeNil)
 
 		Convey("When CreateRelatedDataPage is called", func() {
-			sp := CreateRelatedDataPage(cfg, req, mdl, validatedQueryParams, respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, nil, respZ, respBc)
+			sp := CreateRelatedDataPage(cfg, req, mdl, validatedQueryParams, data.GetRelatedDataCategories(), respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, nil, respZ, respBc)
+
+			Convey("Then the content type filters and related data sort options are mapped", func() {
+				So(sp.Data.ContentTypeFilterEnabled, ShouldBeTrue)
+				So(sp.Data.Filters, ShouldHaveLength, 1)
+				So(sp.Data.Filters[0].Types, ShouldHaveLength, 3)
+				So(sp.Data.Filters[0].Types[0].FilterKey, ShouldResemble, []string{"datasets"})
+				So(sp.Data.Sort.Options, ShouldResemble, []model.SortOptions{
+					{Query: "release_date", LocaliseKeyName: "ReleaseDate"},
+					{Query: "title", LocaliseKeyName: "Title"},
+				})
+				So(sp.Data.RelatedDataGroups, ShouldHaveLength, 1)
+				So(sp.Data.RelatedDataGroups[0].LocaliseKeyName, ShouldEqual, "Other")
+				So(sp.Data.RelatedDataGroups[0].Count, ShouldEqual, 1)
+			})
 
 			Convey("Then successfully map search response from search-query client to page model", func() {
 				So(sp.Data.Pagination.CurrentPage, ShouldEqual, 1)
@@ -95,7 +110,7 @@ func TestCreateRelatedDataPage(t *testing.T) {
 				},
 			}
 
-			sp := CreateRelatedDataPage(cfg, req, mdl, validatedQueryParams, respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, validationErrs, respZ, respBc)
+			sp := CreateRelatedDataPage(cfg, req, mdl, validatedQueryParams, data.GetRelatedDataCategories(), respC, englishLang, respH, "", &topicModels.Navigation{}, "", cache.Topic{}, validationErrs, respZ, respBc)
 
 			Convey("Then validation errors are successfully mapped to the page model", func() {
 				So(sp.Error.ErrorItems, ShouldResemble, validationErrs)
@@ -103,3 +118,39 @@ func TestCreateRelatedDataPage(t *testing.T) {
 		})
 	})
 }
+
+func TestGroupRelatedDataByContentType(t *testing.T) {
+	Convey("Given related data of different content types", t, func() {
+		categories := data.GetRelatedDataCategories()
+		categories[0].Count = 3
+		categories[0].ContentTypes[0].Count = 2
+		categories[0].ContentTypes[2].Count = 1
+
+		items := []model.ContentItem{
+			{URI: "/dataset1", Type: model.ContentItemType{Type: "dataset_landing_page"}},
+			{URI: "/adhoc1", Type: model.ContentItemType{Type: "static_adhoc"}},
+			{URI: "/bulletin1", Type: model.ContentItemType{Type: "bulletin"}},
+			{URI: "/dataset2", Type: model.ContentItemType{Type: "timeseries_dataset"}},
+		}
+
+		Convey("When groupRelatedDataByContentType is called", func() {
+			groups := groupRelatedDataByContentType(items, categories, 4)
+
+			Convey("Then the related data is grouped in the order of the content types, keeping the order of the related data", func() {
+				So(groups, ShouldHaveLength, 3)
+				So(groups[0].LocaliseKeyName, ShouldEqual, "Datasets")
+				So(groups[0].Count, ShouldEqual, 2)
+				So(groups[0].Items, ShouldResemble, []model.ContentItem{items[0], items[3]})
+				So(groups[1].LocaliseKeyName, ShouldEqual, "UserRequestedData")
+				So(groups[1].Count, ShouldEqual, 1)
+				So(groups[1].Items, ShouldResemble, []model.ContentItem{items[1]})
+			})
+
+			Convey("And related data of any other content type is grouped last", func() {
+				So(groups[2].LocaliseKeyName, ShouldEqual, "Other")
+				So(groups[2].Count, ShouldEqual, 1)
+				So(groups[2].Items, ShouldResemble, []model.ContentItem{items[2]})
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"net/http"
+	"strings"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/cache"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
+)
+
+// CreateReleaseCalendarPage maps the release calendar search response to model.SearchPage
+func CreateReleaseCalendarPage(cfg *config.Config, req *http.Request, basePage core.Page,
+	validatedQueryParams data.SearchURLParams, topicCategories []data.Topic,
+	respC *searchModels.SearchResponse, lang string, homepageResponse zebedee.HomepageContent, errorMessage string,
+	navigationContent *topicModel.Navigation, template string, validationErrs []core.ErrorItem,
+) model.SearchPage {
+	page := CreateDataAggregationPage(cfg, req, basePage, validatedQueryParams, []data.Category{}, []data.Topic{}, respC, lang, homepageResponse, errorMessage, navigationContent, template, cache.Topic{}, validationErrs)
+
+	mapReleaseCalendarTabs(&page, req, validatedQueryParams)
+
+	mapReleaseCalendarTopicFilters(&page, topicCategories, validatedQueryParams)
+
+	page.ICSLink = generateICSLink(req.URL.RawQuery)
+
+	return page
+}
+
+// mapReleaseCalendarTabs maps a tab for each release type which keeps the other filters applied
+func mapReleaseCalendarTabs(page *model.SearchPage, req *http.Request, validatedQueryParams data.SearchURLParams) {
+	tabs := make([]model.ReleaseCalendarTab, 0, len(data.ReleaseTypes))
+
+	for _, releaseType := range data.ReleaseTypes {
+		query := req.URL.Query()
+		query.Set(data.Type, releaseType.Query)
+		query.Del(data.Page)
+
+		tabs = append(tabs, model.ReleaseCalendarTab{
+			LocaliseKeyName: releaseType.LocaliseKeyName,
+			Query:           releaseType.Query,
+			URL:             "?" + query.Encode(),
+			IsSelected:      releaseType == validatedQueryParams.ReleaseType,
+		})
+	}
+
+	page.Data.ReleaseCalendar = &model.ReleaseCalendar{
+		Tabs: tabs,
+	}
+}
+
+// mapReleaseCalendarTopicFilters maps the root data topics which the release calendar can be filtered by
+func mapReleaseCalendarTopicFilters(page *model.SearchPage, topicCategories []data.Topic, validatedQueryParams data.SearchURLParams) {
+	selectedTopics := make(map[string]bool)
+	for _, topicID := range strings.Split(validatedQueryParams.TopicFilter, ",") {
+		selectedTopics[topicID] = true
+	}
+
+	topicFilters := make([]model.TopicFilter, 0, len(topicCategories))
+	for i := range topicCategories {
+		topicFilters = append(topicFilters, model.TopicFilter{
+			LocaliseKeyName: topicCategories[i].LocaliseKeyName,
+			Query:           topicCategories[i].Query,
+			IsChecked:       selectedTopics[topicCategories[i].Query],
+		})
+	}
+
+	page.Data.TopicFilters = topicFilters
+}
@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"net/http"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/model"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
+)
+
+// CreateSearchUnavailablePage maps the page shown when the search api cannot be called, keeping the query so that the
+// user can try again
+func CreateSearchUnavailablePage(cfg *config.Config, req *http.Request, basePage core.Page, lang string,
+	homepageResponse zebedee.HomepageContent, navigationContent *topicModel.Navigation,
+) model.SearchPage {
+	page := model.SearchPage{
+		Page: basePage,
+	}
+
+	MapCookiePreferences(req, &page.Page.CookiesPreferencesSet, &page.Page.CookiesPolicy)
+
+	page.Metadata.Title = "Search temporarily unavailable"
+	page.Type = "search-unavailable"
+	page.Language = lang
+	page.BetaBannerEnabled = true
+	page.SearchDisabled = false
+	page.URI = req.URL.RequestURI()
+	page.PatternLibraryAssetsPath = cfg.PatternLibraryAssetsPath
+	page.ServiceMessage = homepageResponse.ServiceMessage
+	page.EmergencyBanner = mapEmergencyBanner(homepageResponse)
+	page.FeatureFlags.IsPublishing = cfg.IsPublishing
+	page.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	if navigationContent != nil {
+		page.NavigationContent = mapNavigationContent(*navigationContent)
+	}
+
+	page.Data.Query = req.URL.Query().Get("q")
+
+	return page
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSearchUnavailablePage(t *testing.T) {
+	Convey("Given a search which cannot be made", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		req := httptest.NewRequest("", "/search?q=inflation&page=2", http.NoBody)
+
+		Convey("When the search unavailable page is created", func() {
+			page := CreateSearchUnavailablePage(cfg, req, core.Page{}, "en", zebedee.HomepageContent{ServiceMessage: "Planned maintenance"}, nil)
+
+			Convey("Then the query is kept so the search can be tried again", func() {
+				So(page.Type, ShouldEqual, "search-unavailable")
+				So(page.Metadata.Title, ShouldEqual, "Search temporarily unavailable")
+				So(page.URI, ShouldEqual, "/search?q=inflation&page=2")
+				So(page.Data.Query, ShouldEqual, "inflation")
+				So(page.ServiceMessage, ShouldEqual, "Planned maintenance")
+				So(page.NavigationContent, ShouldBeNil)
+			})
+		})
+	})
+}
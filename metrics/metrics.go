@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "dp_frontend_search_controller"
+
+// Outcomes of a cache lookup, cache refresh or feed
+const (
+	ResultHit      = "hit"
+	ResultMiss     = "miss"
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// UnknownID is the label of a validation error which has no id
+const UnknownID = "unknown"
+
+// The metrics of the controller, which are registered with the default Prometheus registry and served by
+// promhttp.Handler
+var (
+	// HTTPRequests counts the requests served, by the template of their route, the page template rendered and status
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests served by route, page template and status code.",
+	}, []string{"route", "template", "status"})
+	// HTTPRequestDuration is the time taken to serve requests, by the template of their route and the page template rendered
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests by route and page template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "template"})
+
+	// SearchAPIRequests counts the calls made to the search api, by method and the status code returned
+	SearchAPIRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "search_api_requests_total",
+		Help:      "Number of calls made to the search api by method and status code.",
+	}, []string{"method", "code"})
+	// SearchAPIRequestDuration is the time taken by the calls made to the search api, by method
+	SearchAPIRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "search_api_request_duration_seconds",
+		Help:      "Time taken by calls made to the search api by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// Searches counts the searches made for a page, by page template
+	Searches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "searches_total",
+		Help:      "Number of searches made for a page by page template.",
+	}, []string{"template"})
+	// ZeroResultSearches counts the searches made for a page which found no results, by page template
+	ZeroResultSearches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "zero_result_searches_total",
+		Help:      "Number of searches made for a page which found no results by page template.",
+	}, []string{"template"})
+	// ValidationErrors counts the invalid query parameters shown to users, by the id of the error
+	ValidationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "validation_errors_total",
+		Help:      "Number of validation errors shown to users by error id.",
+	}, []string{"id"})
+	// RSSFeeds counts the RSS feeds generated, by page template and outcome
+	RSSFeeds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rss_feeds_total",
+		Help:      "Number of RSS feeds generated by page template and outcome.",
+	}, []string{"template", "outcome"})
+
+	// RateLimitedRequests counts the requests refused because the client had used up its budget, by budget
+	RateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Number of requests refused because the client had used up its budget by budget.",
+	}, []string{"budget"})
+
+	// MigrationRedirects counts the requests redirected to the migration link of their page, by route
+	MigrationRedirects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "migration_redirects_total",
+		Help:      "Number of requests redirected to the migration link of their page by route.",
+	}, []string{"route"})
+
+	// CacheLookups counts the lookups of each cache, by whether the data was found
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Number of cache lookups by cache and result.",
+	}, []string{"cache", "result"})
+	// CacheRefreshes counts the updates of each cache, by whether new data was got
+	CacheRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_refreshes_total",
+		Help:      "Number of cache refreshes by cache and outcome.",
+	}, []string{"cache", "outcome"})
+)
+
+// Outcome returns the outcome label of an error
+func Outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
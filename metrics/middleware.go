@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// unmatchedRoute is the route label of a request which does not match a route
+const unmatchedRoute = "unmatched"
+
+type contextKey struct{}
+
+// requestLabels are the labels of a request which are only known once it has been handled
+type requestLabels struct {
+	template string
+}
+
+// SetTemplate records the page template rendered for the request, to label its metrics with
+func SetTemplate(ctx context.Context, template string) {
+	if labels, ok := ctx.Value(contextKey{}).(*requestLabels); ok {
+		labels.template = template
+	}
+}
+
+// Middleware records the count and duration of the requests served by the router, labelled by the template of the
+// route matched rather than the path requested so that the number of series stays bounded
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+
+		labels := &requestLabels{}
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), contextKey{}, labels)))
+
+		route := routeTemplate(req)
+		HTTPRequests.WithLabelValues(route, labels.template, strconv.Itoa(rw.status)).Inc()
+		HTTPRequestDuration.WithLabelValues(route, labels.template).Observe(time.Since(start).Seconds())
+	})
+}
+
+func routeTemplate(req *http.Request) string {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return unmatchedRoute
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return unmatchedRoute
+	}
+	return template
+}
+
+// statusRecorder records the status written by the handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the response writer being recorded, for http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a router with the metrics middleware", t, func() {
+		r := mux.NewRouter()
+		r.Use(Middleware)
+		r.Path("/{uri:.*}/metrics-test").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			SetTemplate(req.Context(), "metrics-test-template")
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		route := "/{uri:.*}/metrics-test"
+		before := testutil.ToFloat64(HTTPRequests.WithLabelValues(route, "metrics-test-template", "418"))
+		beforeCount := sampleCount(HTTPRequestDuration, route, "metrics-test-template")
+
+		Convey("When a request is served", func() {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/economy/metrics-test", http.NoBody))
+
+			Convey("Then it is recorded by the template of its route, the page template and status", func() {
+				So(w.Code, ShouldEqual, http.StatusTeapot)
+				So(testutil.ToFloat64(HTTPRequests.WithLabelValues(route, "metrics-test-template", "418")), ShouldEqual, before+1)
+				So(sampleCount(HTTPRequestDuration, route, "metrics-test-template"), ShouldEqual, beforeCount+1)
+			})
+		})
+	})
+}
+
+// sampleCount returns the number of values observed by the series of the histogram with the label values given
+func sampleCount(h *prometheus.HistogramVec, labelValues ...string) uint64 {
+	m := &dto.Metric{}
+	if err := h.WithLabelValues(labelValues...).(prometheus.Metric).Write(m); err != nil {
+		panic(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
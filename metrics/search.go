@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
+)
+
+// SearchClient is the search api client which records the latency and status code of each call it makes
+type SearchClient struct {
+	searchSDK.Clienter
+}
+
+// NewSearchClient wraps the search api client to record the metrics of its calls. It should wrap the client itself,
+// rather than any retries, so that each call made is recorded
+func NewSearchClient(client searchSDK.Clienter) *SearchClient {
+	return &SearchClient{Clienter: client}
+}
+
+// GetReleaseCalendarEntries gets the release calendar entries from the search api
+func (c *SearchClient) GetReleaseCalendarEntries(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+	start := time.Now()
+	resp, err := c.Clienter.GetReleaseCalendarEntries(ctx, options)
+	observeSearchAPI("GetReleaseCalendarEntries", start, err)
+	return resp, err
+}
+
+// GetSearch gets the search results from the search api
+func (c *SearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	start := time.Now()
+	resp, err := c.Clienter.GetSearch(ctx, options)
+	observeSearchAPI("GetSearch", start, err)
+	return resp, err
+}
+
+// PostSearchURIs gets the search results of the uris from the search api
+func (c *SearchClient) PostSearchURIs(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+	start := time.Now()
+	resp, err := c.Clienter.PostSearchURIs(ctx, options, urisRequest)
+	observeSearchAPI("PostSearchURIs", start, err)
+	return resp, err
+}
+
+func observeSearchAPI(method string, start time.Time, err searchError.Error) {
+	code := http.StatusOK
+	if err != nil {
+		code = err.Status()
+	}
+
+	SearchAPIRequests.WithLabelValues(method, strconv.Itoa(code)).Inc()
+	SearchAPIRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
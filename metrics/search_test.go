@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeSearchClient is a search api client which returns the error given
+type fakeSearchClient struct {
+	searchSDK.Clienter
+	err searchError.Error
+}
+
+func (c *fakeSearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &searchModels.SearchResponse{Count: 1}, nil
+}
+
+func TestSearchClient(t *testing.T) {
+	Convey("Given the search api client wrapped to record metrics", t, func() {
+		fake := &fakeSearchClient{}
+		client := NewSearchClient(fake)
+
+		before200 := testutil.ToFloat64(SearchAPIRequests.WithLabelValues("GetSearch", "200"))
+		before502 := testutil.ToFloat64(SearchAPIRequests.WithLabelValues("GetSearch", "502"))
+		beforeCount := sampleCount(SearchAPIRequestDuration, "GetSearch")
+
+		Convey("When a search succeeds", func() {
+			resp, err := client.GetSearch(context.Background(), searchSDK.Options{})
+
+			Convey("Then the response is returned and the call is recorded with a 200", func() {
+				So(err, ShouldBeNil)
+				So(resp.Count, ShouldEqual, 1)
+				So(testutil.ToFloat64(SearchAPIRequests.WithLabelValues("GetSearch", "200")), ShouldEqual, before200+1)
+				So(sampleCount(SearchAPIRequestDuration, "GetSearch"), ShouldEqual, beforeCount+1)
+			})
+		})
+
+		Convey("When a search fails", func() {
+			fake.err = searchError.StatusError{Code: http.StatusBadGateway, Err: errors.New("bad gateway")}
+			_, err := client.GetSearch(context.Background(), searchSDK.Options{})
+
+			Convey("Then the error is returned and the call is recorded with its status", func() {
+				So(err, ShouldNotBeNil)
+				So(testutil.ToFloat64(SearchAPIRequests.WithLabelValues("GetSearch", "502")), ShouldEqual, before502+1)
+				So(sampleCount(SearchAPIRequestDuration, "GetSearch"), ShouldEqual, beforeCount+1)
+			})
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/dp-frontend-search-controller/ratelimit"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// RateLimit refuses the page requests of clients which have used up their budget with a 429 Too Many Requests, which
+// the renderror middleware renders as an error page, and a Retry-After header. The healthcheck, metrics and requests
+// other than GET and HEAD, e.g. the cache invalidation webhook, are not limited. Requests are allowed if the budget
+// cannot be checked so that an outage of a shared store does not take the site down
+func RateLimit(limiter *ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == HealthPath || req.URL.Path == MetricsPath || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			budget, allowed, retryAfter, err := limiter.Allow(req.Context(), req)
+			if err != nil {
+				log.Warn(req.Context(), "failed to check rate limit, allowing request", log.FormatErrors([]error{err}), log.Data{"budget": budget})
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			if !allowed {
+				metrics.RateLimitedRequests.WithLabelValues(budget).Inc()
+				w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(retryAfter.Seconds())), 1)))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			h.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/ratelimit"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// failingStore is a rate limit store which cannot be reached
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (allowed bool, retryAfter time.Duration, err error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, target, http.NoBody))
+		return w
+	}
+
+	Convey("Given a client which can make a search a minute", t, func() {
+		limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{
+			Limits: map[string]ratelimit.Limit{ratelimit.BudgetSearch: ratelimit.PerMinute(1, 1)},
+		})
+		So(err, ShouldBeNil)
+		handler := RateLimit(limiter)(nextHandler)
+
+		So(serve(handler, http.MethodGet, "/search?q=a").Code, ShouldEqual, http.StatusOK)
+
+		Convey("When it makes another search", func() {
+			w := serve(handler, http.MethodGet, "/search?q=b")
+
+			Convey("Then it is refused with a 429, to be rendered as an error page, and a Retry-After header", func() {
+				So(w.Code, ShouldEqual, http.StatusTooManyRequests)
+				So(w.Header().Get("Retry-After"), ShouldEqual, "60")
+				So(w.Body.String(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the healthcheck, metrics and webhook are requested", func() {
+			Convey("Then they are not limited", func() {
+				So(serve(handler, http.MethodGet, HealthPath).Code, ShouldEqual, http.StatusOK)
+				So(serve(handler, http.MethodGet, MetricsPath).Code, ShouldEqual, http.StatusOK)
+				So(serve(handler, http.MethodPost, "/cache/invalidate").Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given the budgets of clients cannot be checked", t, func() {
+		limiter, err := ratelimit.NewLimiter(failingStore{}, ratelimit.Config{
+			Limits: map[string]ratelimit.Limit{ratelimit.BudgetSearch: ratelimit.PerMinute(1, 1)},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a page is requested", func() {
+			w := serve(RateLimit(limiter)(nextHandler), http.MethodGet, "/search")
+
+			Convey("Then it is served", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HealthPath is the path of the healthcheck endpoint which is always served
+const HealthPath = "/health"
+
+// MetricsPath is the path of the metrics endpoint which is always served
+const MetricsPath = "/metrics"
+
+// Readiness holds back all requests, other than the healthcheck and metrics, with a 503 Service Unavailable
+// until isReady returns true. retryAfter is sent to the client as the Retry-After header.
+func Readiness(isReady func() bool, retryAfter time.Duration) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == HealthPath || req.URL.Path == MetricsPath || isReady() {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+	}
+}
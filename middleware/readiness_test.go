@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadiness(t *testing.T) {
+	t.Parallel()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	Convey("Given the caches have not been populated", t, func() {
+		handler := Readiness(func() bool { return false }, 5*time.Second)(nextHandler)
+
+		Convey("When a page is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+			handler.ServeHTTP(w, req)
+
+			Convey("Then the request is held back with a 503 and a Retry-After header", func() {
+				So(w.Code, ShouldEqual, http.StatusServiceUnavailable)
+				So(w.Header().Get("Retry-After"), ShouldEqual, "5")
+			})
+		})
+
+		Convey("When the healthcheck is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+			handler.ServeHTTP(w, req)
+
+			Convey("Then the request is served", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the metrics are requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+			handler.ServeHTTP(w, req)
+
+			Convey("Then the request is served", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given the caches have been populated", t, func() {
+		handler := Readiness(func() bool { return true }, 5*time.Second)(nextHandler)
+
+		Convey("When a page is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+			handler.ServeHTTP(w, req)
+
+			Convey("Then the request is served", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+}
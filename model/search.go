@@ -12,6 +12,7 @@ type SearchPage struct {
 	BeforeDate model.InputDate `json:"before_date"`
 	AfterDate  model.InputDate `json:"after_date"`
 	RSSLink    string          `json:"rss_link"`
+	ICSLink    string          `json:"ics_link,omitempty"`
 }
 
 // Search represents all search parameters and response data of the search
@@ -39,6 +40,115 @@ type Search struct {
 	TermLocalKey                   string                 `json:"term_localise_key_name,omitempty"`
 	Topic                          string                 `json:"topic,omitempty"`
 	FeedbackAPIURL                 string                 `json:"feedback_api_url"`
+	CollectionPreview              *CollectionPreview     `json:"collection_preview,omitempty"`
+	TopicScheduledRelease          *ScheduledRelease      `json:"topic_scheduled_release,omitempty"`
+	ReleaseCalendar                *ReleaseCalendar       `json:"release_calendar,omitempty"`
+	EditionComparison              *EditionComparison     `json:"edition_comparison,omitempty"`
+	PreviousReleases               *PreviousReleases      `json:"previous_releases,omitempty"`
+	RelatedDataGroups              []ContentTypeGroup     `json:"related_data_groups,omitempty"`
+	BrokenRelatedLinks             *BrokenRelatedLinks    `json:"broken_related_links,omitempty"`
+	Degraded                       *Degraded              `json:"degraded,omitempty"`
+}
+
+// Degraded represents the parts of the page left out because a dependency failed or did not respond in time
+type Degraded struct {
+	EmergencyBanner bool `json:"emergency_banner,omitempty"`
+	Breadcrumbs     bool `json:"breadcrumbs,omitempty"`
+	FilterCounts    bool `json:"filter_counts,omitempty"`
+	Navigation      bool `json:"navigation,omitempty"`
+}
+
+// PreviousReleases represents the filters and grouping of the previous releases of a release
+type PreviousReleases struct {
+	EditionFilter  string             `json:"edition_filter,omitempty"`
+	YearFacets     []YearFacet        `json:"year_facets,omitempty"`
+	GroupByYear    bool               `json:"group_by_year,omitempty"`
+	GroupByYearURL string             `json:"group_by_year_url"`
+	ReleasesByYear []ReleaseYearGroup `json:"releases_by_year,omitempty"`
+}
+
+// YearFacet represents a year which the previous releases can be filtered by and the number of releases in it
+type YearFacet struct {
+	Year       int    `json:"year"`
+	Count      int    `json:"count"`
+	URL        string `json:"url"`
+	IsSelected bool   `json:"is_selected,omitempty"`
+}
+
+// ReleaseYearGroup represents the previous releases released in a year
+type ReleaseYearGroup struct {
+	Year  string        `json:"year"`
+	Items []ContentItem `json:"items"`
+}
+
+// BrokenRelatedLinks represents the related data of a release which could not be found, shown in publishing mode
+type BrokenRelatedLinks struct {
+	Count     int      `json:"count"`
+	URIs      []string `json:"uris"`
+	ReportURL string   `json:"report_url"`
+}
+
+// ContentTypeGroup represents the search results of a content type
+type ContentTypeGroup struct {
+	LocaliseKeyName string        `json:"localise_key_name"`
+	Count           int           `json:"count"`
+	Items           []ContentItem `json:"items"`
+}
+
+// EditionComparison represents two editions of a release shown side by side, with the earlier edition first
+type EditionComparison struct {
+	Editions        []ComparedEdition `json:"editions"`
+	SummaryDiff     []DiffSegment     `json:"summary_diff"`
+	KeywordsAdded   []string          `json:"keywords_added,omitempty"`
+	KeywordsRemoved []string          `json:"keywords_removed,omitempty"`
+}
+
+// ComparedEdition represents the metadata of an edition being compared
+type ComparedEdition struct {
+	URI         string   `json:"uri"`
+	Title       string   `json:"title"`
+	Edition     string   `json:"edition"`
+	Summary     string   `json:"summary"`
+	Keywords    []string `json:"keywords,omitempty"`
+	ReleaseDate string   `json:"release_date"`
+}
+
+// DiffSegment represents a run of words which are unchanged, added or removed between two editions
+type DiffSegment struct {
+	Text    string `json:"text"`
+	Added   bool   `json:"added,omitempty"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+// ReleaseCalendar represents the tabs of the release calendar
+type ReleaseCalendar struct {
+	Tabs []ReleaseCalendarTab `json:"tabs"`
+}
+
+// ReleaseCalendarTab represents a tab of the release calendar, e.g. upcoming releases
+type ReleaseCalendarTab struct {
+	LocaliseKeyName string `json:"localise_key"`
+	Query           string `json:"query"`
+	URL             string `json:"url"`
+	IsSelected      bool   `json:"is_selected,omitempty"`
+}
+
+// CollectionPreview represents the collection being previewed in publishing mode
+type CollectionPreview struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	ItemsInCollection   int    `json:"items_in_collection"`
+	ShowNewInCollection bool   `json:"show_new_in_collection,omitempty"`
+	NewInCollection     int    `json:"new_in_collection,omitempty"`
+}
+
+// ScheduledRelease represents the release of an item or topic which is scheduled in the future, along with the time
+// remaining until it is released
+type ScheduledRelease struct {
+	ReleaseDate string `json:"release_date"`
+	Days        int    `json:"days"`
+	Hours       int    `json:"hours"`
+	Minutes     int    `json:"minutes"`
 }
 
 // Filter represents all filter information needed by templates
@@ -53,12 +163,13 @@ type Filter struct {
 
 // TopicFilter represents all the topic filter information needed by templates
 type TopicFilter struct {
-	LocaliseKeyName    string        `json:"localise_key_name,omitempty"`
-	DistinctItemsCount int           `json:"distinct_items_count,omitempty"`
-	Query              string        `json:"query,omitempty"`
-	IsChecked          bool          `json:"is_checked,omitempty"`
-	NumberOfResults    int           `json:"number_of_results,omitempty"`
-	Types              []TopicFilter `json:"subtopics,omitempty"`
+	LocaliseKeyName    string            `json:"localise_key_name,omitempty"`
+	DistinctItemsCount int               `json:"distinct_items_count,omitempty"`
+	Query              string            `json:"query,omitempty"`
+	IsChecked          bool              `json:"is_checked,omitempty"`
+	NumberOfResults    int               `json:"number_of_results,omitempty"`
+	Types              []TopicFilter     `json:"subtopics,omitempty"`
+	ScheduledRelease   *ScheduledRelease `json:"scheduled_release,omitempty"`
 }
 
 type PopulationTypeFilter struct {
@@ -119,12 +230,17 @@ type ContentType struct {
 
 // ContentItem represents each search result
 type ContentItem struct {
-	Type            ContentItemType `json:"type"`
-	Dataset         Dataset         `json:"dataset"`
-	Description     Description     `json:"description"`
-	URI             string          `json:"uri"`
-	Matches         *Matches        `json:"matches,omitempty"`
-	IsLatestRelease bool            `json:"is_latest_release"`
+	Type        ContentItemType `json:"type"`
+	Dataset     Dataset         `json:"dataset"`
+	Description Description     `json:"description"`
+	URI         string          `json:"uri"`
+	// ClickURI is the link to the result which records the click on it, if clicks are tracked
+	ClickURI         string            `json:"click_uri,omitempty"`
+	Matches          *Matches          `json:"matches,omitempty"`
+	IsLatestRelease  bool              `json:"is_latest_release"`
+	InCollection     bool              `json:"in_collection,omitempty"`
+	NewInCollection  bool              `json:"new_in_collection,omitempty"`
+	ScheduledRelease *ScheduledRelease `json:"scheduled_release,omitempty"`
 }
 
 // ContentItemType represents the type of each search result
@@ -160,6 +276,10 @@ type Description struct {
 	Title             string    `json:"title"`
 	Unit              string    `json:"unit,omitempty"`
 	Highlight         Highlight `json:"hightlight"`
+	Cancelled         bool      `json:"cancelled,omitempty"`
+	Finalised         bool      `json:"finalised,omitempty"`
+	Published         bool      `json:"published,omitempty"`
+	ProvisionalDate   string    `json:"provisional_date,omitempty"`
 }
 
 // Hightlight contains specfic metadata with search keyword(s) highlighted
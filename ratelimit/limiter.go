@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Budgets which requests are counted against, so that a client reading feeds or exporting does not use up its budget
+// for searching
+const (
+	BudgetSearch = "search"
+	BudgetRSS    = "rss"
+	BudgetExport = "export"
+)
+
+// Config is the budgets of each client and the clients which are never limited
+type Config struct {
+	// Limits are the limits of each budget, a budget without a limit or with a rate of 0 is not limited
+	Limits map[string]Limit
+	// AllowedIPs are the IP addresses or CIDR ranges of clients which are not limited, e.g. monitors
+	AllowedIPs []string
+	// TrustedProxies are the IP addresses or CIDR ranges of the proxies in front of the service, e.g. the router, whose
+	// X-Forwarded-For addresses are trusted
+	TrustedProxies []string
+}
+
+// Limiter limits the requests made by each client, identified by its IP address, to the budget of the kind of request
+// it makes. The user agent is not used as it is chosen by the client
+type Limiter struct {
+	store       Store
+	limits      map[string]Limit
+	allowedNets []*net.IPNet
+	trustedNets []*net.IPNet
+}
+
+// NewLimiter creates a limiter which keeps the budgets of clients in the store. An error is returned if one of the
+// allowed IPs or trusted proxies is neither an IP address nor a CIDR range
+func NewLimiter(store Store, cfg Config) (*Limiter, error) {
+	l := &Limiter{
+		store:  store,
+		limits: cfg.Limits,
+	}
+
+	for _, allowed := range cfg.AllowedIPs {
+		ipNet, err := parseIPNet(allowed)
+		if err != nil {
+			return nil, err
+		}
+		l.allowedNets = append(l.allowedNets, ipNet)
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		ipNet, err := parseIPNet(proxy)
+		if err != nil {
+			return nil, err
+		}
+		l.trustedNets = append(l.trustedNets, ipNet)
+	}
+
+	return l, nil
+}
+
+// Allow takes a request from the budget of the client making it. If the budget has been used up, false is returned
+// with the wait until the client can make the request again
+func (l *Limiter) Allow(ctx context.Context, req *http.Request) (budget string, allowed bool, retryAfter time.Duration, err error) {
+	budget = Budget(req)
+	limit, limited := l.limits[budget]
+	if !limited || limit.Rate <= 0 || l.isAllowListed(req) {
+		return budget, true, 0, nil
+	}
+
+	key := budget + "|" + l.ClientIP(req)
+	allowed, retryAfter, err = l.store.Take(ctx, key, limit)
+
+	return budget, allowed, retryAfter, err
+}
+
+// isAllowListed returns true if the client making the request is never limited
+func (l *Limiter) isAllowListed(req *http.Request) bool {
+	ip := net.ParseIP(l.ClientIP(req))
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range l.allowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Budget returns the budget the request is counted against. RSS feeds and exports, i.e. iCalendar feeds and related
+// data reports, have budgets of their own and every other page is counted against the search budget
+func Budget(req *http.Request) string {
+	query := req.URL.Query()
+
+	switch {
+	case query.Has("ics"), strings.HasSuffix(req.URL.Path, "/relateddata/report"):
+		return BudgetExport
+	case query.Has("rss"):
+		return BudgetRSS
+	default:
+		return BudgetSearch
+	}
+}
+
+// ClientIP returns the IP address of the client making the request. Each proxy appends the address it was called from
+// to X-Forwarded-For, so the client is the rightmost address, working back from the remote address, which is not a
+// trusted proxy. The addresses before it could have been sent by the client and are ignored
+func (l *Limiter) ClientIP(req *http.Request) string {
+	hops := []string{remoteHost(req)}
+	for _, header := range req.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	client := hops[0]
+	for i := len(hops) - 1; i > 0; i-- {
+		if !l.isTrustedProxy(client) || hops[i] == "" {
+			break
+		}
+		client = hops[i]
+	}
+	return client
+}
+
+// isTrustedProxy returns true if the address is one of the proxies in front of the service
+func (l *Limiter) isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range l.trustedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteHost returns the address the request was made from without its port
+func remoteHost(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// parseIPNet parses the CIDR range, or the IP address as the range of just that address
+func parseIPNet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q: %w", s, err)
+		}
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", s)
+	}
+
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
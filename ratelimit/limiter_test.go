@@ -0,0 +1,208 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newRequest := func(target, ip, userAgent string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		req.RemoteAddr = "10.0.0.2:51234"
+		req.Header.Set("X-Forwarded-For", ip+", 10.0.0.1")
+		req.Header.Set("User-Agent", userAgent)
+		return req
+	}
+
+	Convey("Given a limiter which allows a search, an RSS feed and an export at once", t, func() {
+		limiter, err := NewLimiter(NewMemoryStore(), Config{
+			Limits: map[string]Limit{
+				BudgetSearch: PerMinute(1, 1),
+				BudgetRSS:    PerMinute(1, 1),
+				BudgetExport: PerMinute(1, 1),
+			},
+			AllowedIPs:     []string{"192.0.2.0/24", "2001:db8::1"},
+			TrustedProxies: []string{"10.0.0.0/8"},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a client has used up its search budget", func() {
+			_, allowed, _, err := limiter.Allow(ctx, newRequest("/search?q=a", "203.0.113.7", "scraper"))
+			So(err, ShouldBeNil)
+			So(allowed, ShouldBeTrue)
+
+			budget, allowed, retryAfter, _ := limiter.Allow(ctx, newRequest("/search?q=b&page=50", "203.0.113.7", "scraper"))
+
+			Convey("Then its next search is refused", func() {
+				So(budget, ShouldEqual, BudgetSearch)
+				So(allowed, ShouldBeFalse)
+				So(retryAfter, ShouldBeGreaterThan, 0)
+			})
+
+			Convey("Then it can still request an RSS feed and an export", func() {
+				_, rssAllowed, _, _ := limiter.Allow(ctx, newRequest("/releasecalendar?rss", "203.0.113.7", "scraper"))
+				_, exportAllowed, _, _ := limiter.Allow(ctx, newRequest("/releasecalendar?ics", "203.0.113.7", "scraper"))
+				So(rssAllowed, ShouldBeTrue)
+				So(exportAllowed, ShouldBeTrue)
+			})
+
+			Convey("Then it cannot get a new budget by sending another address forwarded for", func() {
+				_, allowed, _, _ := limiter.Allow(ctx, newRequest("/search?q=c", "198.51.100.1, 203.0.113.7", "scraper"))
+				So(allowed, ShouldBeFalse)
+			})
+
+			Convey("Then it cannot get a new budget by sending another user agent", func() {
+				_, allowed, _, _ := limiter.Allow(ctx, newRequest("/search?q=c", "203.0.113.7", "browser"))
+				So(allowed, ShouldBeFalse)
+			})
+		})
+
+		Convey("When allow-listed monitors make many searches", func() {
+			refused := 0
+			for range 3 {
+				for _, req := range []*http.Request{
+					newRequest("/search", "192.0.2.10", "monitor"),
+					newRequest("/search", "2001:db8::1", "monitor"),
+				} {
+					if _, allowed, _, _ := limiter.Allow(ctx, req); !allowed {
+						refused++
+					}
+				}
+			}
+
+			Convey("Then none of them are refused", func() {
+				So(refused, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a client sends the user agent of a monitor", func() {
+			refused := 0
+			for range 3 {
+				if _, allowed, _, _ := limiter.Allow(ctx, newRequest("/search", "203.0.113.8", "Mozilla/5.0 (compatible; pingdom.com_bot)")); !allowed {
+					refused++
+				}
+			}
+
+			Convey("Then it is limited", func() {
+				So(refused, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a client which sends the address of an allow-listed monitor forwarded for", t, func() {
+		limiter, err := NewLimiter(NewMemoryStore(), Config{
+			Limits:         map[string]Limit{BudgetSearch: PerMinute(1, 1)},
+			AllowedIPs:     []string{"192.0.2.10"},
+			TrustedProxies: []string{"10.0.0.0/8"},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When it makes many searches", func() {
+			refused := 0
+			for range 3 {
+				if _, allowed, _, _ := limiter.Allow(ctx, newRequest("/search", "192.0.2.10, 203.0.113.7", "scraper")); !allowed {
+					refused++
+				}
+			}
+
+			Convey("Then it is limited as itself", func() {
+				So(refused, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given an allowed IP which is not an IP address", t, func() {
+		_, err := NewLimiter(NewMemoryStore(), Config{AllowedIPs: []string{"monitor.example.com"}})
+
+		Convey("Then the limiter cannot be created", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a trusted proxy which is not an IP address", t, func() {
+		_, err := NewLimiter(NewMemoryStore(), Config{TrustedProxies: []string{"router.example.com"}})
+
+		Convey("Then the limiter cannot be created", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given requests for pages, feeds and exports", t, func() {
+		Convey("Then feeds and exports are counted against budgets of their own", func() {
+			So(Budget(httptest.NewRequest(http.MethodGet, "/search?q=cpi&page=3", http.NoBody)), ShouldEqual, BudgetSearch)
+			So(Budget(httptest.NewRequest(http.MethodGet, "/alladhocs?rss", http.NoBody)), ShouldEqual, BudgetRSS)
+			So(Budget(httptest.NewRequest(http.MethodGet, "/releasecalendar?ics", http.NoBody)), ShouldEqual, BudgetExport)
+			So(Budget(httptest.NewRequest(http.MethodGet, "/economy/bulletins/gdp/relateddata/report", http.NoBody)), ShouldEqual, BudgetExport)
+		})
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewLimiter(NewMemoryStore(), Config{TrustedProxies: []string{"10.0.0.0/8", "2001:db8::/48"}})
+	if err != nil {
+		t.Fatalf("failed to create limiter: %v", err)
+	}
+
+	newRequest := func(remoteAddr string, forwardedFor ...string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		for _, header := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", header)
+		}
+		return req
+	}
+
+	Convey("Given a request forwarded by the router through trusted proxies", t, func() {
+		req := newRequest("10.0.0.2:51234", "203.0.113.7, 10.0.0.1")
+
+		Convey("Then the client is the rightmost address which is not a trusted proxy", func() {
+			So(limiter.ClientIP(req), ShouldEqual, "203.0.113.7")
+		})
+	})
+
+	Convey("Given a request with addresses forwarded for sent by the client", t, func() {
+		req := newRequest("10.0.0.2:51234", "198.51.100.1, 192.0.2.10", "203.0.113.7, 10.0.0.1")
+
+		Convey("Then the spoofed addresses are ignored", func() {
+			So(limiter.ClientIP(req), ShouldEqual, "203.0.113.7")
+		})
+	})
+
+	Convey("Given a request forwarded through IPv6 proxies", t, func() {
+		req := newRequest("[2001:db8::2]:51234", "2001:db8:ffff::7")
+
+		Convey("Then the client is the rightmost address which is not a trusted proxy", func() {
+			So(limiter.ClientIP(req), ShouldEqual, "2001:db8:ffff::7")
+		})
+	})
+
+	Convey("Given a request made directly with addresses forwarded for", t, func() {
+		req := newRequest("203.0.113.7:51234", "198.51.100.1")
+
+		Convey("Then the client is the remote address without its port", func() {
+			So(limiter.ClientIP(req), ShouldEqual, "203.0.113.7")
+		})
+	})
+
+	Convey("Given a request made only through trusted proxies", t, func() {
+		req := newRequest("10.0.0.2:51234", "10.0.0.1")
+
+		Convey("Then the client is the leftmost proxy", func() {
+			So(limiter.ClientIP(req), ShouldEqual, "10.0.0.1")
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store removes the buckets of clients which have stopped making requests
+const sweepInterval = time.Minute
+
+// Limit is a token bucket budget, which allows Burst requests at once and is refilled at Rate requests a second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns the limit which allows the number of requests a minute, with bursts of up to burst requests
+func PerMinute(requests, burst int) Limit {
+	return Limit{
+		Rate:  float64(requests) / time.Minute.Seconds(),
+		Burst: max(burst, 1),
+	}
+}
+
+// Store keeps the token buckets of clients. A store shared by every instance of the service, e.g. in redis, can be
+// used instead of the memory store so that a client has the same budget whichever instance serves it
+type Store interface {
+	// Take takes a token from the bucket for the key, returning false with the wait until the next token if it is empty
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// bucket is the token bucket of a client
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket will have been refilled, after which it can be removed as it would be created full
+	full time.Time
+}
+
+// MemoryStore is a Store which keeps the token buckets in memory, so each instance of the service has its own budgets
+type MemoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Take takes a token from the bucket for the key, which is refilled for the time since it was last taken from
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	} else {
+		retryAfter = time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+
+	b.full = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+
+	return allowed, retryAfter, nil
+}
+
+// Len returns the number of buckets in the store
+func (s *MemoryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.buckets)
+}
+
+// sweep removes the buckets which have been refilled, as they are no different to the buckets of new clients
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
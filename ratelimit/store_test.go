@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a memory store and a limit of 2 requests at once, refilled at a request a second", t, func() {
+		now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		store := NewMemoryStore()
+		store.now = func() time.Time { return now }
+		limit := Limit{Rate: 1, Burst: 2}
+
+		Convey("When a client makes more requests at once than its burst", func() {
+			first, _, err := store.Take(ctx, "client", limit)
+			So(err, ShouldBeNil)
+			second, _, _ := store.Take(ctx, "client", limit)
+			third, retryAfter, _ := store.Take(ctx, "client", limit)
+
+			Convey("Then the requests beyond the burst are refused until the bucket is refilled", func() {
+				So(first, ShouldBeTrue)
+				So(second, ShouldBeTrue)
+				So(third, ShouldBeFalse)
+				So(retryAfter, ShouldEqual, time.Second)
+			})
+
+			Convey("Then another client has a budget of its own", func() {
+				allowed, _, _ := store.Take(ctx, "other client", limit)
+				So(allowed, ShouldBeTrue)
+			})
+
+			Convey("Then a request is allowed once the bucket has been refilled with a token", func() {
+				now = now.Add(time.Second)
+				allowed, _, _ := store.Take(ctx, "client", limit)
+				So(allowed, ShouldBeTrue)
+			})
+		})
+
+		Convey("When clients stop making requests for long enough for their buckets to be refilled", func() {
+			store.Take(ctx, "client", limit)
+			store.Take(ctx, "other client", limit)
+			So(store.Len(), ShouldEqual, 2)
+
+			now = now.Add(sweepInterval)
+			store.Take(ctx, "new client", limit)
+
+			Convey("Then their buckets are removed", func() {
+				So(store.Len(), ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestPerMinute(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a limit of 60 requests a minute", t, func() {
+		Convey("Then it is refilled at a request a second", func() {
+			So(PerMinute(60, 20), ShouldResemble, Limit{Rate: 1, Burst: 20})
+		})
+
+		Convey("Then at least one request is allowed at once", func() {
+			So(PerMinute(60, 0).Burst, ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,145 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces the values which are redacted
+const Mask = "[REDACTED]"
+
+// sensitiveKeys are the keys, once lowercased without separators, whose values are always redacted, e.g. the
+// Authorization and X-Florence-Token headers
+var sensitiveKeys = map[string]struct{}{
+	"authorization": {},
+	"cookie":        {},
+	"setcookie":     {},
+}
+
+// sensitiveSuffixes are the endings of the keys, once lowercased without separators, whose values are always redacted,
+// e.g. ServiceAuthToken, access_token or the florence token
+var sensitiveSuffixes = []string{"token", "secret", "password"}
+
+// bearerPattern matches the credentials of an Authorization header
+var bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[^\s"'&,;]+`)
+
+// Config is what is redacted from log lines on top of credentials
+type Config struct {
+	// QueryParams are the query parameters whose values are redacted, e.g. the search query typed by users
+	QueryParams []string
+	// Secrets are values which are redacted wherever they appear, e.g. the service auth token
+	Secrets []string
+}
+
+// Redactor masks credentials, secrets and query parameters in structured log lines
+type Redactor struct {
+	queryParams  map[string]struct{}
+	paramPattern *regexp.Regexp
+	secrets      []string
+}
+
+// New creates a redactor which masks the query parameters and secrets of the config as well as credentials
+func New(cfg Config) *Redactor {
+	r := &Redactor{queryParams: map[string]struct{}{}}
+
+	names := make([]string, 0, len(cfg.QueryParams))
+	for _, param := range cfg.QueryParams {
+		if param == "" {
+			continue
+		}
+		r.queryParams[param] = struct{}{}
+		names = append(names, regexp.QuoteMeta(param))
+	}
+
+	// access tokens can also be given as query parameters
+	names = append(names, `[\w.-]*(?i:token|secret|password)`)
+
+	// the separators include & escaped as JSON does
+	r.paramPattern = regexp.MustCompile(`(^|[?&;\s"]|\\u0026)(` + strings.Join(names, "|") + `)=[^&#;\s"\\]*`)
+
+	for _, secret := range cfg.Secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	return r
+}
+
+// Line returns the log line with its sensitive values masked. A line of JSON has the values of its sensitive keys
+// masked as well as sensitive text in any of its strings, any other line only has its sensitive text masked
+func (r *Redactor) Line(line []byte) []byte {
+	trimmed := bytes.TrimRight(line, "\n")
+
+	var event map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil || decoder.More() {
+		return []byte(r.String(string(line)))
+	}
+
+	b, err := json.Marshal(r.value(event))
+	if err != nil {
+		return []byte(r.String(string(line)))
+	}
+
+	return append(b, line[len(trimmed):]...)
+}
+
+// String returns the text with its credentials, secrets and redacted query parameters masked
+func (r *Redactor) String(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+Mask)
+
+	return r.paramPattern.ReplaceAllString(s, "${1}${2}="+Mask)
+}
+
+// value returns the value decoded from JSON with its sensitive values masked
+func (r *Redactor) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if r.isSensitive(key) {
+				v[key] = Mask
+				continue
+			}
+			v[key] = r.value(nested)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+		return v
+	case string:
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+// isSensitive returns true if the values of the key are redacted, e.g. a header holding credentials or a url.Values
+// entry of a redacted query parameter
+func (r *Redactor) isSensitive(key string) bool {
+	if _, ok := r.queryParams[key]; ok {
+		return true
+	}
+
+	normalised := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(key))
+	if _, ok := sensitiveKeys[normalised]; ok {
+		return true
+	}
+
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(normalised, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
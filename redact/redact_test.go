@@ -0,0 +1,89 @@
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a redactor of the search query and the service auth token", t, func() {
+		redactor := New(Config{QueryParams: []string{"q"}, Secrets: []string{"service-secret"}})
+
+		Convey("When a log line of JSON with credentials, the query and the service auth token is redacted", func() {
+			line := []byte(`{"created_at":"2026-10-19T10:00:00Z","event":"request failed","data":{"headers":{"Authorization":"Bearer user-secret",` +
+				`"X-Florence-Token":"florence-secret"},"url_values":{"q":["jane doe"],"page":["2"]},"access_token":"florence-secret",` +
+				`"config":{"ServiceAuthToken":"service-secret","SearchTimeout":5000}},"http":{"path":"/search","query":"q=jane+doe&page=2"},` +
+				`"errors":[{"message":"failed to call http://search-api/search?page=2&q=jane+doe with token service-secret"}]}` + "\n")
+
+			redacted := redactor.Line(line)
+
+			Convey("Then it is still a line of JSON", func() {
+				So(redacted[len(redacted)-1], ShouldEqual, '\n')
+
+				var event map[string]any
+				So(json.Unmarshal(redacted, &event), ShouldBeNil)
+				So(event["event"], ShouldEqual, "request failed")
+			})
+
+			Convey("Then the values of the credentials and the query are masked", func() {
+				var event struct {
+					Data struct {
+						Headers     map[string]string `json:"headers"`
+						URLValues   map[string]any    `json:"url_values"`
+						AccessToken string            `json:"access_token"`
+						Config      map[string]any    `json:"config"`
+					} `json:"data"`
+					HTTP struct {
+						Query string `json:"query"`
+					} `json:"http"`
+					Errors []struct {
+						Message string `json:"message"`
+					} `json:"errors"`
+				}
+				So(json.Unmarshal(redacted, &event), ShouldBeNil)
+
+				So(event.Data.Headers, ShouldResemble, map[string]string{"Authorization": Mask, "X-Florence-Token": Mask})
+				So(event.Data.URLValues["page"], ShouldResemble, []any{"2"})
+				So(event.Data.URLValues["q"], ShouldEqual, Mask)
+				So(event.Data.AccessToken, ShouldEqual, Mask)
+				So(event.Data.Config["ServiceAuthToken"], ShouldEqual, Mask)
+				So(event.Data.Config["SearchTimeout"], ShouldEqual, 5000)
+				So(event.HTTP.Query, ShouldEqual, "q="+Mask+"&page=2")
+				So(event.Errors[0].Message, ShouldEqual, "failed to call http://search-api/search?page=2&q="+Mask+" with token "+Mask)
+			})
+
+			Convey("Then no secret or query is left in the line", func() {
+				So(string(redacted), ShouldNotContainSubstring, "secret")
+				So(string(redacted), ShouldNotContainSubstring, "jane")
+			})
+		})
+
+		Convey("When a line which is not JSON is redacted", func() {
+			redacted := redactor.Line([]byte("GET /search?q=jane+doe&page=2 Authorization: Bearer user-secret\n"))
+
+			Convey("Then its sensitive text is masked", func() {
+				So(string(redacted), ShouldEqual, "GET /search?q="+Mask+"&page=2 Authorization: Bearer "+Mask+"\n")
+			})
+		})
+	})
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a redactor without query parameters or secrets", t, func() {
+		redactor := New(Config{})
+
+		Convey("Then the query is kept but access tokens given as query parameters are masked", func() {
+			So(redactor.String("/search?q=inflation&access_token=florence-secret"), ShouldEqual, "/search?q=inflation&access_token="+Mask)
+		})
+
+		Convey("Then text without credentials is unchanged", func() {
+			So(redactor.String("failed to get census topic"), ShouldEqual, "failed to get census topic")
+		})
+	})
+}
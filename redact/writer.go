@@ -0,0 +1,27 @@
+package redact
+
+import "io"
+
+// Writer masks the sensitive values of each log line before writing it to the destination. It is set as the
+// destination of the logs, which writes each event as a single line
+type Writer struct {
+	redactor *Redactor
+	dest     io.Writer
+}
+
+// NewWriter creates a writer which writes log lines to the destination once the redactor has masked them
+func NewWriter(redactor *Redactor, dest io.Writer) *Writer {
+	return &Writer{
+		redactor: redactor,
+		dest:     dest,
+	}
+}
+
+// Write writes the log line once masked. The length of the line given is returned on success, as the logger checks
+// that the whole of its line has been written
+func (w *Writer) Write(p []byte) (int, error) {
+	if _, err := w.dest.Write(w.redactor.Line(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
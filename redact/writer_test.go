@@ -0,0 +1,31 @@
+package redact
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a writer which redacts the search query", t, func() {
+		var dest bytes.Buffer
+		w := NewWriter(New(Config{QueryParams: []string{"q"}}), &dest)
+
+		Convey("When a log line is written", func() {
+			line := []byte(`{"event":"search","data":{"q":"jane doe"}}` + "\n")
+			n, err := w.Write(line)
+
+			Convey("Then the line is written once redacted", func() {
+				So(dest.String(), ShouldEqual, `{"data":{"q":"[REDACTED]"},"event":"search"}`+"\n")
+			})
+
+			Convey("Then the length of the line given is returned, as the logger expects", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, len(line))
+			})
+		})
+	})
+}
@@ -0,0 +1,127 @@
+package redirect
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// LegacyURL maps an old babbage url to its current equivalent
+type LegacyURL struct {
+	// Path is the path of the legacy url
+	Path string
+	// Target is the current path the legacy path is redirected to, or empty if the path has not changed
+	Target string
+	// Params are the legacy query parameters which are renamed
+	Params []LegacyParam
+}
+
+// LegacyParam maps a legacy query parameter to its current name and, optionally, its values to current values
+type LegacyParam struct {
+	Name   string
+	Target string
+	Values map[string]string
+}
+
+// legacyParams are the query parameters used by the babbage search and list pages
+var legacyParams = []LegacyParam{
+	{Name: "query", Target: "q"},
+	{Name: "size", Target: "limit"},
+	{
+		Name:   "sortBy",
+		Target: "sort",
+		Values: map[string]string{
+			"first_letter": "title",
+		},
+	},
+}
+
+// LegacyURLs are the babbage urls, and their query parameters, which are redirected to the current search and list pages
+var LegacyURLs = []LegacyURL{
+	{Path: "/searchdata", Target: "/datalist", Params: legacyParams},
+	{Path: "/searchpublication", Target: "/publications", Params: legacyParams},
+	{Path: "/search", Params: legacyParams},
+	{Path: "/datalist", Params: legacyParams},
+	{Path: "/publications", Params: legacyParams},
+}
+
+// Legacy permanently redirects requests for the legacy urls to their current equivalents. Requests for any other url
+// are passed on
+func Legacy(legacyURLs []LegacyURL) func(http.Handler) http.Handler {
+	urls := make(map[string]LegacyURL, len(legacyURLs))
+	for _, legacyURL := range legacyURLs {
+		urls[legacyURL.Path] = legacyURL
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			legacyURL, ok := urls[strings.TrimSuffix(req.URL.Path, "/")]
+			if !ok {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			location, ok := legacyURL.location(req.URL)
+			if !ok {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			log.Info(req.Context(), "redirecting legacy url", log.Data{
+				"path":     req.URL.Path,
+				"location": location,
+			})
+
+			http.Redirect(w, req, location, http.StatusMovedPermanently)
+		})
+	}
+}
+
+// location returns the current equivalent of the requested url, or false if the url is already current
+func (l LegacyURL) location(requestURL *url.URL) (string, bool) {
+	query := requestURL.Query()
+	changed := l.Target != ""
+
+	for _, param := range l.Params {
+		values, ok := query[param.Name]
+		if !ok {
+			continue
+		}
+
+		query.Del(param.Name)
+		changed = true
+
+		// the current parameter takes precedence over the legacy one
+		if query.Has(param.Target) {
+			continue
+		}
+
+		for _, value := range values {
+			if current, ok := param.Values[value]; ok {
+				value = current
+			}
+			query.Add(param.Target, value)
+		}
+	}
+
+	if !changed {
+		return "", false
+	}
+
+	location := url.URL{
+		Path:     requestURL.Path,
+		RawQuery: query.Encode(),
+	}
+	if l.Target != "" {
+		location.Path = l.Target
+	}
+
+	return location.String(), true
+}
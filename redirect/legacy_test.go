@@ -0,0 +1,76 @@
+package redirect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLegacy(t *testing.T) {
+	t.Parallel()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := Legacy(LegacyURLs)(nextHandler)
+
+	redirectTests := []struct {
+		name     string
+		url      string
+		location string
+	}{
+		{name: "legacy data search path", url: "/searchdata", location: "/datalist"},
+		{name: "legacy data search path with trailing slash", url: "/searchdata/", location: "/datalist"},
+		{name: "legacy publication search path", url: "/searchpublication", location: "/publications"},
+		{name: "legacy path and parameters", url: "/searchdata?query=gdp&size=25&sortBy=release_date&page=2", location: "/datalist?limit=25&page=2&q=gdp&sort=release_date"},
+		{name: "legacy query parameter", url: "/search?query=inflation", location: "/search?q=inflation"},
+		{name: "legacy size parameter", url: "/publications?size=50", location: "/publications?limit=50"},
+		{name: "legacy sort value", url: "/datalist?sortBy=first_letter", location: "/datalist?sort=title"},
+		{name: "current parameter takes precedence", url: "/search?query=old&q=new", location: "/search?q=new"},
+		{name: "unknown parameters are kept", url: "/search?query=cpi&filter=bulletin", location: "/search?filter=bulletin&q=cpi"},
+	}
+
+	Convey("Given the legacy url compatibility layer", t, func() {
+		for _, tc := range redirectTests {
+			Convey("When the "+tc.name+" is requested", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, tc.url, http.NoBody)
+				handler.ServeHTTP(w, req)
+
+				Convey("Then the request is permanently redirected to "+tc.location, func() {
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+					So(w.Header().Get("Location"), ShouldEqual, tc.location)
+				})
+			})
+		}
+	})
+
+	passThroughTests := []struct {
+		name   string
+		method string
+		url    string
+	}{
+		{name: "current search url", method: http.MethodGet, url: "/search?q=inflation&limit=10"},
+		{name: "current list url", method: http.MethodGet, url: "/datalist?sort=title"},
+		{name: "url with no legacy mapping", method: http.MethodGet, url: "/economy/previousreleases?size=10"},
+		{name: "legacy url with a non GET method", method: http.MethodPost, url: "/searchdata?query=gdp"},
+	}
+
+	Convey("Given the legacy url compatibility layer", t, func() {
+		for _, tc := range passThroughTests {
+			Convey("When the "+tc.name+" is requested", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest(tc.method, tc.url, http.NoBody)
+				handler.ServeHTTP(w, req)
+
+				Convey("Then the request is passed on", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Header().Get("Location"), ShouldBeEmpty)
+				})
+			})
+		}
+	})
+}
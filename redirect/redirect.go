@@ -0,0 +1,79 @@
+package redirect
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Redirector redirects requests for pages which have been migrated to the new site. Each route is redirected to the
+// migration link of its page followed by the destination suffix of the route, keeping only the query parameters which
+// are safe to pass on. The number of requests redirected from each route is recorded in the metrics
+type Redirector struct {
+	suffixes    map[string]string
+	queryParams []string
+}
+
+// New creates a Redirector which redirects the routes to the destination suffixes given, keeping the query parameters given
+func New(suffixes map[string]string, queryParams []string) *Redirector {
+	return &Redirector{
+		suffixes:    suffixes,
+		queryParams: queryParams,
+	}
+}
+
+// Redirect permanently redirects a request for the route of a page which has a migration link. It returns whether the
+// request was redirected
+func (r *Redirector) Redirect(w http.ResponseWriter, req *http.Request, route, migrationLink string) bool {
+	location, ok := r.Location(req, route, migrationLink)
+	if !ok {
+		return false
+	}
+
+	metrics.MigrationRedirects.WithLabelValues(route).Inc()
+
+	log.Info(req.Context(), "redirecting to migration link", log.Data{
+		"route":    route,
+		"path":     req.URL.Path,
+		"location": location,
+	})
+
+	http.Redirect(w, req, location, http.StatusPermanentRedirect)
+	return true
+}
+
+// Location returns where a request for the route of a page with the migration link is redirected to, or false if the
+// page has not been migrated or the route has no destination
+func (r *Redirector) Location(req *http.Request, route, migrationLink string) (string, bool) {
+	if migrationLink == "" {
+		return "", false
+	}
+
+	suffix, ok := r.suffixes[route]
+	if !ok {
+		return "", false
+	}
+
+	location, err := url.Parse(migrationLink)
+	if err != nil {
+		log.Warn(req.Context(), "invalid migration link, request will not be redirected", log.FormatErrors([]error{err}), log.Data{
+			"route":          route,
+			"migration_link": migrationLink,
+		})
+		return "", false
+	}
+
+	location = location.JoinPath(suffix)
+
+	query := location.Query()
+	for _, param := range r.queryParams {
+		if values, ok := req.URL.Query()[param]; ok && !query.Has(param) {
+			query[param] = values
+		}
+	}
+	location.RawQuery = query.Encode()
+
+	return location.String(), true
+}
@@ -0,0 +1,119 @@
+package redirect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedirect(t *testing.T) {
+	t.Parallel()
+
+	suffixes := map[string]string{
+		"previousreleases": "/editions",
+		"relateddata":      "/related-data",
+	}
+
+	Convey("Given a redirector", t, func() {
+		r := New(suffixes, []string{"page"})
+		redirects := testutil.ToFloat64(metrics.MigrationRedirects.WithLabelValues("previousreleases"))
+
+		Convey("When a request for a route with a migration link is redirected", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/economy/previousreleases?page=2&sort=title", http.NoBody)
+
+			redirected := r.Redirect(w, req, "previousreleases", "/my-new-bulletin")
+
+			Convey("Then the request is permanently redirected to the migration link with the route suffix", func() {
+				So(redirected, ShouldBeTrue)
+				So(w.Code, ShouldEqual, http.StatusPermanentRedirect)
+				So(w.Header().Get("Location"), ShouldEqual, "/my-new-bulletin/editions?page=2")
+			})
+
+			Convey("And the redirect is counted against the route", func() {
+				So(testutil.ToFloat64(metrics.MigrationRedirects.WithLabelValues("previousreleases")), ShouldEqual, redirects+1)
+			})
+		})
+
+		Convey("When the page has no migration link", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/economy/previousreleases", http.NoBody)
+
+			redirected := r.Redirect(w, req, "previousreleases", "")
+
+			Convey("Then the request is not redirected or counted", func() {
+				So(redirected, ShouldBeFalse)
+				So(w.Header().Get("Location"), ShouldBeEmpty)
+				So(testutil.ToFloat64(metrics.MigrationRedirects.WithLabelValues("previousreleases")), ShouldEqual, redirects)
+			})
+		})
+
+		Convey("When the route has no destination suffix", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/economy/timeseries", http.NoBody)
+
+			redirected := r.Redirect(w, req, "timeseries", "/my-new-bulletin")
+
+			Convey("Then the request is not redirected or counted", func() {
+				So(redirected, ShouldBeFalse)
+				So(testutil.ToFloat64(metrics.MigrationRedirects.WithLabelValues("timeseries")), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a redirector which keeps the page query parameter", t, func() {
+		r := New(map[string]string{"relateddata": "/related-data"}, []string{"page"})
+
+		Convey("When the request has no query", func() {
+			req := httptest.NewRequest(http.MethodGet, "/economy/bulletin/latest/relateddata", http.NoBody)
+
+			location, ok := r.Location(req, "relateddata", "/new-weekly-earnings")
+
+			Convey("Then the location has no query", func() {
+				So(ok, ShouldBeTrue)
+				So(location, ShouldEqual, "/new-weekly-earnings/related-data")
+			})
+		})
+
+		Convey("When the request has query parameters which are not kept", func() {
+			req := httptest.NewRequest(http.MethodGet, "/economy/bulletin/latest/relateddata?filter=datasets&sort=title", http.NoBody)
+
+			location, ok := r.Location(req, "relateddata", "/new-weekly-earnings")
+
+			Convey("Then they are dropped from the location", func() {
+				So(ok, ShouldBeTrue)
+				So(location, ShouldEqual, "/new-weekly-earnings/related-data")
+			})
+		})
+
+		Convey("When the migration link already has the query parameter", func() {
+			req := httptest.NewRequest(http.MethodGet, "/economy/bulletin/latest/relateddata?page=4", http.NoBody)
+
+			location, ok := r.Location(req, "relateddata", "https://www.ons.gov.uk/new-weekly-earnings?page=1")
+
+			Convey("Then the query parameter of the migration link is kept", func() {
+				So(ok, ShouldBeTrue)
+				So(location, ShouldEqual, "https://www.ons.gov.uk/new-weekly-earnings/related-data?page=1")
+			})
+		})
+
+		Convey("When the migration link is invalid", func() {
+			req := httptest.NewRequest(http.MethodGet, "/economy/bulletin/latest/relateddata", http.NoBody)
+
+			location, ok := r.Location(req, "relateddata", "://invalid%%")
+
+			Convey("Then there is no location", func() {
+				So(ok, ShouldBeFalse)
+				So(location, ShouldBeEmpty)
+			})
+		})
+	})
+}
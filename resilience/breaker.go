@@ -0,0 +1,196 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets calls through, counting the consecutive failures
+	StateClosed State = iota
+	// StateOpen fails calls without making them until the open timeout has passed
+	StateOpen
+	// StateHalfOpen lets a single trial call through to find out whether the dependency has recovered
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// UnavailableError is returned instead of calling a dependency whose circuit breaker is open. It has the status of the
+// errors of each client so that it can be returned by any of them
+type UnavailableError struct {
+	Dependency string
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%s is unavailable: circuit breaker is open", e.Dependency)
+}
+
+// Status returns the status of the error, as the search and topic api errors do
+func (e *UnavailableError) Status() int {
+	return http.StatusServiceUnavailable
+}
+
+// Code returns the status of the error, as the zebedee errors do
+func (e *UnavailableError) Code() int {
+	return http.StatusServiceUnavailable
+}
+
+// IsUnavailable returns true if the error is from a dependency whose circuit breaker is open
+func IsUnavailable(err error) bool {
+	var unavailableErr *UnavailableError
+	return errors.As(err, &unavailableErr)
+}
+
+// BreakerConfig is the configuration of a circuit breaker
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures which opens the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a trial call is let through
+	OpenTimeout time.Duration
+}
+
+// Breaker is the circuit breaker of a dependency, which stops calling it once it keeps failing so that requests fail
+// fast rather than waiting on it
+type Breaker struct {
+	name     string
+	cfg      BreakerConfig
+	mutex    *sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+// NewBreaker creates a closed circuit breaker for the named dependency
+func NewBreaker(name string, cfg BreakerConfig) *Breaker {
+	return &Breaker{
+		name:  name,
+		cfg:   cfg,
+		mutex: &sync.Mutex{},
+		state: StateClosed,
+		now:   time.Now,
+	}
+}
+
+// Name returns the name of the dependency of the breaker
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the state of the breaker
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+// Allow returns an UnavailableError if the dependency should not be called, otherwise the outcome of the call must be
+// passed to Record
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return &UnavailableError{Dependency: b.name}
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+	case StateHalfOpen:
+		if b.trial {
+			return &UnavailableError{Dependency: b.name}
+		}
+		b.trial = true
+	}
+
+	return nil
+}
+
+// Record records the outcome of a call let through by Allow, closing the breaker if it succeeded and opening it if
+// too many calls have failed in a row
+func (b *Breaker) Record(ctx context.Context, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.trial = false
+
+	if !IsFailure(err) {
+		if b.state != StateClosed {
+			log.Info(ctx, "circuit breaker closed", log.Data{"dependency": b.name})
+		}
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		if b.state != StateOpen {
+			log.Warn(ctx, "circuit breaker opened", log.FormatErrors([]error{err}), log.Data{
+				"dependency":   b.name,
+				"failures":     b.failures,
+				"open_timeout": b.cfg.OpenTimeout.String(),
+			})
+		}
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Checker returns a healthcheck checker which reports WARNING while the breaker is open or half-open, and OK when it
+// is closed. An open breaker is not CRITICAL as restarting the service will not bring the dependency back
+func (b *Breaker) Checker() healthcheck.Checker {
+	return func(ctx context.Context, state *healthcheck.CheckState) error {
+		switch b.State() {
+		case StateOpen:
+			return state.Update(healthcheck.StatusWarning, fmt.Sprintf("%s circuit breaker is open", b.name), 0)
+		case StateHalfOpen:
+			return state.Update(healthcheck.StatusWarning, fmt.Sprintf("%s circuit breaker is half-open", b.name), 0)
+		default:
+			return state.Update(healthcheck.StatusOK, fmt.Sprintf("%s circuit breaker is closed", b.name), 0)
+		}
+	}
+}
+
+// IsFailure returns true if the error means the dependency has failed, rather than the request made to it being
+// invalid or given up on by the caller
+func IsFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr interface{ Status() int }
+	if errors.As(err, &statusErr) {
+		return statusErr.Status() >= http.StatusInternalServerError
+	}
+
+	var codeErr interface{ Code() int }
+	if errors.As(err, &codeErr) {
+		return codeErr.Code() >= http.StatusInternalServerError
+	}
+
+	return true
+}
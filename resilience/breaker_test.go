@@ -0,0 +1,167 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errDependency = errors.New("connection refused")
+
+func newTestBreaker(now *time.Time) *Breaker {
+	b := NewBreaker("Search API", BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a closed circuit breaker", t, func() {
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		b := newTestBreaker(&now)
+
+		Convey("Then calls are allowed", func() {
+			So(b.Allow(), ShouldBeNil)
+			So(b.State(), ShouldEqual, StateClosed)
+		})
+
+		Convey("When fewer calls than the threshold fail in a row", func() {
+			b.Record(ctx, errDependency)
+			b.Record(ctx, nil)
+			b.Record(ctx, errDependency)
+
+			Convey("Then the breaker stays closed", func() {
+				So(b.State(), ShouldEqual, StateClosed)
+				So(b.Allow(), ShouldBeNil)
+			})
+		})
+
+		Convey("When calls fail with client errors", func() {
+			b.Record(ctx, searchError.StatusError{Code: http.StatusBadRequest})
+			b.Record(ctx, searchError.StatusError{Code: http.StatusNotFound})
+
+			Convey("Then the breaker stays closed", func() {
+				So(b.State(), ShouldEqual, StateClosed)
+			})
+		})
+
+		Convey("When as many calls as the threshold fail in a row", func() {
+			b.Record(ctx, errDependency)
+			b.Record(ctx, searchError.StatusError{Code: http.StatusBadGateway})
+
+			Convey("Then the breaker opens and calls are not allowed", func() {
+				So(b.State(), ShouldEqual, StateOpen)
+
+				err := b.Allow()
+				So(IsUnavailable(err), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "Search API is unavailable: circuit breaker is open")
+			})
+
+			Convey("And the open timeout passes", func() {
+				now = now.Add(time.Minute)
+
+				Convey("Then a single trial call is allowed", func() {
+					So(b.Allow(), ShouldBeNil)
+					So(b.State(), ShouldEqual, StateHalfOpen)
+					So(IsUnavailable(b.Allow()), ShouldBeTrue)
+				})
+
+				Convey("And the trial call succeeds", func() {
+					So(b.Allow(), ShouldBeNil)
+					b.Record(ctx, nil)
+
+					Convey("Then the breaker closes", func() {
+						So(b.State(), ShouldEqual, StateClosed)
+						So(b.Allow(), ShouldBeNil)
+					})
+				})
+
+				Convey("And the trial call fails", func() {
+					So(b.Allow(), ShouldBeNil)
+					b.Record(ctx, errDependency)
+
+					Convey("Then the breaker opens again", func() {
+						So(b.State(), ShouldEqual, StateOpen)
+						So(IsUnavailable(b.Allow()), ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestBreakerChecker(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a circuit breaker", t, func() {
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		b := newTestBreaker(&now)
+		state := healthcheck.NewCheckState("Search API circuit breaker")
+
+		Convey("When it is closed", func() {
+			err := b.Checker()(ctx, state)
+
+			Convey("Then the health is OK", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "Search API circuit breaker is closed")
+			})
+		})
+
+		Convey("When it is open", func() {
+			b.Record(ctx, errDependency)
+			b.Record(ctx, errDependency)
+			err := b.Checker()(ctx, state)
+
+			Convey("Then the health is WARNING", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldEqual, "Search API circuit breaker is open")
+			})
+		})
+	})
+}
+
+func TestIsFailure(t *testing.T) {
+	t.Parallel()
+
+	failureTests := []struct {
+		name    string
+		err     error
+		failure bool
+	}{
+		{name: "no error", err: nil, failure: false},
+		{name: "network error", err: errDependency, failure: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, failure: true},
+		{name: "request cancelled by the caller", err: context.Canceled, failure: false},
+		{name: "server error status", err: searchError.StatusError{Code: http.StatusInternalServerError}, failure: true},
+		{name: "client error status", err: searchError.StatusError{Code: http.StatusNotFound}, failure: false},
+		{name: "server error code", err: codeError(http.StatusServiceUnavailable), failure: true},
+		{name: "client error code", err: codeError(http.StatusUnauthorized), failure: false},
+	}
+
+	Convey("Given the errors returned by a dependency", t, func() {
+		for _, tc := range failureTests {
+			Convey("Then a "+tc.name+" is classified correctly", func() {
+				So(IsFailure(tc.err), ShouldEqual, tc.failure)
+			})
+		}
+	})
+}
+
+// codeError is an error with a status code, as returned by the zebedee client
+type codeError int
+
+func (e codeError) Error() string { return http.StatusText(int(e)) }
+func (e codeError) Code() int     { return int(e) }
@@ -0,0 +1,207 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	searchAPI "github.com/ONSdigital/dp-search-api/api"
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	searchTransformer "github.com/ONSdigital/dp-search-api/transformer"
+	topicModels "github.com/ONSdigital/dp-topic-api/models"
+	topicSDK "github.com/ONSdigital/dp-topic-api/sdk"
+	topicError "github.com/ONSdigital/dp-topic-api/sdk/errors"
+)
+
+// statusError is the error returned by the search and topic api clients
+type statusError interface {
+	error
+	Status() int
+}
+
+// toStatusError returns the error of a call as the error of the search and topic api clients
+func toStatusError(err error) statusError {
+	if err == nil {
+		return nil
+	}
+
+	var statusErr statusError
+	if errors.As(err, &statusErr) {
+		return statusErr
+	}
+
+	return searchError.StatusError{Code: http.StatusInternalServerError, Err: err}
+}
+
+// SearchClient is the search api client with retries and a circuit breaker. Every call it makes is a search, so all
+// of them are retried
+type SearchClient struct {
+	searchSDK.Clienter
+	breaker *Breaker
+	policy  RetryPolicy
+}
+
+// NewSearchClient wraps the search api client with the circuit breaker and retry policy given
+func NewSearchClient(client searchSDK.Clienter, breaker *Breaker, policy RetryPolicy) *SearchClient {
+	return &SearchClient{Clienter: client, breaker: breaker, policy: policy}
+}
+
+// GetReleaseCalendarEntries gets the release calendar entries from the search api
+func (c *SearchClient) GetReleaseCalendarEntries(ctx context.Context, options searchSDK.Options) (*searchTransformer.SearchReleaseResponse, searchError.Error) {
+	resp, err := Do(ctx, c.breaker, c.policy, "GetReleaseCalendarEntries", func(ctx context.Context) (*searchTransformer.SearchReleaseResponse, error) {
+		resp, err := c.Clienter.GetReleaseCalendarEntries(ctx, options)
+		if err != nil {
+			return resp, err
+		}
+		return resp, nil
+	})
+	return resp, toStatusError(err)
+}
+
+// GetSearch gets the search results from the search api
+func (c *SearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	resp, err := Do(ctx, c.breaker, c.policy, "GetSearch", func(ctx context.Context) (*searchModels.SearchResponse, error) {
+		resp, err := c.Clienter.GetSearch(ctx, options)
+		if err != nil {
+			return resp, err
+		}
+		return resp, nil
+	})
+	return resp, toStatusError(err)
+}
+
+// PostSearchURIs gets the search results of the uris from the search api
+func (c *SearchClient) PostSearchURIs(ctx context.Context, options searchSDK.Options, urisRequest searchAPI.URIsRequest) (*searchModels.SearchResponse, searchError.Error) {
+	resp, err := Do(ctx, c.breaker, c.policy, "PostSearchURIs", func(ctx context.Context) (*searchModels.SearchResponse, error) {
+		resp, err := c.Clienter.PostSearchURIs(ctx, options, urisRequest)
+		if err != nil {
+			return resp, err
+		}
+		return resp, nil
+	})
+	return resp, toStatusError(err)
+}
+
+// TopicClient is the topic api client with retries and a circuit breaker. Only the gets are wrapped, the updates made
+// in publishing are passed straight to the client
+type TopicClient struct {
+	topicSDK.Clienter
+	breaker *Breaker
+	policy  RetryPolicy
+}
+
+// NewTopicClient wraps the topic api client with the circuit breaker and retry policy given
+func NewTopicClient(client topicSDK.Clienter, breaker *Breaker, policy RetryPolicy) *TopicClient {
+	return &TopicClient{Clienter: client, breaker: breaker, policy: policy}
+}
+
+// GetNavigationPublic gets the navigation from the topic api
+func (c *TopicClient) GetNavigationPublic(ctx context.Context, reqHeaders topicSDK.Headers, options topicSDK.Options) (*topicModels.Navigation, topicError.Error) {
+	return doTopic(ctx, c, "GetNavigationPublic", func(ctx context.Context) (*topicModels.Navigation, topicError.Error) {
+		return c.Clienter.GetNavigationPublic(ctx, reqHeaders, options)
+	})
+}
+
+// GetRootTopicsPrivate gets the root topics, including those not yet published, from the topic api
+func (c *TopicClient) GetRootTopicsPrivate(ctx context.Context, reqHeaders topicSDK.Headers) (*topicModels.PrivateSubtopics, topicError.Error) {
+	return doTopic(ctx, c, "GetRootTopicsPrivate", func(ctx context.Context) (*topicModels.PrivateSubtopics, topicError.Error) {
+		return c.Clienter.GetRootTopicsPrivate(ctx, reqHeaders)
+	})
+}
+
+// GetRootTopicsPublic gets the published root topics from the topic api
+func (c *TopicClient) GetRootTopicsPublic(ctx context.Context, reqHeaders topicSDK.Headers) (*topicModels.PublicSubtopics, topicError.Error) {
+	return doTopic(ctx, c, "GetRootTopicsPublic", func(ctx context.Context) (*topicModels.PublicSubtopics, topicError.Error) {
+		return c.Clienter.GetRootTopicsPublic(ctx, reqHeaders)
+	})
+}
+
+// GetSubtopicsPrivate gets the subtopics of the topic, including those not yet published, from the topic api
+func (c *TopicClient) GetSubtopicsPrivate(ctx context.Context, reqHeaders topicSDK.Headers, id string) (*topicModels.PrivateSubtopics, topicError.Error) {
+	return doTopic(ctx, c, "GetSubtopicsPrivate", func(ctx context.Context) (*topicModels.PrivateSubtopics, topicError.Error) {
+		return c.Clienter.GetSubtopicsPrivate(ctx, reqHeaders, id)
+	})
+}
+
+// GetSubtopicsPublic gets the published subtopics of the topic from the topic api
+func (c *TopicClient) GetSubtopicsPublic(ctx context.Context, reqHeaders topicSDK.Headers, id string) (*topicModels.PublicSubtopics, topicError.Error) {
+	return doTopic(ctx, c, "GetSubtopicsPublic", func(ctx context.Context) (*topicModels.PublicSubtopics, topicError.Error) {
+		return c.Clienter.GetSubtopicsPublic(ctx, reqHeaders, id)
+	})
+}
+
+// GetTopicPrivate gets the topic, including any changes not yet published, from the topic api
+func (c *TopicClient) GetTopicPrivate(ctx context.Context, reqHeaders topicSDK.Headers, id string) (*topicModels.TopicResponse, topicError.Error) {
+	return doTopic(ctx, c, "GetTopicPrivate", func(ctx context.Context) (*topicModels.TopicResponse, topicError.Error) {
+		return c.Clienter.GetTopicPrivate(ctx, reqHeaders, id)
+	})
+}
+
+// GetTopicPublic gets the published topic from the topic api
+func (c *TopicClient) GetTopicPublic(ctx context.Context, reqHeaders topicSDK.Headers, id string) (*topicModels.Topic, topicError.Error) {
+	return doTopic(ctx, c, "GetTopicPublic", func(ctx context.Context) (*topicModels.Topic, topicError.Error) {
+		return c.Clienter.GetTopicPublic(ctx, reqHeaders, id)
+	})
+}
+
+func doTopic[T any](ctx context.Context, c *TopicClient, operation string, call func(ctx context.Context) (T, topicError.Error)) (T, topicError.Error) {
+	value, err := Do(ctx, c.breaker, c.policy, operation, func(ctx context.Context) (T, error) {
+		value, err := call(ctx)
+		if err != nil {
+			return value, err
+		}
+		return value, nil
+	})
+	return value, toStatusError(err)
+}
+
+// ZebedeeAPI is the zebedee client used by the controller
+type ZebedeeAPI interface {
+	GetHomepageContent(ctx context.Context, userAuthToken, collectionID, lang, path string) (m zebedee.HomepageContent, err error)
+	GetPageData(ctx context.Context, userAuthToken, collectionID, lang, path string) (m zebedee.PageData, err error)
+	GetBreadcrumb(ctx context.Context, userAccessToken, collectionID, lang, uri string) (bc []zebedee.Breadcrumb, err error)
+	GetCollection(ctx context.Context, userAccessToken, collectionID string) (m zebedee.Collection, err error)
+}
+
+// ZebedeeClient is the zebedee client with retries and a circuit breaker
+type ZebedeeClient struct {
+	client  ZebedeeAPI
+	breaker *Breaker
+	policy  RetryPolicy
+}
+
+// NewZebedeeClient wraps the zebedee client with the circuit breaker and retry policy given
+func NewZebedeeClient(client ZebedeeAPI, breaker *Breaker, policy RetryPolicy) *ZebedeeClient {
+	return &ZebedeeClient{client: client, breaker: breaker, policy: policy}
+}
+
+// GetHomepageContent gets the content of the homepage, e.g. the emergency banner, from zebedee
+func (c *ZebedeeClient) GetHomepageContent(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.HomepageContent, error) {
+	return Do(ctx, c.breaker, c.policy, "GetHomepageContent", func(ctx context.Context) (zebedee.HomepageContent, error) {
+		return c.client.GetHomepageContent(ctx, userAuthToken, collectionID, lang, path)
+	})
+}
+
+// GetPageData gets the data of the page from zebedee
+func (c *ZebedeeClient) GetPageData(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+	return Do(ctx, c.breaker, c.policy, "GetPageData", func(ctx context.Context) (zebedee.PageData, error) {
+		return c.client.GetPageData(ctx, userAuthToken, collectionID, lang, path)
+	})
+}
+
+// GetBreadcrumb gets the breadcrumbs of the page from zebedee
+func (c *ZebedeeClient) GetBreadcrumb(ctx context.Context, userAccessToken, collectionID, lang, uri string) ([]zebedee.Breadcrumb, error) {
+	return Do(ctx, c.breaker, c.policy, "GetBreadcrumb", func(ctx context.Context) ([]zebedee.Breadcrumb, error) {
+		return c.client.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, uri)
+	})
+}
+
+// GetCollection gets the collection being previewed from zebedee
+func (c *ZebedeeClient) GetCollection(ctx context.Context, userAccessToken, collectionID string) (zebedee.Collection, error) {
+	return Do(ctx, c.breaker, c.policy, "GetCollection", func(ctx context.Context) (zebedee.Collection, error) {
+		return c.client.GetCollection(ctx, userAccessToken, collectionID)
+	})
+}
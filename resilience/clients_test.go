@@ -0,0 +1,101 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	searchModels "github.com/ONSdigital/dp-search-api/models"
+	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	topicModels "github.com/ONSdigital/dp-topic-api/models"
+	topicSDK "github.com/ONSdigital/dp-topic-api/sdk"
+	topicError "github.com/ONSdigital/dp-topic-api/sdk/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeSearchClient is a search api client returning the responses given in turn
+type fakeSearchClient struct {
+	searchSDK.Clienter
+	errs  []searchError.Error
+	calls int
+}
+
+func (c *fakeSearchClient) GetSearch(ctx context.Context, options searchSDK.Options) (*searchModels.SearchResponse, searchError.Error) {
+	c.calls++
+	if err := c.errs[min(c.calls, len(c.errs))-1]; err != nil {
+		return nil, err
+	}
+	return &searchModels.SearchResponse{Count: 1}, nil
+}
+
+// fakeTopicClient is a topic api client whose subtopics always fail to be fetched
+type fakeTopicClient struct {
+	topicSDK.Clienter
+	calls int
+}
+
+func (c *fakeTopicClient) GetSubtopicsPublic(ctx context.Context, reqHeaders topicSDK.Headers, id string) (*topicModels.PublicSubtopics, topicError.Error) {
+	c.calls++
+	return nil, topicError.StatusError{Code: http.StatusBadGateway}
+}
+
+func TestSearchClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond}
+
+	Convey("Given the search api fails once", t, func() {
+		fake := &fakeSearchClient{errs: []searchError.Error{searchError.StatusError{Code: http.StatusBadGateway}, nil}}
+		client := NewSearchClient(fake, NewBreaker("Search API", BreakerConfig{FailureThreshold: 5, OpenTimeout: time.Minute}), policy)
+
+		Convey("When a search is made", func() {
+			resp, err := client.GetSearch(ctx, searchSDK.Options{})
+
+			Convey("Then it is retried and the results are returned without an error", func() {
+				So(err, ShouldBeNil)
+				So(resp.Count, ShouldEqual, 1)
+				So(fake.calls, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given the circuit breaker of the search api is open", t, func() {
+		fake := &fakeSearchClient{errs: []searchError.Error{searchError.StatusError{Code: http.StatusInternalServerError}}}
+		client := NewSearchClient(fake, NewBreaker("Search API", BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}), RetryPolicy{})
+		_, err := client.GetSearch(ctx, searchSDK.Options{})
+		So(err.Status(), ShouldEqual, http.StatusInternalServerError)
+
+		Convey("When a search is made", func() {
+			_, err := client.GetSearch(ctx, searchSDK.Options{})
+
+			Convey("Then the search api is not called and an unavailable error is returned", func() {
+				So(IsUnavailable(err), ShouldBeTrue)
+				So(err.Status(), ShouldEqual, http.StatusServiceUnavailable)
+				So(fake.calls, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestTopicClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	Convey("Given a topic client without retries, as used to crawl the topic tree", t, func() {
+		fake := &fakeTopicClient{}
+		client := NewTopicClient(fake, NewBreaker("Topic API", BreakerConfig{FailureThreshold: 5, OpenTimeout: time.Minute}), RetryPolicy{})
+
+		Convey("When the topic api fails to return subtopics", func() {
+			_, err := client.GetSubtopicsPublic(ctx, topicSDK.Headers{}, "1234")
+
+			Convey("Then the topic api is called once and its error is returned", func() {
+				So(err.Status(), ShouldEqual, http.StatusBadGateway)
+				So(fake.calls, ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package resilience
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-search-controller/tracing"
+)
+
+// maxBackoffDoublings caps the growth of the backoff between retries
+const maxBackoffDoublings = 5
+
+// RetryPolicy is how a failed idempotent call is retried
+type RetryPolicy struct {
+	// MaxRetries is the number of times a call is retried after it first fails, none if 0
+	MaxRetries int
+	// Backoff is the wait before the first retry, which doubles with each retry
+	Backoff time.Duration
+}
+
+// backoff returns the wait before the retry, half of which is jittered so that the retries of concurrent requests
+// are spread out
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.Backoff << min(retry, maxBackoffDoublings)
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + rand.N(d-half+1) //nolint:gosec // jitter does not need a secure random number
+}
+
+// Do calls the dependency through its circuit breaker, retrying failures with the policy given. Calls which are not
+// idempotent must be given a policy without retries. Each attempt at the operation, including those refused by the
+// breaker, is traced in its own span
+func Do[T any](ctx context.Context, b *Breaker, policy RetryPolicy, operation string, call func(ctx context.Context) (T, error)) (T, error) {
+	for retry := 0; ; retry++ {
+		value, err := attempt(ctx, b, operation, retry+1, call)
+
+		if IsUnavailable(err) || !IsFailure(err) || retry >= policy.MaxRetries {
+			return value, err
+		}
+
+		timer := time.NewTimer(policy.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return value, err
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt makes a single call to the dependency if its circuit breaker allows it, recording the outcome
+func attempt[T any](ctx context.Context, b *Breaker, operation string, number int, call func(ctx context.Context) (T, error)) (value T, err error) {
+	ctx, span := tracing.Start(ctx, b.Name()+" "+operation,
+		tracing.AttrDependency.String(b.Name()),
+		tracing.AttrOperation.String(operation),
+		tracing.AttrAttempt.Int(number),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if err = b.Allow(); err != nil {
+		return value, err
+	}
+
+	value, err = call(ctx)
+	b.Record(ctx, err)
+
+	return value, err
+}
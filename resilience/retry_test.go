@@ -0,0 +1,134 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	searchError "github.com/ONSdigital/dp-search-api/sdk/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	Convey("Given a dependency which fails once and then succeeds", t, func() {
+		b := NewBreaker("Zebedee", BreakerConfig{FailureThreshold: 5, OpenTimeout: time.Minute})
+		calls := 0
+		call := func(ctx context.Context) (string, error) {
+			calls++
+			if calls == 1 {
+				return "", errDependency
+			}
+			return "response", nil
+		}
+
+		Convey("When it is called", func() {
+			value, err := Do(ctx, b, policy, "GetSearch", call)
+
+			Convey("Then the call is retried and its response returned", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "response")
+				So(calls, ShouldEqual, 2)
+				So(b.State(), ShouldEqual, StateClosed)
+			})
+		})
+	})
+
+	Convey("Given a dependency which keeps failing", t, func() {
+		b := NewBreaker("Zebedee", BreakerConfig{FailureThreshold: 5, OpenTimeout: time.Minute})
+		calls := 0
+		call := func(ctx context.Context) (string, error) {
+			calls++
+			return "", errDependency
+		}
+
+		Convey("When it is called", func() {
+			_, err := Do(ctx, b, policy, "GetSearch", call)
+
+			Convey("Then the call is retried up to the maximum and the last error returned", func() {
+				So(err, ShouldEqual, errDependency)
+				So(calls, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When it is called without retries", func() {
+			_, err := Do(ctx, b, RetryPolicy{}, "GetSearch", call)
+
+			Convey("Then the call is made once", func() {
+				So(err, ShouldEqual, errDependency)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a dependency which returns a client error", t, func() {
+		b := NewBreaker("Zebedee", BreakerConfig{FailureThreshold: 5, OpenTimeout: time.Minute})
+		calls := 0
+		call := func(ctx context.Context) (string, error) {
+			calls++
+			return "", searchError.StatusError{Code: http.StatusNotFound}
+		}
+
+		Convey("When it is called", func() {
+			_, err := Do(ctx, b, policy, "GetSearch", call)
+
+			Convey("Then the call is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a dependency whose failures open its circuit breaker", t, func() {
+		b := NewBreaker("Zebedee", BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+		calls := 0
+		call := func(ctx context.Context) (string, error) {
+			calls++
+			return "", errDependency
+		}
+
+		Convey("When it is called", func() {
+			_, err := Do(ctx, b, policy, "GetSearch", call)
+
+			Convey("Then the retries stop once the breaker opens", func() {
+				So(IsUnavailable(err), ShouldBeTrue)
+				So(calls, ShouldEqual, 2)
+			})
+
+			Convey("And it is called again", func() {
+				calls = 0
+				_, err = Do(ctx, b, policy, "GetSearch", call)
+
+				Convey("Then it is not called", func() {
+					So(IsUnavailable(err), ShouldBeTrue)
+					So(calls, ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a retry policy", t, func() {
+		policy := RetryPolicy{MaxRetries: 10, Backoff: 100 * time.Millisecond}
+
+		Convey("Then the backoff doubles with each retry and is jittered", func() {
+			for retry := range 3 {
+				d := policy.backoff(retry)
+				So(d, ShouldBeGreaterThanOrEqualTo, (100*time.Millisecond<<retry)/2)
+				So(d, ShouldBeLessThanOrEqualTo, 100*time.Millisecond<<retry)
+			}
+		})
+
+		Convey("Then the backoff stops growing after a number of retries", func() {
+			So(policy.backoff(20), ShouldBeLessThanOrEqualTo, 100*time.Millisecond<<maxBackoffDoublings)
+		})
+	})
+}
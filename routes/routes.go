@@ -5,54 +5,76 @@ import (
 	"net/http"
 
 	rend "github.com/ONSdigital/dis-design-system-go/v2"
-	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
 	"github.com/ONSdigital/dp-frontend-search-controller/handlers"
-	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
 	topic "github.com/ONSdigital/dp-topic-api/sdk"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // Clients - struct containing all the clients for the controller
 type Clients struct {
+	Analytics          *analytics.Recorder
+	CacheInvalidator   cache.TopicInvalidator
 	HealthCheckHandler func(w http.ResponseWriter, req *http.Request)
 	Renderer           *rend.Render
-	Search             *searchSDK.Client
-	Topic              *topic.Client
-	Zebedee            *zebedee.Client
+	Search             handlers.SearchClient
+	Topic              topic.Clienter
+	Zebedee            handlers.ZebedeeClient
 }
 
 // Setup registers routes for the service
 func Setup(ctx context.Context, r *mux.Router, cfg *config.Config, c Clients, cacheList cache.List) {
 	log.Info(ctx, "adding routes")
 	sh := handlers.NewSearchHandler(c.Renderer, c.Search, c.Topic, c.Zebedee, cfg, cacheList)
+	sh.Analytics = c.Analytics
 
 	r.StrictSlash(true).Path("/health").HandlerFunc(c.HealthCheckHandler)
+	r.StrictSlash(true).Path("/metrics").Methods("GET").Handler(promhttp.Handler())
 	r.StrictSlash(true).Path("/search").Methods("GET").HandlerFunc(sh.Search(cfg))
+	if c.Analytics.TracksClicks() {
+		r.StrictSlash(true).Path(analytics.ClickPath).Methods("GET").HandlerFunc(sh.Click())
+	}
+
+	if cfg.CacheInvalidationWebhookSecret != "" && c.CacheInvalidator != nil {
+		r.StrictSlash(true).Path("/cache/invalidate").Methods("POST").HandlerFunc(handlers.CacheInvalidation(cfg.CacheInvalidationWebhookSecret, c.CacheInvalidator))
+	}
 
 	if sh.EnableAggregationPages {
-		r.StrictSlash(true).Path("/alladhocs").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "all-adhocs"))
-		r.StrictSlash(true).Path("/datalist").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "home-datalist"))
-		r.StrictSlash(true).Path("/publishedrequests").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "published-requests"))
-		r.StrictSlash(true).Path("/staticlist").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "home-list"))
-		r.StrictSlash(true).Path("/topicspecificmethodology").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "home-methodology"))
-		r.StrictSlash(true).Path("/timeseriestool").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "time-series-tool"))
-		r.StrictSlash(true).Path("/publications").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "home-publications"))
-		r.StrictSlash(true).Path("/allmethodologies").Methods("GET").HandlerFunc(sh.DataAggregation(cfg, "all-methodologies"))
+		aggregationPages := data.GetAggregationPages()
+
+		for i := range aggregationPages {
+			r.StrictSlash(true).Path(aggregationPages[i].Path).Methods("GET").HandlerFunc(aggregationHandler(cfg, sh, aggregationPages[i]))
+		}
 
 		if sh.EnableTopicAggregationPages {
 			// handle dynamic aggregated data topic pages
-			r.StrictSlash(true).Path("/{topicsPath:.*}/datalist").Methods("GET").HandlerFunc(sh.DataAggregationWithTopics(cfg, "home-datalist"))
-			r.StrictSlash(true).Path("/{topicsPath:.*}/publications").Methods("GET").HandlerFunc(sh.DataAggregationWithTopics(cfg, "home-publications"))
-			r.StrictSlash(true).Path("/{topicsPath:.*}/staticlist").Methods("GET").HandlerFunc(sh.DataAggregationWithTopics(cfg, "home-list"))
-			r.StrictSlash(true).Path("/{topicsPath:.*}/topicspecificmethodology").Methods("GET").HandlerFunc(sh.DataAggregationWithTopics(cfg, "home-methodology"))
+			for i := range aggregationPages {
+				if aggregationPages[i].TopicPaths {
+					r.StrictSlash(true).Path("/{topicsPath:.*}" + aggregationPages[i].Path).Methods("GET").HandlerFunc(sh.DataAggregationWithTopics(cfg, aggregationPages[i].Template))
+				}
+			}
 		}
 	}
 
 	r.StrictSlash(true).Path("/{uri:.*}/previousreleases").Methods("GET").HandlerFunc(sh.PreviousReleases(cfg))
 	r.StrictSlash(true).Path("/{uri:.*}/relateddata").Methods("GET").HandlerFunc(sh.RelatedData(cfg))
+	if cfg.IsPublishing {
+		r.StrictSlash(true).Path("/{uri:.*}/relateddata/report").Methods("GET").HandlerFunc(sh.RelatedDataReport())
+	}
 
 	r.StrictSlash(true).Path("/census/find-a-dataset").Methods("GET").HandlerFunc(sh.FindDataset(cfg))
 }
+
+// aggregationHandler returns the handler of a data aggregation page
+func aggregationHandler(cfg *config.Config, sh *handlers.SearchHandler, page data.AggregationPage) http.HandlerFunc {
+	if page.Handler == data.ReleaseCalendarHandler {
+		return sh.ReleaseCalendar(cfg)
+	}
+
+	return sh.DataAggregation(cfg, page.Template)
+}
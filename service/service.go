@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	render "github.com/ONSdigital/dis-design-system-go/v2"
 	"github.com/ONSdigital/dis-design-system-go/v2/middleware/renderror"
@@ -12,11 +14,20 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-frontend-search-controller/analytics"
 	"github.com/ONSdigital/dp-frontend-search-controller/assets"
 	"github.com/ONSdigital/dp-frontend-search-controller/cache"
 	cachePrivate "github.com/ONSdigital/dp-frontend-search-controller/cache/private"
 	cachePublic "github.com/ONSdigital/dp-frontend-search-controller/cache/public"
+	cacheStatic "github.com/ONSdigital/dp-frontend-search-controller/cache/static"
+	"github.com/ONSdigital/dp-frontend-search-controller/coalesce"
 	"github.com/ONSdigital/dp-frontend-search-controller/config"
+	"github.com/ONSdigital/dp-frontend-search-controller/data"
+	"github.com/ONSdigital/dp-frontend-search-controller/metrics"
+	dpMiddleware "github.com/ONSdigital/dp-frontend-search-controller/middleware"
+	"github.com/ONSdigital/dp-frontend-search-controller/ratelimit"
+	"github.com/ONSdigital/dp-frontend-search-controller/redirect"
+	"github.com/ONSdigital/dp-frontend-search-controller/resilience"
 	"github.com/ONSdigital/dp-frontend-search-controller/routes"
 	searchSDK "github.com/ONSdigital/dp-search-api/sdk"
 	topic "github.com/ONSdigital/dp-topic-api/sdk"
@@ -33,12 +44,17 @@ var (
 	Version string
 )
 
+// readinessRetryAfter is the time clients are asked to wait before retrying a request held back until the caches are ready
+const readinessRetryAfter = 5 * time.Second
+
 // Service contains the healthcheck, server and serviceList for the frontend search controller
 type Service struct {
 	Cache              cache.List
 	Config             *config.Config
 	HealthCheck        HealthChecker
 	routerHealthClient *health.Client
+	breakers           []*resilience.Breaker
+	analytics          *analytics.Recorder
 	Server             HTTPServer
 	ServiceList        *ExternalServiceList
 }
@@ -58,25 +74,54 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 	// Get health client for api router
 	svc.routerHealthClient = serviceList.GetHealthClient("api-router", svc.Config.APIRouterURL)
 
-	// Initialise clients
+	// Initialise clients, with retries and a circuit breaker for each dependency
+	retryPolicy := resilience.RetryPolicy{
+		MaxRetries: svc.Config.DependencyMaxRetries,
+		Backoff:    svc.Config.DependencyRetryBackoff,
+	}
+	breakerConfig := resilience.BreakerConfig{
+		FailureThreshold: svc.Config.CircuitBreakerFailureThreshold,
+		OpenTimeout:      svc.Config.CircuitBreakerOpenTimeout,
+	}
+	searchBreaker := resilience.NewBreaker("Search API", breakerConfig)
+	topicBreaker := resilience.NewBreaker("Topic API", breakerConfig)
+	zebedeeBreaker := resilience.NewBreaker("Zebedee", breakerConfig)
+	svc.breakers = []*resilience.Breaker{searchBreaker, topicBreaker, zebedeeBreaker}
+
+	// Record the metrics of every call made to the search api, including retries
+	searchClient := resilience.NewSearchClient(metrics.NewSearchClient(searchSDK.NewWithHealthClient(svc.routerHealthClient)), searchBreaker, retryPolicy)
+	topicClient := topic.NewWithHealthClient(svc.routerHealthClient)
+
 	clients := routes.Clients{
 		Renderer: render.NewWithDefaultClient(assets.Asset, assets.AssetNames, svc.Config.PatternLibraryAssetsPath, svc.Config.SiteDomain),
-		Search:   searchSDK.NewWithHealthClient(svc.routerHealthClient),
-		Topic:    topic.NewWithHealthClient(svc.routerHealthClient),
-		Zebedee:  zebedee.NewWithHealthClient(svc.routerHealthClient),
+		Search:   searchClient,
+		Topic:    resilience.NewTopicClient(topicClient, topicBreaker, retryPolicy),
+		Zebedee:  resilience.NewZebedeeClient(zebedee.NewWithHealthClient(svc.routerHealthClient), zebedeeBreaker, retryPolicy),
 	}
 
-	// Get healthcheck with checkers
-	svc.HealthCheck, err = serviceList.GetHealthCheck(svc.Config, BuildTime, GitCommit, Version)
+	// Share the responses of identical concurrent searches, after any retries
+	if svc.Config.EnableSearchCoalescing {
+		clients.Search = coalesce.NewSearchClient(searchClient, svc.Config.SearchTimeout)
+	}
+
+	// Initialise search analytics, if a sink is configured
+	svc.analytics, err = getAnalyticsRecorder(svc.Config)
 	if err != nil {
-		log.Fatal(ctx, "failed to create health check", err)
+		log.Error(ctx, "failed to create search analytics recorder", err, log.Data{"analytics_sink": svc.Config.AnalyticsSink})
 		return err
 	}
-	if err = svc.registerCheckers(ctx, clients); err != nil {
-		log.Error(ctx, "failed to register checkers", err)
-		return err
+	clients.Analytics = svc.analytics
+
+	// Override the aggregation pages embedded in assets
+	if svc.Config.AggregationPagesPath != "" {
+		var aggregationPages data.AggregationPages
+		aggregationPages, err = data.LoadAggregationPages(svc.Config.AggregationPagesPath)
+		if err != nil {
+			log.Error(ctx, "failed to load aggregation pages", err, log.Data{"aggregation_pages_path": svc.Config.AggregationPagesPath})
+			return err
+		}
+		data.SetAggregationPages(aggregationPages)
 	}
-	clients.HealthCheckHandler = svc.HealthCheck.Handler
 
 	// Initialise caching
 	cache.CensusTopicID = svc.Config.CensusTopicID
@@ -98,30 +143,75 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 		return err
 	}
 
-	if svc.Config.IsPublishing {
-		svc.Cache.CensusTopic.AddUpdateFunc(cache.CensusTopicID, cachePrivate.UpdateCensusTopic(ctx, svc.Config.ServiceAuthToken, clients.Topic))
-		if svc.Config.EnableTopicAggregationPages {
-			svc.Cache.DataTopic.AddUpdateFunc(svc.Cache.DataTopic.GetDataTopicCacheKey(), cachePrivate.UpdateDataTopicCache(ctx, svc.Config.ServiceAuthToken, clients.Topic))
-		}
-	} else {
-		svc.Cache.CensusTopic.AddUpdateFunc(cache.CensusTopicID, cachePublic.UpdateCensusTopic(ctx, clients.Topic))
-		if svc.Config.EnableTopicAggregationPages {
-			svc.Cache.DataTopic.AddUpdateFunc(svc.Cache.DataTopic.GetDataTopicCacheKey(), cachePublic.UpdateDataTopicCache(ctx, clients.Topic))
-		}
+	crawlConfig := cache.CrawlConfig{
+		Workers:      svc.Config.CacheTopicCrawlWorkers,
+		CallTimeout:  svc.Config.CacheTopicCrawlCallTimeout,
+		MaxRetries:   svc.Config.CacheTopicCrawlMaxRetries,
+		RetryBackoff: svc.Config.CacheTopicCrawlRetryBackoff,
+	}
+
+	// The crawl retries failed topics itself, so its calls share the circuit breaker of the topic api without retrying
+	crawlTopicClient := resilience.NewTopicClient(topicClient, topicBreaker, resilience.RetryPolicy{})
+
+	topicSource, err := getTopicSource(svc.Config, crawlTopicClient)
+	if err != nil {
+		log.Error(ctx, "failed to create topic source", err, log.Data{"topic_fixture_path": svc.Config.TopicFixturePath})
+		return err
 	}
 
+	svc.Cache.CensusTopic.AddUpdateFunc(cache.CensusTopicID, cache.UpdateCensusTopic(ctx, svc.Config.CensusTopicID, crawlConfig, topicSource))
+	if svc.Config.EnableTopicAggregationPages {
+		svc.Cache.DataTopic.AddUpdateFunc(svc.Cache.DataTopic.GetDataTopicCacheKey(), cache.UpdateDataTopicCache(ctx, crawlConfig, topicSource))
+	}
+	clients.CacheInvalidator = cache.NewInvalidator(svc.Cache, crawlConfig, topicSource)
+
 	for _, lang := range svc.Config.SupportedLanguages {
 		navigationlangKey := svc.Cache.Navigation.GetCachingKeyForNavigationLanguage(lang)
 		svc.Cache.Navigation.AddUpdateFunc(navigationlangKey, cachePublic.UpdateNavigationData(ctx, svc.Config, lang, clients.Topic))
 	}
 
+	svc.Cache.EnableMetrics()
+
+	// Warm start caches from snapshots written by a previous run
+	if svc.Config.CacheSnapshotDir != "" {
+		svc.Cache.EnableSnapshots(svc.Config.CacheSnapshotDir, svc.Config.CacheSnapshotMaxAge)
+		svc.Cache.LoadSnapshots(ctx)
+	}
+
+	// Get healthcheck with checkers
+	svc.HealthCheck, err = serviceList.GetHealthCheck(svc.Config, BuildTime, GitCommit, Version)
+	if err != nil {
+		log.Fatal(ctx, "failed to create health check", err)
+		return err
+	}
+	if err = svc.registerCheckers(ctx, clients); err != nil {
+		log.Error(ctx, "failed to register checkers", err)
+		return err
+	}
+	clients.HealthCheckHandler = svc.HealthCheck.Handler
+
 	// Initialise router
 	r := mux.NewRouter()
 	if svc.Config.OtelEnabled {
 		r.Use(otelmux.Middleware(svc.Config.OTServiceName))
 	}
+	r.Use(metrics.Middleware)
 	middleware := []alice.Constructor{
 		renderror.Handler(clients.Renderer),
+		redirect.Legacy(redirect.LegacyURLs),
+	}
+
+	if svc.Config.EnableRateLimiting {
+		var limiter *ratelimit.Limiter
+		if limiter, err = getRateLimiter(svc.Config); err != nil {
+			log.Error(ctx, "failed to create rate limiter", err)
+			return err
+		}
+		middleware = append(middleware, dpMiddleware.RateLimit(limiter))
+	}
+
+	if svc.Config.EnableCacheReadinessGate {
+		middleware = append(middleware, dpMiddleware.Readiness(svc.Cache.IsReady, readinessRetryAfter))
 	}
 
 	if svc.Config.OtelEnabled {
@@ -149,6 +239,11 @@ func (svc *Service) Run(ctx context.Context, svcErrors chan error) {
 	}
 	go svc.Cache.Navigation.StartAndManageUpdates(ctx, svcErrors)
 
+	// Start sending search analytics events
+	if svc.analytics != nil {
+		svc.analytics.Start(ctx)
+	}
+
 	// Start HTTP server
 	log.Info(ctx, "Starting server")
 	go func() {
@@ -184,6 +279,14 @@ func (svc *Service) Close(ctx context.Context) error {
 			log.Error(ctx, "failed to shutdown http server", err)
 			hasShutdownError = true
 		}
+
+		// send the search analytics events of the requests served
+		if svc.analytics != nil {
+			if err := svc.analytics.Close(ctx); err != nil {
+				log.Error(ctx, "failed to close search analytics recorder", err)
+				hasShutdownError = true
+			}
+		}
 	}()
 
 	// wait for shutdown success (via cancel) or failure (timeout)
@@ -206,6 +309,65 @@ func (svc *Service) Close(ctx context.Context) error {
 	return nil
 }
 
+// getAnalyticsRecorder returns the recorder of search analytics events for the sink configured, or nil if no sink is
+// configured
+func getAnalyticsRecorder(cfg *config.Config) (*analytics.Recorder, error) {
+	var sink analytics.Sink
+	switch cfg.AnalyticsSink {
+	case "":
+		return nil, nil
+	case analytics.SinkLog:
+		sink = analytics.LogSink{}
+	case analytics.SinkFile:
+		fileSink, err := analytics.NewFileSink(cfg.AnalyticsFilePath)
+		if err != nil {
+			return nil, err
+		}
+		sink = fileSink
+	case analytics.SinkHTTP:
+		if cfg.AnalyticsHTTPURL == "" {
+			return nil, errors.New("analytics http url is required for the http sink")
+		}
+		sink = analytics.NewHTTPSink(cfg.AnalyticsHTTPURL, cfg.AnalyticsFlushInterval)
+	default:
+		return nil, fmt.Errorf("unknown analytics sink %q", cfg.AnalyticsSink)
+	}
+
+	return analytics.NewRecorder(sink, analytics.Config{
+		BatchSize:     cfg.AnalyticsBatchSize,
+		FlushInterval: cfg.AnalyticsFlushInterval,
+		SampleRate:    cfg.AnalyticsSampleRate,
+		ClickSecret:   cfg.AnalyticsClickSecret,
+	}), nil
+}
+
+// getRateLimiter returns the limiter of the requests of each client, which keeps their budgets in memory
+func getRateLimiter(cfg *config.Config) (*ratelimit.Limiter, error) {
+	return ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{
+		Limits: map[string]ratelimit.Limit{
+			ratelimit.BudgetSearch: ratelimit.PerMinute(cfg.RateLimitSearchPerMinute, cfg.RateLimitSearchBurst),
+			ratelimit.BudgetRSS:    ratelimit.PerMinute(cfg.RateLimitRSSPerMinute, cfg.RateLimitRSSBurst),
+			ratelimit.BudgetExport: ratelimit.PerMinute(cfg.RateLimitExportPerMinute, cfg.RateLimitExportBurst),
+		},
+		AllowedIPs:     cfg.RateLimitAllowedIPs,
+		TrustedProxies: cfg.RateLimitTrustedProxies,
+	})
+}
+
+// getTopicSource returns the source used to populate the topic caches, which is a static fixture when configured,
+// otherwise the private or public endpoints of the dp-topic-api depending on whether the service is in publishing mode
+func getTopicSource(cfg *config.Config, topicClient topic.Clienter) (cache.TopicSource, error) {
+	if cfg.TopicFixturePath != "" {
+		return cacheStatic.NewTopicSource(cfg.TopicFixturePath)
+	}
+
+	if cfg.IsPublishing {
+		return cachePrivate.NewTopicSource(cfg.ServiceAuthToken, topicClient), nil
+	}
+
+	return cachePublic.NewTopicSource(topicClient), nil
+}
+
 func (svc *Service) registerCheckers(ctx context.Context, c routes.Clients) (err error) {
 	hasErrors := false
 
@@ -214,6 +376,30 @@ func (svc *Service) registerCheckers(ctx context.Context, c routes.Clients) (err
 		log.Error(ctx, "failed to add API router health checker", err)
 	}
 
+	if err = svc.HealthCheck.AddCheck("Census topic cache", svc.Cache.CensusTopic.Checker("census topic cache", svc.Config.CacheHealthCheckStaleThreshold, svc.Config.CacheHealthCheckStartupGrace)); err != nil {
+		hasErrors = true
+		log.Error(ctx, "failed to add census topic cache health checker", err)
+	}
+
+	if svc.Config.EnableTopicAggregationPages {
+		if err = svc.HealthCheck.AddCheck("Data topic cache", svc.Cache.DataTopic.Checker("data topic cache", svc.Config.CacheHealthCheckStaleThreshold, svc.Config.CacheHealthCheckStartupGrace)); err != nil {
+			hasErrors = true
+			log.Error(ctx, "failed to add data topic cache health checker", err)
+		}
+	}
+
+	if err = svc.HealthCheck.AddCheck("Navigation cache", svc.Cache.Navigation.Checker("navigation cache", svc.Config.CacheHealthCheckStaleThreshold, svc.Config.CacheHealthCheckStartupGrace)); err != nil {
+		hasErrors = true
+		log.Error(ctx, "failed to add navigation cache health checker", err)
+	}
+
+	for _, breaker := range svc.breakers {
+		if err = svc.HealthCheck.AddCheck(breaker.Name()+" circuit breaker", breaker.Checker()); err != nil {
+			hasErrors = true
+			log.Error(ctx, "failed to add circuit breaker health checker", err, log.Data{"dependency": breaker.Name()})
+		}
+	}
+
 	if hasErrors {
 		return errors.New("Error(s) registering checkers for healthcheck")
 	}
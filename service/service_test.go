@@ -142,8 +142,13 @@ func TestInitSuccess(t *testing.T) {
 
 						Convey("And the checkers are registered and the healthcheck", func() {
 							So(mockServiceList.HealthCheck, ShouldBeTrue)
-							So(len(hcMock.AddCheckCalls()), ShouldEqual, 1)
+							So(len(hcMock.AddCheckCalls()), ShouldEqual, 6)
 							So(hcMock.AddCheckCalls()[0].Name, ShouldResemble, "API router")
+							So(hcMock.AddCheckCalls()[1].Name, ShouldResemble, "Census topic cache")
+							So(hcMock.AddCheckCalls()[2].Name, ShouldResemble, "Navigation cache")
+							So(hcMock.AddCheckCalls()[3].Name, ShouldResemble, "Search API circuit breaker")
+							So(hcMock.AddCheckCalls()[4].Name, ShouldResemble, "Topic API circuit breaker")
+							So(hcMock.AddCheckCalls()[5].Name, ShouldResemble, "Zebedee circuit breaker")
 							So(len(initMock.DoGetHTTPServerCalls()), ShouldEqual, 1)
 							So(initMock.DoGetHTTPServerCalls()[0].BindAddr, ShouldEqual, bindAddrAny)
 						})
@@ -230,8 +235,10 @@ func TestInitFailure(t *testing.T) {
 
 						Convey("And all checks try to register", func() {
 							So(mockServiceList.HealthCheck, ShouldBeTrue)
-							So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 1)
+							So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 6)
 							So(hcMockAddFail.AddCheckCalls()[0].Name, ShouldResemble, "API router")
+							So(hcMockAddFail.AddCheckCalls()[1].Name, ShouldResemble, "Census topic cache")
+							So(hcMockAddFail.AddCheckCalls()[2].Name, ShouldResemble, "Navigation cache")
 						})
 					})
 				})
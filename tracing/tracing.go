@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer of the spans started by the controller
+const TracerName = "github.com/ONSdigital/dp-frontend-search-controller"
+
+// Attributes of the spans started by the controller
+const (
+	AttrTemplate         = attribute.Key("search.template")
+	AttrResultCount      = attribute.Key("search.result_count")
+	AttrFilters          = attribute.Key("search.filters")
+	AttrValidationErrors = attribute.Key("search.validation_errors")
+	AttrDependency       = attribute.Key("dependency.name")
+	AttrOperation        = attribute.Key("dependency.operation")
+	AttrAttempt          = attribute.Key("dependency.attempt")
+	AttrCache            = attribute.Key("cache.name")
+	AttrTopicID          = attribute.Key("topic.id")
+	AttrTopicDepth       = attribute.Key("topic.depth")
+)
+
+// Start starts a span as a child of the span in the context, if any. Spans are only exported when OpenTelemetry has
+// been set up, otherwise they cost next to nothing
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, recording the error which failed its work if there is one
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
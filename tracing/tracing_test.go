@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given spans started by a tracer", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(TracerName)
+
+		Convey("When a span is ended without an error", func() {
+			_, span := tracer.Start(context.Background(), "succeeded")
+			End(span, nil)
+
+			Convey("Then its status is left unset", func() {
+				So(recorder.Ended(), ShouldHaveLength, 1)
+				So(recorder.Ended()[0].Status().Code, ShouldEqual, codes.Unset)
+				So(recorder.Ended()[0].Events(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a span is ended with an error", func() {
+			_, span := tracer.Start(context.Background(), "failed")
+			End(span, errors.New("search api failed"))
+
+			Convey("Then the error is recorded and its status is an error", func() {
+				So(recorder.Ended(), ShouldHaveLength, 1)
+				So(recorder.Ended()[0].Status(), ShouldResemble, sdktrace.Status{Code: codes.Error, Description: "search api failed"})
+				So(recorder.Ended()[0].Events(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given OpenTelemetry has not been set up", t, func() {
+		Convey("Then the spans started are not recorded", func() {
+			_, span := Start(context.Background(), "untraced")
+			So(span.IsRecording(), ShouldBeFalse)
+			So(func() { End(span, errors.New("ignored")) }, ShouldNotPanic)
+		})
+	})
+}